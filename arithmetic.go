@@ -0,0 +1,111 @@
+package sqrl
+
+import "fmt"
+
+type ident string
+
+// Ident marks name as identifier, e.g. a column, so it is not bound as value.
+// Ex:
+//     .Set("total", Mul(Ident("qty"), Ident("unit_price"))) == "total = qty * unit_price"
+func Ident(name string) Sqlizer {
+	return ident(name)
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (i ident) ToSql() (string, []interface{}, error) {
+	return string(i), nil, nil
+}
+
+// arithExpr joins operands with an arithmetic operator
+type arithExpr struct {
+	opr        string
+	precedence int
+	operands   []interface{}
+}
+
+// Add builds an expression summing up operands.
+//
+// Operands may be identifiers created with Ident, nested expressions or
+// values which are bound as args. Nested arithmetic expressions are
+// parenthesized where operator precedence requires it.
+// Ex:
+//     Add(Ident("price"), Mul(Ident("price"), 0.2)) == "price + price * ?"
+func Add(operands ...interface{}) Sqlizer {
+	return arithExpr{"+", 1, operands}
+}
+
+// Sub builds an expression subtracting operands from the first one.
+// Ex:
+//     Sub(Ident("stock"), Add(Ident("reserved"), 1)) == "stock - (reserved + ?)"
+func Sub(operands ...interface{}) Sqlizer {
+	return arithExpr{"-", 1, operands}
+}
+
+// Mul builds an expression multiplying operands.
+// Ex:
+//     Mul(Add(Ident("a"), 1), Ident("b")) == "(a + ?) * b"
+func Mul(operands ...interface{}) Sqlizer {
+	return arithExpr{"*", 2, operands}
+}
+
+// Div builds an expression dividing the first operand by the rest.
+func Div(operands ...interface{}) Sqlizer {
+	return arithExpr{"/", 2, operands}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (e arithExpr) ToSql() (sql string, args []interface{}, err error) {
+	if len(e.operands) < 2 {
+		err = fmt.Errorf("arithmetic expression %s needs at least two operands", e.opr)
+		return
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	for i, operand := range e.operands {
+		if i > 0 {
+			buf.WriteString(" ")
+			buf.WriteString(e.opr)
+			buf.WriteString(" ")
+		}
+
+		switch o := operand.(type) {
+		case ident:
+			buf.WriteString(string(o))
+		case arithExpr:
+			// Operators are left associative, so a nested expression on the
+			// right hand side needs parentheses even on equal precedence.
+			parens := o.precedence < e.precedence || (i > 0 && o.precedence == e.precedence)
+
+			opSql, opArgs, err := o.ToSql()
+			if err != nil {
+				return "", nil, err
+			}
+
+			if parens {
+				buf.WriteString("(")
+			}
+			buf.WriteString(opSql)
+			if parens {
+				buf.WriteString(")")
+			}
+			args = append(args, opArgs...)
+		case Sqlizer:
+			opSql, opArgs, err := nestedToSql(o)
+			if err != nil {
+				return "", nil, err
+			}
+
+			buf.WriteString("(")
+			buf.WriteString(opSql)
+			buf.WriteString(")")
+			args = append(args, opArgs...)
+		default:
+			buf.WriteString("?")
+			args = append(args, operand)
+		}
+	}
+
+	sql = buf.String()
+	return
+}
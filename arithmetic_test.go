@@ -0,0 +1,48 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArithExprToSql(t *testing.T) {
+	tests := []struct {
+		expr Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{Mul(Ident("qty"), Ident("unit_price")), "qty * unit_price", nil},
+		{Add(Ident("price"), Mul(Ident("price"), 0.2)), "price + price * ?", []interface{}{0.2}},
+		{Mul(Add(Ident("a"), 1), Ident("b")), "(a + ?) * b", []interface{}{1}},
+		{Sub(Ident("stock"), Add(Ident("reserved"), 1)), "stock - (reserved + ?)", []interface{}{1}},
+		{Sub(Sub(Ident("a"), Ident("b")), Ident("c")), "a - b - c", nil},
+		{Div(Ident("a"), Div(Ident("b"), 2)), "a / (b / ?)", []interface{}{2}},
+		{Add(Ident("a"), Ident("b"), 3), "a + b + ?", []interface{}{3}},
+		{Mul(Expr("COALESCE(discount, ?)", 0), Ident("price")), "(COALESCE(discount, ?)) * price", []interface{}{0}},
+	}
+
+	for _, test := range tests {
+		sql, args, err := test.expr.ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+
+	_, _, err := Add(Ident("a")).ToSql()
+	assert.Error(t, err)
+}
+
+func TestArithExprInUpdate(t *testing.T) {
+	sql, args, err := Update("order_lines").
+		Set("total", Mul(Ident("qty"), Ident("unit_price"))).
+		Set("discounted", Sub(Ident("total"), Mul(Ident("total"), 0.1))).
+		Where(Eq{"order_id": 7}).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "UPDATE order_lines SET total = qty * unit_price, discounted = total - total * $1 WHERE order_id = $2"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{0.1, 7}, args)
+}
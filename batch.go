@@ -0,0 +1,106 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Batch collects statements to run together.
+//
+// A Batch can be built into a single multi statement query with ToSql, for
+// drivers supporting those (e.g. MySQL with multiStatements=true), or run
+// statement by statement with ExecContext. See the pgxrunner package to send
+// it as a pgx batch in a single round trip.
+// Ex:
+//     b := NewBatch(
+//         Insert("users").Values(1, "Joe"),
+//         Update("stats").Set("users", Expr("users + 1")),
+//     )
+//     results, err := b.ExecContext(ctx, db)
+type Batch struct {
+	stmts []Sqlizer
+}
+
+// NewBatch creates a Batch of stmts.
+func NewBatch(stmts ...Sqlizer) *Batch {
+	return &Batch{stmts: stmts}
+}
+
+// Add adds stmts to the batch.
+func (b *Batch) Add(stmts ...Sqlizer) *Batch {
+	b.stmts = append(b.stmts, stmts...)
+	return b
+}
+
+// Len returns the number of statements in the batch.
+func (b *Batch) Len() int {
+	return len(b.stmts)
+}
+
+// Statements returns the statements of the batch.
+func (b *Batch) Statements() []Sqlizer {
+	return b.stmts
+}
+
+// ToSql builds the statements into a single query, separated by ";", and
+// the args of all statements.
+//
+// Numbered placeholders like Dollar restart in every statement, so use
+// Question placeholders for batches built with ToSql.
+func (b *Batch) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.stmts) == 0 {
+		err = fmt.Errorf("batch must have at least one statement")
+		return
+	}
+
+	sql := getBuffer()
+	defer putBuffer(sql)
+	for i, s := range b.stmts {
+		stmtSql, stmtArgs, err := s.ToSql()
+		if err != nil {
+			return "", nil, &BatchError{Index: i, Err: err}
+		}
+		if i > 0 {
+			sql.WriteString("; ")
+		}
+		sql.WriteString(stmtSql)
+		args = append(args, stmtArgs...)
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+// ExecContext Execs the statements in order with db and returns their
+// results. It stops at the first statement failing, returning the results of
+// the statements run before and a *BatchError.
+//
+// Run it with a transaction to apply the statements atomically.
+func (b *Batch) ExecContext(ctx context.Context, db ExecerContext) ([]sql.Result, error) {
+	results := make([]sql.Result, 0, len(b.stmts))
+	for i, s := range b.stmts {
+		res, err := ExecWithContext(ctx, db, s)
+		if err != nil {
+			return results, &BatchError{Index: i, Err: err}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// BatchError is returned when a statement of a Batch fails.
+type BatchError struct {
+	// Index is the index of the failed statement in the batch.
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch statement %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the failed statement.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,48 @@
+package sqrl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchToSql(t *testing.T) {
+	b := NewBatch(Insert("users").Values(1, "Joe")).
+		Add(Update("stats").Set("users", Expr("users + ?", 1)))
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "INSERT INTO users VALUES (?,?); UPDATE stats SET users = users + ?"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{1, "Joe", 1}, args)
+	assert.Equal(t, 2, b.Len())
+}
+
+func TestBatchToSqlErr(t *testing.T) {
+	_, _, err := NewBatch().ToSql()
+	assert.Error(t, err)
+
+	_, _, err = NewBatch(Delete("t"), Select()).ToSql()
+	var batchErr *BatchError
+	if assert.True(t, errors.As(err, &batchErr)) {
+		assert.Equal(t, 1, batchErr.Index)
+	}
+}
+
+func TestBatchExecContext(t *testing.T) {
+	runner := &CaptureRunner{}
+	b := NewBatch(Delete("a"), Delete("b"))
+
+	results, err := b.ExecContext(context.Background(), runner)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, []CapturedQuery{{SQL: "DELETE FROM a"}, {SQL: "DELETE FROM b"}}, runner.Queries)
+
+	runner.Err = errors.New("fail")
+	results, err = b.ExecContext(context.Background(), runner)
+	assert.Len(t, results, 0)
+	assert.EqualError(t, err, "batch statement 0: fail")
+}
@@ -0,0 +1,83 @@
+package sqrl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type bitExpr struct {
+	column string
+	opr    string
+	mask   interface{}
+}
+
+// BitAnd builds a bitwise AND of column and mask.
+// Ex:
+//     .Where(Expr("? > 0", BitAnd("flags", 6))) == "(flags & 6) > 0"
+func BitAnd(column string, mask interface{}) Sqlizer {
+	return bitExpr{column, "&", mask}
+}
+
+// BitOr builds a bitwise OR of column and mask.
+// Ex:
+//     .Set("flags", BitOr("flags", 4)) == "flags = (flags | ?)"
+func BitOr(column string, mask interface{}) Sqlizer {
+	return bitExpr{column, "|", mask}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (e bitExpr) ToSql() (sql string, args []interface{}, err error) {
+	sql = fmt.Sprintf("(%s %s ?)", e.column, e.opr)
+	args = []interface{}{e.mask}
+	return
+}
+
+// HasFlag is syntactic sugar for use with Where/Having methods.
+// It matches rows where all bits of the mask are set.
+// Ex:
+//     .Where(HasFlag{"flags": 4}) == "(flags & 4) = 4"
+type HasFlag map[string]interface{}
+
+func (hf HasFlag) toSql(anyBit bool) (sql string, args []interface{}, err error) {
+	keys := make([]string, 0, len(hf))
+	for key := range hf {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	exprs := make([]string, len(keys))
+	for i, key := range keys {
+		mask := hf[key]
+		if isListType(mask) {
+			return "", nil, fmt.Errorf("cannot use array or slice as a bit mask")
+		}
+
+		if anyBit {
+			exprs[i] = fmt.Sprintf("(%s & ?) <> 0", key)
+			args = append(args, mask)
+		} else {
+			exprs[i] = fmt.Sprintf("(%s & ?) = ?", key)
+			args = append(args, mask, mask)
+		}
+	}
+
+	sql = strings.Join(exprs, " AND ")
+	return
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (hf HasFlag) ToSql() (sql string, args []interface{}, err error) {
+	return hf.toSql(false)
+}
+
+// HasAnyFlag is syntactic sugar for use with Where/Having methods.
+// It matches rows where at least one bit of the mask is set.
+// Ex:
+//     .Where(HasAnyFlag{"flags": 6}) == "(flags & 6) <> 0"
+type HasAnyFlag HasFlag
+
+// ToSql builds the query into a SQL string and bound args.
+func (hf HasAnyFlag) ToSql() (sql string, args []interface{}, err error) {
+	return HasFlag(hf).toSql(true)
+}
@@ -0,0 +1,51 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitExprToSql(t *testing.T) {
+	sql, args, err := Update("users").
+		Set("flags", BitOr("flags", 4)).
+		Where(Eq{"id": 1}).
+		ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "UPDATE users SET flags = (flags | ?) WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{4, 1}, args)
+
+	sql, args, err = BitAnd("flags", 6).ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "(flags & ?)", sql)
+	assert.Equal(t, []interface{}{6}, args)
+}
+
+func TestHasFlagToSql(t *testing.T) {
+	b := HasFlag{"perms": 2, "flags": 4}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "(flags & ?) = ? AND (perms & ?) = ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{4, 4, 2, 2}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestHasAnyFlagToSql(t *testing.T) {
+	b := HasAnyFlag{"flags": 6}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "(flags & ?) <> 0"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{6}
+	assert.Equal(t, expectedArgs, args)
+
+	_, _, err = HasAnyFlag{"flags": []int{1, 2}}.ToSql()
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+package sqrl
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+)
+
+// maxPooledBuffer is the capacity above which buffers aren't pooled, so a
+// single huge query doesn't keep its memory alive.
+const maxPooledBuffer = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return &bytes.Buffer{}
+	},
+}
+
+// getBuffer returns an empty buffer from the pool. Return it with putBuffer
+// once its content was copied, e.g. with String.
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns buf to the pool.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// sqlWriter is written to by the helpers shared by queries and parts of
+// them, implemented by *bytes.Buffer and *queryBuffer.
+type sqlWriter interface {
+	io.Writer
+	io.StringWriter
+}
+
+var queryBufferPool = sync.Pool{
+	New: func() interface{} {
+		return &queryBuffer{buf: &bytes.Buffer{}}
+	},
+}
+
+// queryBuffer is the buffer a statement is written to. With a numbered
+// placeholder format like Dollar it numbers the ? placeholders while they
+// are written, so the query isn't copied once more to replace them. Other
+// formats replace them in finish.
+type queryBuffer struct {
+	buf *bytes.Buffer
+	f   PlaceholderFormat
+	nf  numberedFormat
+
+	// n is the number of placeholders written
+	n int
+	// pending is set if the last byte written was a ?, which is either a
+	// placeholder or the start of an escaped ??
+	pending bool
+	err     error
+
+	// d is the dialect of the builder writing the query, see withDialect
+	d Dialect
+}
+
+// getQueryBuffer returns an empty queryBuffer replacing placeholders with
+// f, or none if f is nil. Return it with putQueryBuffer.
+func getQueryBuffer(f PlaceholderFormat) *queryBuffer {
+	q := queryBufferPool.Get().(*queryBuffer)
+	q.buf.Reset()
+	q.f, q.nf, q.n, q.pending, q.err, q.d = f, nil, 0, false, nil, nil
+	if _, ok := f.(argsFormat); !ok {
+		q.nf, _ = f.(numberedFormat)
+	}
+	return q
+}
+
+// putQueryBuffer returns q to the pool.
+func putQueryBuffer(q *queryBuffer) {
+	if q.buf.Cap() > maxPooledBuffer {
+		return
+	}
+	q.f, q.nf, q.d = nil, nil, nil
+	queryBufferPool.Put(q)
+}
+
+// Write writes p like WriteString.
+func (q *queryBuffer) Write(p []byte) (int, error) {
+	if q.nf == nil {
+		return q.buf.Write(p)
+	}
+	return q.WriteString(string(p))
+}
+
+// WriteByte writes c like WriteString.
+func (q *queryBuffer) WriteByte(c byte) error {
+	if q.nf == nil {
+		return q.buf.WriteByte(c)
+	}
+	_, err := q.WriteString(string(c))
+	return err
+}
+
+// WriteString writes s, replacing its placeholders with a numbered format.
+func (q *queryBuffer) WriteString(s string) (int, error) {
+	if q.nf == nil {
+		return q.buf.WriteString(s)
+	}
+
+	n := len(s)
+	for len(s) > 0 {
+		if q.pending {
+			q.pending = false
+			if s[0] == '?' { // escape ?? => ?
+				q.buf.WriteByte('?')
+				s = s[1:]
+				continue
+			}
+			q.placeholder()
+		}
+
+		p := strings.IndexByte(s, '?')
+		if p == -1 {
+			q.buf.WriteString(s)
+			break
+		}
+		q.buf.WriteString(s[:p])
+		q.pending = true
+		s = s[p+1:]
+	}
+	return n, nil
+}
+
+func (q *queryBuffer) placeholder() {
+	q.n++
+	if err := q.nf.writePlaceholder(q.buf, q.n); err != nil && q.err == nil {
+		q.err = err
+	}
+}
+
+// String returns the SQL written so far.
+func (q *queryBuffer) String() string {
+	return q.buf.String()
+}
+
+// finish returns the SQL written with its placeholders replaced.
+func (q *queryBuffer) finish(args []interface{}) (string, []interface{}, error) {
+	if q.f == nil {
+		return q.buf.String(), args, nil
+	}
+	if q.nf == nil {
+		return replaceFormat(q.f, q.buf.String(), args)
+	}
+
+	if q.pending {
+		q.pending = false
+		q.placeholder()
+	}
+	if q.err != nil {
+		return "", nil, q.err
+	}
+	if len(args) == 0 {
+		args = nil
+	}
+	return q.buf.String(), args, nil
+}
+
+// appendQuery writes the query written by build to w. A queryBuffer is
+// written to directly, so nested queries are numbered along with the
+// enclosing one, other writers get the query in a single Write.
+func appendQuery(w io.Writer, args []interface{}, d Dialect, build func(*queryBuffer, []interface{}) ([]interface{}, error)) ([]interface{}, error) {
+	q, ok := w.(*queryBuffer)
+	if !ok {
+		q = getQueryBuffer(nil)
+		defer putQueryBuffer(q)
+	}
+
+	start := q.buf.Len()
+	prev := q.d
+	if d != nil {
+		q.d = d
+	}
+	args, err := build(q, args)
+	q.d = prev
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		if err = checkOperators(d, string(q.buf.Bytes()[start:])); err != nil {
+			return nil, err
+		}
+	}
+
+	if !ok {
+		if _, err = w.Write(q.buf.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,82 @@
+package sqrl
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferPool(t *testing.T) {
+	buf := getBuffer()
+	buf.WriteString("SELECT 1")
+	putBuffer(buf)
+	assert.Equal(t, 0, getBuffer().Len())
+
+	large := getBuffer()
+	large.WriteString(strings.Repeat("x", maxPooledBuffer+1))
+	putBuffer(large)
+}
+
+func TestBufferPoolConcurrentToSql(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				sql, args, err := Select("id").From("users").Where(Eq{"id": i}).PlaceholderFormat(Dollar).ToSql()
+				assert.NoError(t, err)
+				assert.Equal(t, "SELECT id FROM users WHERE id = $1", sql)
+				assert.Equal(t, []interface{}{i}, args)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestQueryBuffer(t *testing.T) {
+	q := getQueryBuffer(Dollar)
+	q.WriteString("SELECT a ?")
+	q.WriteString("? b FROM t WHERE c = ?")
+	q.WriteByte(' ')
+	q.Write([]byte("AND d IN (?,?)"))
+	sql, args, err := q.finish(nil)
+	putQueryBuffer(q)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a ? b FROM t WHERE c = $1 AND d IN ($2,$3)", sql)
+	assert.Nil(t, args)
+
+	q = getQueryBuffer(Question)
+	q.WriteString("a = ? AND b ?? c")
+	sql, _, err = q.finish([]interface{}{1})
+	putQueryBuffer(q)
+	assert.NoError(t, err)
+	assert.Equal(t, "a = ? AND b ?? c", sql)
+
+	q = getQueryBuffer(nil)
+	q.WriteString("a = ? AND b ?? c")
+	sql, _, err = q.finish(nil)
+	putQueryBuffer(q)
+	assert.NoError(t, err)
+	assert.Equal(t, "a = ? AND b ?? c", sql)
+}
+
+func TestQueryBufferFuncErr(t *testing.T) {
+	f := FuncPlaceholderFormat(func(buf *bytes.Buffer, idx int) error {
+		if idx > 1 {
+			return errors.New("too many placeholders")
+		}
+		buf.WriteString("@p")
+		return nil
+	})
+
+	q := getQueryBuffer(f)
+	q.WriteString("a = ? AND b = ?")
+	_, _, err := q.finish(nil)
+	putQueryBuffer(q)
+	assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+)
+
+// CapturedQuery is a query recorded by a CaptureRunner.
+type CapturedQuery struct {
+	SQL  string
+	Args []interface{}
+}
+
+// CaptureRunner is a Runner that never hits a database. It records every
+// query run with it and returns canned results instead, which makes it
+// useful for "plan only" modes and for asserting on generated queries in
+// tests.
+//
+// The zero value is ready to use: Exec reports 0 affected rows, Query
+// fails with ErrNoCapturedRows and QueryRow returns a row whose Scan fails
+// with sql.ErrNoRows. It may be used by several goroutines at once.
+// Ex:
+//     runner := &CaptureRunner{}
+//     Update("users").Set("name", "Joe").Where(Eq{"id": 1}).RunWith(runner).Exec()
+//     runner.Last().SQL == "UPDATE users SET name = ? WHERE id = ?"
+type CaptureRunner struct {
+	// Queries holds the queries run so far, in order. It must not be
+	// accessed while queries run, use Last or Captured instead.
+	Queries []CapturedQuery
+
+	// Result is returned by Exec.
+	Result sql.Result
+	// Rows is returned by Query.
+	Rows *sql.Rows
+	// Row is returned by QueryRow.
+	Row RowScanner
+	// Err is returned by every query, if set.
+	Err error
+
+	mu sync.Mutex
+}
+
+// ErrNoCapturedRows is returned by Query of a CaptureRunner without Rows.
+var ErrNoCapturedRows = errors.New("cannot query; CaptureRunner has no Rows")
+
+// Last returns the last query run, or a zero CapturedQuery if there is none.
+func (r *CaptureRunner) Last() CapturedQuery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.Queries) == 0 {
+		return CapturedQuery{}
+	}
+	return r.Queries[len(r.Queries)-1]
+}
+
+// Captured returns a copy of the queries run so far, in order.
+func (r *CaptureRunner) Captured() []CapturedQuery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]CapturedQuery(nil), r.Queries...)
+}
+
+// Reset forgets the queries run so far.
+func (r *CaptureRunner) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Queries = nil
+}
+
+func (r *CaptureRunner) capture(query string, args []interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Queries = append(r.Queries, CapturedQuery{SQL: query, Args: args})
+}
+
+// Exec records the query and returns Result.
+func (r *CaptureRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return r.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext records the query and returns Result.
+func (r *CaptureRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.capture(query, args)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	if r.Result == nil {
+		return driver.RowsAffected(0), nil
+	}
+	return r.Result, nil
+}
+
+// Query records the query and returns Rows.
+func (r *CaptureRunner) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext records the query and returns Rows, or ErrNoCapturedRows if
+// they aren't set.
+func (r *CaptureRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	r.capture(query, args)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	if r.Rows == nil {
+		return nil, ErrNoCapturedRows
+	}
+	return r.Rows, nil
+}
+
+// QueryRow records the query and returns Row.
+func (r *CaptureRunner) QueryRow(query string, args ...interface{}) RowScanner {
+	return r.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext records the query and returns Row.
+func (r *CaptureRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	r.capture(query, args)
+	if r.Err != nil {
+		return &Row{err: r.Err}
+	}
+	if r.Row == nil {
+		return &Row{err: sql.ErrNoRows}
+	}
+	return r.Row
+}
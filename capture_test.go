@@ -0,0 +1,73 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureRunner(t *testing.T) {
+	runner := &CaptureRunner{}
+	var _ Runner = runner
+
+	res, err := Update("users").Set("name", "Joe").Where(Eq{"id": 1}).RunWith(runner).Exec()
+	assert.NoError(t, err)
+	n, _ := res.RowsAffected()
+	assert.Equal(t, int64(0), n)
+
+	_, err = Select("id").From("users").RunWith(runner).Query()
+	assert.Equal(t, ErrNoCapturedRows, err)
+	var users []struct{ ID int }
+	err = Select("id").From("users").RunWith(runner).QueryStructs(context.Background(), &users)
+	assert.Equal(t, ErrNoCapturedRows, err)
+
+	err = Select("name").From("users").Where("id = ?", 2).RunWith(runner).Scan()
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	expected := []CapturedQuery{
+		{"UPDATE users SET name = ? WHERE id = ?", []interface{}{"Joe", 1}},
+		{"SELECT id FROM users", nil},
+		{"SELECT id FROM users", nil},
+		{"SELECT name FROM users WHERE id = ?", []interface{}{2}},
+	}
+	assert.Equal(t, expected, runner.Captured())
+	assert.Equal(t, expected[3], runner.Last())
+
+	runner.Reset()
+	assert.Equal(t, CapturedQuery{}, runner.Last())
+}
+
+func TestCaptureRunnerCanned(t *testing.T) {
+	stub := &RowStub{}
+	runner := &CaptureRunner{Row: stub}
+
+	err := Select("name").From("users").RunWith(runner).Scan()
+	assert.NoError(t, err)
+	assert.True(t, stub.Scanned)
+
+	runner.Err = errors.New("dry run")
+	_, err = Delete("users").RunWith(runner).Exec()
+	assert.Equal(t, runner.Err, err)
+	assert.Equal(t, "DELETE FROM users", runner.Last().SQL)
+}
+
+func TestCaptureRunnerConcurrent(t *testing.T) {
+	runner := &CaptureRunner{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := Delete("users").Where(Eq{"id": i}).RunWith(runner).Exec()
+			assert.NoError(t, err)
+			runner.Last()
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, runner.Captured(), 8)
+}
@@ -0,0 +1,141 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CommentBuilder builds SQL COMMENT ON statements, which document the
+// objects of a schema.
+type CommentBuilder struct {
+	StatementBuilderType
+
+	object string
+	name   string
+	text   string
+}
+
+// NewCommentBuilder creates new instance of CommentBuilder
+func NewCommentBuilder(b StatementBuilderType) *CommentBuilder {
+	return &CommentBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *CommentBuilder) RunWith(runner BaseRunnerContext) *CommentBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *CommentBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *CommentBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext.
+func (b *CommentBuilder) Timeout(d time.Duration) *CommentBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on. MySQL
+// comments on tables with ALTER TABLE, and can't comment on other objects.
+//
+// See StatementBuilderType.Dialect.
+func (b *CommentBuilder) Dialect(d Dialect) *CommentBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote the name of the object.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *CommentBuilder) QuoteIdentifiers() *CommentBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+//
+// Databases don't bind args in DDL statements, so the comment is escaped
+// into the statement as a string literal. Comments with NUL bytes or
+// invalid UTF-8 can't be escaped and return an error.
+func (b *CommentBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.object) == 0 || len(b.name) == 0 {
+		err = fmt.Errorf("comment statements must specify an object type and name")
+		return
+	}
+	if !isObjectType(b.object) {
+		err = fmt.Errorf("invalid object type %q", b.object)
+		return
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifier(b.name, identName); err != nil {
+			return
+		}
+	}
+
+	d := dialectName(b.dialect)
+	object := strings.ToUpper(b.object)
+	name := b.quoteName(b.name, identName)
+	var text string
+	if text, err = escapeLiteral(b.text, b.dialect); err != nil {
+		return
+	}
+
+	switch d {
+	case MySQLName:
+		if object != "TABLE" {
+			err = fmt.Errorf("%s does not support comments on %s", d, object)
+			return
+		}
+		sqlStr = "ALTER TABLE " + name + " COMMENT = " + text
+	case SQLiteName, SQLServerName:
+		err = fmt.Errorf("%s does not support COMMENT ON", d)
+	default:
+		sqlStr = "COMMENT ON " + object + " " + name + " IS " + text
+	}
+	return
+}
+
+// isObjectType reports whether s is made of words only, like TABLE or
+// MATERIALIZED VIEW.
+func isObjectType(s string) bool {
+	for _, word := range strings.Split(s, " ") {
+		if word == "" {
+			return false
+		}
+		for _, r := range word {
+			if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// On sets the type and name of the object to comment on.
+func (b *CommentBuilder) On(object, name string) *CommentBuilder {
+	b.object = object
+	b.name = name
+	return b
+}
+
+// Is sets the comment.
+func (b *CommentBuilder) Is(text string) *CommentBuilder {
+	b.text = text
+	return b
+}
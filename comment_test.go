@@ -0,0 +1,49 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentBuilderToSql(t *testing.T) {
+	sql, args, err := CommentOn("TABLE", "users", "Registered users, see the user's guide").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "COMMENT ON TABLE users IS 'Registered users, see the user''s guide'", sql)
+	assert.Nil(t, args)
+
+	sql, _, err = CommentOnColumn("users", "email", "Login").Dialect(Postgres).QuoteIdentifiers().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `COMMENT ON COLUMN "users"."email" IS 'Login'`, sql)
+
+	sql, _, err = CommentOn("materialized view", "totals", "").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "COMMENT ON MATERIALIZED VIEW totals IS ''", sql)
+
+	sql, _, err = CommentOn("TABLE", "users", `C:\users`).Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "ALTER TABLE `users` COMMENT = 'C:\\\\users'", sql)
+
+	sql, _, err = CommentOn("TABLE", "users", `a\' ; DROP TABLE x; --`).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `COMMENT ON TABLE users IS E'a\\'' ; DROP TABLE x; --'`, sql)
+
+	sql, _, err = CommentOn("TABLE", "users", `a\' ; DROP TABLE x; --`).Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "ALTER TABLE `users` COMMENT = 'a\\\\'' ; DROP TABLE x; --'", sql)
+}
+
+func TestCommentBuilderToSqlErr(t *testing.T) {
+	_, _, err := CommentOn("", "users", "").ToSql()
+	assert.Error(t, err)
+	_, _, err = CommentOn("TABLE users IS 'x'; --", "users", "").ToSql()
+	assert.Error(t, err)
+	_, _, err = CommentOnColumn("users", "email", "").Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+	_, _, err = CommentOn("TABLE", "users", "").Dialect(SQLServer).ToSql()
+	assert.Error(t, err)
+	_, _, err = CommentOn("TABLE", "users", "a\x00b").ToSql()
+	assert.Error(t, err)
+	_, _, err = CommentOn("TABLE", "users", "\xbf'").Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+}
@@ -0,0 +1,253 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CreateIndexBuilder builds SQL CREATE INDEX statements.
+type CreateIndexBuilder struct {
+	StatementBuilderType
+
+	name         string
+	table        string
+	unique       bool
+	concurrently bool
+	method       string
+	columns      []indexColumn
+	include      []string
+	whereParts   []Sqlizer
+}
+
+// indexColumn is a column of an index, or an expression if expr is set.
+type indexColumn struct {
+	sql  string
+	expr bool
+}
+
+// NewCreateIndexBuilder creates new instance of CreateIndexBuilder
+func NewCreateIndexBuilder(b StatementBuilderType) *CreateIndexBuilder {
+	return &CreateIndexBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *CreateIndexBuilder) RunWith(runner BaseRunnerContext) *CreateIndexBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *CreateIndexBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *CreateIndexBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext.
+func (b *CreateIndexBuilder) Timeout(d time.Duration) *CreateIndexBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on.
+//
+// See StatementBuilderType.Dialect.
+func (b *CreateIndexBuilder) Dialect(d Dialect) *CreateIndexBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its index, table and column names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *CreateIndexBuilder) QuoteIdentifiers() *CreateIndexBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+//
+// Databases don't bind args in DDL statements, so the args of the WHERE
+// clause are escaped into the statement as literals and no args are
+// returned. Only NULL, booleans, numbers and strings can be escaped.
+func (b *CreateIndexBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.table) == 0 {
+		err = fmt.Errorf("create index statements must specify a table")
+		return
+	}
+	if len(b.columns) == 0 {
+		err = fmt.Errorf("create index statements must have at least one column")
+		return
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifiers(); err != nil {
+			return
+		}
+	}
+	d := dialectName(b.dialect)
+	if b.concurrently && d != "" && d != PostgresName {
+		err = fmt.Errorf("%s does not support CREATE INDEX CONCURRENTLY", d)
+		return
+	}
+	if len(b.include) > 0 && d != "" && d != PostgresName && d != SQLServerName {
+		err = fmt.Errorf("%s does not support INCLUDE columns", d)
+		return
+	}
+	if len(b.whereParts) > 0 && (d == MySQLName || d == OracleName) {
+		err = fmt.Errorf("%s does not support partial indexes", d)
+		return
+	}
+
+	sql := getBuffer()
+	defer putBuffer(sql)
+
+	sql.WriteString("CREATE ")
+	if b.unique {
+		sql.WriteString("UNIQUE ")
+	}
+	sql.WriteString("INDEX ")
+	if b.concurrently {
+		sql.WriteString("CONCURRENTLY ")
+	}
+	if len(b.name) > 0 {
+		sql.WriteString(b.quoteName(b.name, identName))
+		sql.WriteString(" ")
+	}
+	sql.WriteString("ON ")
+	sql.WriteString(b.quoteName(b.table, identName))
+
+	if len(b.method) > 0 && d != MySQLName {
+		sql.WriteString(" USING ")
+		sql.WriteString(b.method)
+	}
+
+	sql.WriteString(" (")
+	for i, c := range b.columns {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		if c.expr {
+			sql.WriteString("(")
+			sql.WriteString(c.sql)
+			sql.WriteString(")")
+		} else {
+			sql.WriteString(b.quoteName(c.sql, identOrderBy))
+		}
+	}
+	sql.WriteString(")")
+
+	// MySQL takes the index type after the columns
+	if len(b.method) > 0 && d == MySQLName {
+		sql.WriteString(" USING ")
+		sql.WriteString(b.method)
+	}
+
+	if len(b.include) > 0 {
+		sql.WriteString(" INCLUDE (")
+		sql.WriteString(strings.Join(b.quoteNames(b.include, identName), ", "))
+		sql.WriteString(")")
+	}
+
+	if len(b.whereParts) > 0 {
+		where := getBuffer()
+		defer putBuffer(where)
+
+		var whereArgs []interface{}
+		whereArgs, err = appendToSql(b.whereParts, where, " AND ", nil)
+		if err != nil {
+			return
+		}
+		var pred string
+		pred, err = escapeSql(Expr(where.String(), whereArgs...), b.dialect)
+		if err != nil {
+			return
+		}
+		sql.WriteString(" WHERE ")
+		sql.WriteString(pred)
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+// Name sets the name of the index. PostgreSQL generates a name if it isn't
+// set.
+func (b *CreateIndexBuilder) Name(name string) *CreateIndexBuilder {
+	b.name = name
+	return b
+}
+
+// On sets the table to create the index on.
+func (b *CreateIndexBuilder) On(table string) *CreateIndexBuilder {
+	b.table = table
+	return b
+}
+
+// Unique creates a UNIQUE index.
+func (b *CreateIndexBuilder) Unique() *CreateIndexBuilder {
+	b.unique = true
+	return b
+}
+
+// Concurrently adds the PostgreSQL CONCURRENTLY option, which builds the
+// index without locking out writes to the table. It can't be run in a
+// transaction.
+func (b *CreateIndexBuilder) Concurrently() *CreateIndexBuilder {
+	b.concurrently = true
+	return b
+}
+
+// Using sets the index method, like btree, hash, gin or gist.
+func (b *CreateIndexBuilder) Using(method string) *CreateIndexBuilder {
+	b.method = method
+	return b
+}
+
+// Columns adds columns to the index, optionally followed by ASC or DESC.
+func (b *CreateIndexBuilder) Columns(columns ...string) *CreateIndexBuilder {
+	for _, column := range columns {
+		b.columns = append(b.columns, indexColumn{sql: column})
+	}
+	return b
+}
+
+// Expression adds an expression to the index, which is put into the
+// statement in parentheses.
+// Ex:
+//     CreateIndex("users_email_idx").On("users").Unique().Expression("lower(email)")
+//     == "CREATE UNIQUE INDEX users_email_idx ON users ((lower(email)))"
+func (b *CreateIndexBuilder) Expression(expr string) *CreateIndexBuilder {
+	b.columns = append(b.columns, indexColumn{sql: expr, expr: true})
+	return b
+}
+
+// Include adds the columns stored in the index without being part of its
+// key, to allow index-only scans on PostgreSQL and SQL Server.
+func (b *CreateIndexBuilder) Include(columns ...string) *CreateIndexBuilder {
+	b.include = append(b.include, columns...)
+	return b
+}
+
+// Where adds an expression to the WHERE clause of a partial index, which
+// only indexes the rows matching it. Expressions are ANDed together.
+//
+// See SelectBuilder.Where for the types pred can have.
+// Ex:
+//     CreateIndex("orders_open_idx").On("orders").Columns("created_at").Where(Eq{"status": "open"})
+//     == "CREATE INDEX orders_open_idx ON orders (created_at) WHERE status = 'open'"
+func (b *CreateIndexBuilder) Where(pred interface{}, args ...interface{}) *CreateIndexBuilder {
+	b.whereParts = append(b.whereParts, newWherePart(pred, args...))
+	return b
+}
@@ -0,0 +1,72 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateIndexBuilderToSql(t *testing.T) {
+	sql, args, err := CreateIndex("orders_user_idx").On("orders").Columns("user_id", "created_at DESC").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE INDEX orders_user_idx ON orders (user_id, created_at DESC)", sql)
+	assert.Nil(t, args)
+
+	sql, args, err = CreateIndex("orders_open_idx").On("orders").
+		Unique().
+		Concurrently().
+		Using("btree").
+		Columns("user_id").
+		Expression("lower(code)").
+		Include("total").
+		Where(Eq{"status": "open"}).
+		Where("total > ?", 100).
+		Dialect(Postgres).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE UNIQUE INDEX CONCURRENTLY orders_open_idx ON orders USING btree "+
+		"(user_id, (lower(code))) INCLUDE (total) WHERE status = 'open' AND total > 100", sql)
+	assert.Nil(t, args)
+
+	sql, _, err = CreateIndex("docs_draft_idx").On("docs").Columns("id").
+		Where("data ?? 'draft' AND title <> '??'").
+		Where("owner = ?", "o'brien").
+		Dialect(Postgres).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE INDEX docs_draft_idx ON docs (id) WHERE data ? 'draft' AND title <> '??' AND owner = 'o''brien'", sql)
+
+	sql, _, err = CreateIndex("").On("docs").Using("gin").Columns("tags").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE INDEX ON docs USING gin (tags)", sql)
+
+	sql, _, err = CreateIndex("order").On("orders").Using("BTREE").Columns("id").Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE INDEX `order` ON `orders` (`id`) USING BTREE", sql)
+}
+
+func TestCreateIndexBuilderToSqlErr(t *testing.T) {
+	_, _, err := CreateIndex("i").Columns("a").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = CreateIndex("i").On("t").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = CreateIndex("i").On("t").Columns("a").Concurrently().Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = CreateIndex("i").On("t").Columns("a").Include("b").Dialect(SQLite).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = CreateIndex("i").On("t").Columns("a").Where("b").Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = CreateIndex("i").On("t").Columns("a").Where("b = ?").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = CreateIndex("i").On("t").Columns("a").Where("b = ?", []byte("x")).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = StatementBuilder.StrictIdentifiers().CreateIndex("i").On("t").Columns("a); DROP TABLE t; --").ToSql()
+	assert.Error(t, err)
+}
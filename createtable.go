@@ -0,0 +1,240 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CreateTableBuilder builds SQL CREATE TABLE statements.
+type CreateTableBuilder struct {
+	StatementBuilderType
+
+	table       string
+	temporary   bool
+	ifNotExists bool
+	columns     []columnDef
+	constraints []tableConstraint
+}
+
+type columnDef struct {
+	name        string
+	typ         string
+	constraints []string
+}
+
+// tableConstraint is a table constraint, kind on columns, or sql as it is
+// if kind is empty.
+type tableConstraint struct {
+	kind       string
+	columns    []string
+	refTable   string
+	refColumns []string
+	actions    []string
+	sql        string
+}
+
+// NewCreateTableBuilder creates new instance of CreateTableBuilder
+func NewCreateTableBuilder(b StatementBuilderType) *CreateTableBuilder {
+	return &CreateTableBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *CreateTableBuilder) RunWith(runner BaseRunnerContext) *CreateTableBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *CreateTableBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *CreateTableBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext.
+func (b *CreateTableBuilder) Timeout(d time.Duration) *CreateTableBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on.
+//
+// See StatementBuilderType.Dialect.
+func (b *CreateTableBuilder) Dialect(d Dialect) *CreateTableBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its table and column names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *CreateTableBuilder) QuoteIdentifiers() *CreateTableBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *CreateTableBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.table) == 0 {
+		err = fmt.Errorf("create table statements must specify a table")
+		return
+	}
+	if len(b.columns) == 0 {
+		err = fmt.Errorf("create table statements must have at least one column")
+		return
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifiers(); err != nil {
+			return
+		}
+	}
+
+	sql := getBuffer()
+	defer putBuffer(sql)
+
+	sql.WriteString("CREATE ")
+	if b.temporary {
+		switch dialectName(b.dialect) {
+		case SQLServerName:
+			err = fmt.Errorf("%s does not support CREATE TEMPORARY TABLE, prefix the table name with #", b.dialect.Name())
+			return
+		case OracleName:
+			sql.WriteString("GLOBAL ")
+		}
+		sql.WriteString("TEMPORARY ")
+	}
+	sql.WriteString("TABLE ")
+	if b.ifNotExists {
+		if !supports(b.dialect, FeatureIfNotExists) {
+			err = fmt.Errorf("%s does not support CREATE TABLE IF NOT EXISTS", b.dialect.Name())
+			return
+		}
+		sql.WriteString("IF NOT EXISTS ")
+	}
+	sql.WriteString(b.quoteName(b.table, identName))
+
+	sql.WriteString(" (")
+	for i, c := range b.columns {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString(b.quoteName(c.name, identName))
+		sql.WriteString(" ")
+		sql.WriteString(c.typ)
+		for _, constraint := range c.constraints {
+			sql.WriteString(" ")
+			sql.WriteString(constraint)
+		}
+	}
+	for _, c := range b.constraints {
+		sql.WriteString(", ")
+		if c.kind == "" {
+			sql.WriteString(c.sql)
+			continue
+		}
+		sql.WriteString(c.kind)
+		sql.WriteString(" (")
+		sql.WriteString(strings.Join(b.quoteNames(c.columns, identName), ", "))
+		sql.WriteString(")")
+		if c.refTable != "" {
+			sql.WriteString(" REFERENCES ")
+			sql.WriteString(b.quoteName(c.refTable, identName))
+			sql.WriteString(" (")
+			sql.WriteString(strings.Join(b.quoteNames(c.refColumns, identName), ", "))
+			sql.WriteString(")")
+		}
+		for _, action := range c.actions {
+			sql.WriteString(" ")
+			sql.WriteString(action)
+		}
+	}
+	sql.WriteString(")")
+
+	sqlStr = sql.String()
+	return
+}
+
+// Table sets the name of the table to create.
+func (b *CreateTableBuilder) Table(table string) *CreateTableBuilder {
+	b.table = table
+	return b
+}
+
+// Temporary creates a temporary table, dropped at the end of the session.
+// Oracle creates a GLOBAL TEMPORARY table, whose rows are private to the
+// session.
+func (b *CreateTableBuilder) Temporary() *CreateTableBuilder {
+	b.temporary = true
+	return b
+}
+
+// IfNotExists adds IF NOT EXISTS, so the statement does nothing if the
+// table already exists.
+func (b *CreateTableBuilder) IfNotExists() *CreateTableBuilder {
+	b.ifNotExists = true
+	return b
+}
+
+// Column adds a column of type typ to the table. The constraints, like
+// NOT NULL or DEFAULT 0, are put into the statement as they are.
+// Ex:
+//     CreateTable("users").
+//         Column("id", "BIGINT", "PRIMARY KEY").
+//         Column("name", "VARCHAR(100)", "NOT NULL", "DEFAULT ''")
+//     == "CREATE TABLE users (id BIGINT PRIMARY KEY, name VARCHAR(100) NOT NULL DEFAULT '')"
+func (b *CreateTableBuilder) Column(name, typ string, constraints ...string) *CreateTableBuilder {
+	b.columns = append(b.columns, columnDef{name: name, typ: typ, constraints: constraints})
+	return b
+}
+
+// PrimaryKey adds a PRIMARY KEY constraint on the columns to the table.
+func (b *CreateTableBuilder) PrimaryKey(columns ...string) *CreateTableBuilder {
+	b.constraints = append(b.constraints, tableConstraint{kind: "PRIMARY KEY", columns: columns})
+	return b
+}
+
+// Unique adds a UNIQUE constraint on the columns to the table.
+func (b *CreateTableBuilder) Unique(columns ...string) *CreateTableBuilder {
+	b.constraints = append(b.constraints, tableConstraint{kind: "UNIQUE", columns: columns})
+	return b
+}
+
+// ForeignKey adds a FOREIGN KEY constraint on the columns referencing the
+// columns of refTable to the table. The actions, like ON DELETE CASCADE,
+// are put into the statement as they are.
+// Ex:
+//     CreateTable("orders").
+//         Column("id", "BIGINT").
+//         Column("user_id", "BIGINT").
+//         ForeignKey([]string{"user_id"}, "users", []string{"id"}, "ON DELETE CASCADE")
+//     == "CREATE TABLE orders (id BIGINT, user_id BIGINT, " +
+//         "FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE)"
+func (b *CreateTableBuilder) ForeignKey(columns []string, refTable string, refColumns []string, actions ...string) *CreateTableBuilder {
+	b.constraints = append(b.constraints, tableConstraint{
+		kind:       "FOREIGN KEY",
+		columns:    columns,
+		refTable:   refTable,
+		refColumns: refColumns,
+		actions:    actions,
+	})
+	return b
+}
+
+// Constraint adds a table constraint, like CHECK (price > 0), to the table.
+// It is put into the statement as it is.
+func (b *CreateTableBuilder) Constraint(constraint string) *CreateTableBuilder {
+	b.constraints = append(b.constraints, tableConstraint{sql: constraint})
+	return b
+}
@@ -0,0 +1,73 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTableBuilderToSql(t *testing.T) {
+	b := CreateTable("orders").
+		IfNotExists().
+		Column("id", "BIGINT", "NOT NULL").
+		Column("user_id", "BIGINT").
+		Column("code", "VARCHAR(20)", "NOT NULL", "DEFAULT ''").
+		PrimaryKey("id").
+		Unique("user_id", "code").
+		ForeignKey([]string{"user_id"}, "users", []string{"id"}, "ON DELETE CASCADE").
+		Constraint("CHECK (id > 0)")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS orders ("+
+		"id BIGINT NOT NULL, user_id BIGINT, code VARCHAR(20) NOT NULL DEFAULT '', "+
+		"PRIMARY KEY (id), UNIQUE (user_id, code), "+
+		"FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE, CHECK (id > 0))", sql)
+	assert.Nil(t, args)
+
+	sql, _, err = b.Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS `orders` ("+
+		"`id` BIGINT NOT NULL, `user_id` BIGINT, `code` VARCHAR(20) NOT NULL DEFAULT '', "+
+		"PRIMARY KEY (`id`), UNIQUE (`user_id`, `code`), "+
+		"FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE, CHECK (id > 0))", sql)
+}
+
+func TestCreateTableBuilderTemporary(t *testing.T) {
+	sql, _, err := CreateTable("tmp").Temporary().Column("id", "INT").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE TEMPORARY TABLE tmp (id INT)", sql)
+
+	sql, _, err = CreateTable("tmp").Temporary().Column("id", "NUMBER").Dialect(Oracle).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE GLOBAL TEMPORARY TABLE tmp (id NUMBER)", sql)
+
+	_, _, err = CreateTable("tmp").Temporary().Column("id", "INT").Dialect(SQLServer).ToSql()
+	assert.Error(t, err)
+}
+
+func TestCreateTableBuilderToSqlErr(t *testing.T) {
+	_, _, err := CreateTable("").Column("id", "INT").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = CreateTable("t").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = CreateTable("t").IfNotExists().Column("id", "INT").Dialect(SQLServer).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = StatementBuilder.StrictIdentifiers().CreateTable("t").Column("id; DROP TABLE t", "INT").ToSql()
+	assert.Error(t, err)
+}
+
+func TestCreateTableBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	b := CreateTable("t").Column("id", "INT").RunWith(db)
+
+	_, err := b.Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE t (id INT)", db.LastExecSql)
+
+	_, err = CreateTable("t").Column("id", "INT").Exec()
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
@@ -0,0 +1,205 @@
+package sqrl
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DebugSqlizer returns the SQL of s with its args inlined as literals, for
+// debug output and logs. If s is a builder, the literals are rendered for
+// its dialect. Errors are returned in the string, like "[ToSql error: ...]".
+//
+// The result is not safe against SQL injection and must never be executed
+// with untrusted args.
+func DebugSqlizer(s Sqlizer) string {
+	var d Dialect
+	if b, ok := s.(interface{ builderDialect() Dialect }); ok {
+		d = b.builderDialect()
+	}
+	query, err := Interpolate(s, d)
+	if err != nil {
+		return fmt.Sprintf("[ToSql error: %s]", err)
+	}
+	return query
+}
+
+// Interpolate builds s and inlines its args as literals of dialect d, so the
+// query can be copied from logs and run on the database: e.g. booleans are
+// rendered as TRUE or FALSE for Postgres and as 1 or 0 for MySQL, byte
+// strings as hex literals. Args implementing driver.Valuer are inlined as
+// their value.
+//
+// The result is not safe against SQL injection and must never be executed
+// with untrusted args.
+// Ex:
+//     Interpolate(Select("*").From("users").Where(Eq{"active": true}), MySQL)
+//     == "SELECT * FROM users WHERE active = 1"
+func Interpolate(s Sqlizer, d Dialect) (string, error) {
+	return interpolate(s, d, sqlLiteral)
+}
+
+// interpolate builds s and inlines its args as literals rendered by literal.
+func interpolate(s Sqlizer, d Dialect, literal func(interface{}, Dialect) (string, error)) (string, error) {
+	query, args, err := s.ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	numbered := false
+	dialect := dialectName(d)
+	scanPlaceholders(query, dialect, func(_, _ int, n int) {
+		numbered = numbered || n > 0
+	})
+
+	buf := &bytes.Buffer{}
+	last, next := 0, 0
+	scanPlaceholders(query, dialect, func(start, end int, n int) {
+		if err != nil || numbered != (n > 0) {
+			return
+		}
+		if n == 0 {
+			next++
+			n = next
+		}
+		if n > len(args) {
+			err = fmt.Errorf("placeholder %d has no arg, only %d given", n, len(args))
+			return
+		}
+		var lit string
+		if lit, err = literal(args[n-1], d); err != nil {
+			return
+		}
+		buf.WriteString(query[last:start])
+		buf.WriteString(lit)
+		last = end
+	})
+	if err != nil {
+		return "", err
+	}
+	buf.WriteString(query[last:])
+	return buf.String(), nil
+}
+
+func (b StatementBuilderType) builderDialect() Dialect {
+	return b.dialect
+}
+
+// scanPlaceholders calls fn with the positions of the placeholders of query
+// outside of string literals and quoted identifiers, and their number for
+// $n and :n placeholders or 0 for ?. Escaped ?? are skipped.
+func scanPlaceholders(query, dialect string, fn func(start, end int, n int)) {
+	for i := 0; i < len(query); i++ {
+		if n := quotedLen(query, i, dialect); n > 0 {
+			i += n - 1
+			continue
+		}
+		switch c := query[i]; c {
+		case '?':
+			if i+1 < len(query) && query[i+1] == '?' {
+				i++
+				continue
+			}
+			fn(i, i+1, 0)
+		case '$', ':':
+			if c == ':' && i+1 < len(query) && query[i+1] == ':' {
+				// :: cast
+				i++
+				continue
+			}
+			j := i + 1
+			for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+				j++
+			}
+			if j > i+1 {
+				if n, err := strconv.Atoi(query[i+1 : j]); err == nil && n > 0 {
+					fn(i, j, n)
+				}
+				i = j - 1
+			}
+		}
+	}
+}
+
+// sqlLiteral renders arg as a literal of dialect d.
+func sqlLiteral(arg interface{}, d Dialect) (string, error) {
+	if v, ok := arg.(driver.Valuer); ok {
+		var err error
+		if arg, err = v.Value(); err != nil {
+			return "", err
+		}
+	}
+
+	name := dialectName(d)
+	switch v := arg.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		switch name {
+		case "", PostgresName:
+			if v {
+				return "TRUE", nil
+			}
+			return "FALSE", nil
+		}
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case string:
+		return quoteString(v, name), nil
+	case []byte:
+		return bytesLiteral(v, name), nil
+	case time.Time:
+		return timeLiteral(v, name), nil
+	case fmt.Stringer:
+		return quoteString(v.String(), name), nil
+	}
+	return "", fmt.Errorf("cannot interpolate arg of type %T", arg)
+}
+
+// quoteString renders s as string literal. MySQL treats backslashes in
+// strings as escape characters.
+func quoteString(s, dialect string) string {
+	s = strings.Replace(s, "'", "''", -1)
+	if dialect == MySQLName {
+		s = strings.Replace(s, `\`, `\\`, -1)
+	}
+	return "'" + s + "'"
+}
+
+func bytesLiteral(b []byte, dialect string) string {
+	h := hex.EncodeToString(b)
+	switch dialect {
+	case PostgresName:
+		return `'\x` + h + `'::bytea`
+	case SQLServerName:
+		return "0x" + h
+	case OracleName:
+		return "HEXTORAW('" + h + "')"
+	}
+	return "X'" + h + "'"
+}
+
+func timeLiteral(t time.Time, dialect string) string {
+	switch dialect {
+	case MySQLName:
+		// MySQL DATETIME and TIMESTAMP have no time zone
+		return t.Format("'2006-01-02 15:04:05.999999'")
+	case SQLServerName:
+		return t.Format("'2006-01-02T15:04:05.9999999-07:00'")
+	case OracleName:
+		return "TIMESTAMP " + t.Format("'2006-01-02 15:04:05.999999999 -07:00'")
+	}
+	return t.Format("'2006-01-02 15:04:05.999999-07:00'")
+}
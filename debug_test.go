@@ -0,0 +1,50 @@
+package sqrl
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolate(t *testing.T) {
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := Select("*").From("users").
+		Where(Eq{"active": true}).
+		Where("created_at > ?", ts).
+		Where("name = ?", "O'Brien").
+		Where("data = ?", []byte{0xca, 0xfe})
+
+	tests := []struct {
+		dialect Dialect
+		sql     string
+	}{
+		{nil, "SELECT * FROM users WHERE active = TRUE AND created_at > '2020-01-02 03:04:05+00:00' AND name = 'O''Brien' AND data = X'cafe'"},
+		{Postgres, `SELECT * FROM users WHERE active = TRUE AND created_at > '2020-01-02 03:04:05+00:00' AND name = 'O''Brien' AND data = '\xcafe'::bytea`},
+		{MySQL, "SELECT * FROM users WHERE active = 1 AND created_at > '2020-01-02 03:04:05' AND name = 'O''Brien' AND data = X'cafe'"},
+		{SQLServer, "SELECT * FROM users WHERE active = 1 AND created_at > '2020-01-02T03:04:05+00:00' AND name = 'O''Brien' AND data = 0xcafe"},
+		{Oracle, "SELECT * FROM users WHERE active = 1 AND created_at > TIMESTAMP '2020-01-02 03:04:05 +00:00' AND name = 'O''Brien' AND data = HEXTORAW('cafe')"},
+	}
+
+	for _, test := range tests {
+		sql, err := Interpolate(s, test.dialect)
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+	}
+}
+
+func TestInterpolateNumbered(t *testing.T) {
+	s := Select("data::jsonb ?? 'key'").From("t").Where("a = ? AND b = ?", nil, sql.NullInt64{Int64: 7, Valid: true}).
+		PlaceholderFormat(Dollar)
+	sql, err := Interpolate(s, Postgres)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT data::jsonb ? 'key' FROM t WHERE a = NULL AND b = 7", sql)
+}
+
+func TestDebugSqlizer(t *testing.T) {
+	s := StatementBuilder.Dialect(Oracle).Select("*").From("users").Where("id = ? AND active = ?", 1, false)
+	assert.Equal(t, "SELECT * FROM users WHERE id = 1 AND active = 0", DebugSqlizer(s))
+
+	assert.Equal(t, `SELECT 'a\\b'`, DebugSqlizer(Select().Column("?", `a\b`).Dialect(MySQL)))
+}
@@ -1,12 +1,13 @@
 package sqrl
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Builder
@@ -31,15 +32,17 @@ type DeleteBuilder struct {
 	offsetValid bool
 
 	suffixes exprs
+
+	memo *memo
 }
 
 // NewDeleteBuilder creates new instance of DeleteBuilder
 func NewDeleteBuilder(b StatementBuilderType) *DeleteBuilder {
-	return &DeleteBuilder{StatementBuilderType: b}
+	return &DeleteBuilder{StatementBuilderType: b, memo: newMemo(b.memoize)}
 }
 
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
-func (b *DeleteBuilder) RunWith(runner BaseRunner) *DeleteBuilder {
+func (b *DeleteBuilder) RunWith(runner BaseRunnerContext) *DeleteBuilder {
 	b.runWith = wrapRunner(runner)
 	return b
 }
@@ -54,9 +57,24 @@ func (b *DeleteBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 	if b.runWith == nil {
 		return nil, ErrRunnerNotSet
 	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
 	return ExecWithContext(ctx, b.runWith, b)
 }
 
+// ExecAffected builds and Execs the query with the Runner set by RunWith
+// using given context and returns the number of rows affected.
+//
+// See ExecAffectedWithContext.
+func (b *DeleteBuilder) ExecAffected(ctx context.Context) (int64, error) {
+	if b.runWith == nil {
+		return 0, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecAffectedWithContext(ctx, b.runWith, b)
+}
+
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b *DeleteBuilder) Query() (*sql.Rows, error) {
 	return b.QueryContext(context.Background())
@@ -67,7 +85,7 @@ func (b *DeleteBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
 	if b.runWith == nil {
 		return nil, ErrRunnerNotSet
 	}
-	return QueryWithContext(ctx, b.runWith, b)
+	return QueryWithContext(b.queryTimeoutContext(ctx), b.runWith, b)
 }
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
@@ -84,7 +102,8 @@ func (b *DeleteBuilder) QueryRowContext(ctx context.Context) RowScanner {
 	if !ok {
 		return &Row{err: ErrRunnerNotQueryRunnerContext}
 	}
-	return QueryRowWithContext(ctx, queryRower, b)
+	ctx, cancel := b.timeoutContext(ctx)
+	return &timeoutRow{RowScanner: QueryRowWithContext(ctx, queryRower, b), cancel: cancel}
 }
 
 // Scan is a shortcut for QueryRow().Scan.
@@ -92,6 +111,57 @@ func (b *DeleteBuilder) Scan(dest ...interface{}) error {
 	return b.QueryRow().Scan(dest...)
 }
 
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext. For Query the timeout covers reading the rows.
+func (b *DeleteBuilder) Timeout(d time.Duration) *DeleteBuilder {
+	b.timeout = d
+	return b
+}
+
+// StrictPlaceholders makes ToSql verify that the raw SQL fragments of the
+// query have as many ? placeholders as args.
+func (b *DeleteBuilder) StrictPlaceholders() *DeleteBuilder {
+	b.strictPlaceholders = true
+	return b
+}
+
+// StrictIdentifiers makes ToSql verify that the table and column names of
+// the query are identifiers, and one of allowed if given.
+//
+// See StatementBuilderType.StrictIdentifiers.
+func (b *DeleteBuilder) StrictIdentifiers(allowed ...string) *DeleteBuilder {
+	b.strictIdentifiers = true
+	b.allowedIdentifiers = identifierSet(allowed)
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on.
+//
+// See StatementBuilderType.Dialect.
+func (b *DeleteBuilder) Dialect(d Dialect) *DeleteBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its table and column names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *DeleteBuilder) QuoteIdentifiers() *DeleteBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// Memoize makes ToSql cache the query until it is changed.
+//
+// See StatementBuilderType.Memoize.
+func (b *DeleteBuilder) Memoize() *DeleteBuilder {
+	b.memoize = true
+	if b.memo == nil {
+		b.memo = newMemo(true)
+	}
+	return b
+}
+
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b *DeleteBuilder) PlaceholderFormat(f PlaceholderFormat) *DeleteBuilder {
@@ -99,17 +169,64 @@ func (b *DeleteBuilder) PlaceholderFormat(f PlaceholderFormat) *DeleteBuilder {
 	return b
 }
 
+// ToSqlNamed builds the query into a SQL string with named placeholders.
+//
+// See ToSqlNamed.
+func (b *DeleteBuilder) ToSqlNamed(f NamedFormat) (string, []sql.NamedArg, error) {
+	nb := *b
+	nb.placeholderFormat = Question
+	return ToSqlNamed(&nb, f)
+}
+
 // ToSql builds the query into a SQL string and bound args.
-func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+func (b *DeleteBuilder) ToSql() (string, []interface{}, error) {
+	if b.memoize {
+		return memoized(b.memo, b, b.toSql)
+	}
+	return b.toSql()
+}
+
+func (b *DeleteBuilder) toSql() (string, []interface{}, error) {
+	sql := getQueryBuffer(b.placeholderFormat)
+	defer putQueryBuffer(sql)
+
+	args := makeArgs(countExprArgs(b.prefixes) + countArgs(b.joins, b.usingParts, b.whereParts) + countExprArgs(b.suffixes))
+	args, err := appendQuery(sql, args, b.dialect, b.appendToSql)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql.finish(args)
+}
+
+// AppendToSql writes the query to w and appends its args to args, to build
+// it into a larger statement without an intermediate string. Placeholders
+// are written as ?, to be replaced by the enclosing statement.
+func (b *DeleteBuilder) AppendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
+	return appendQuery(w, args, b.dialect, b.appendToSql)
+}
+
+func (b *DeleteBuilder) appendToSql(sql *queryBuffer, in []interface{}) (args []interface{}, err error) {
+	args = in
 	if len(b.from) == 0 {
 		err = fmt.Errorf("delete statements must specify a From table")
 		return
 	}
-
-	sql := &bytes.Buffer{}
+	if b.strictPlaceholders {
+		if err = checkPlaceholders(b.placeholderParts()...); err != nil {
+			return
+		}
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifiers(); err != nil {
+			return
+		}
+	}
 
 	if len(b.prefixes) > 0 {
-		args, _ = b.prefixes.AppendToSql(sql, " ", args)
+		args, err = b.prefixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
@@ -117,12 +234,12 @@ func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	// following condition helps to avoid duplicate "from" value in DELETE query
 	// e.g. "DELETE a FROM a ..." which is valid for MySQL but not for PostgreSQL
 	if len(b.what) > 0 && (len(b.what) != 1 || b.what[0] != b.from) {
-		sql.WriteString(strings.Join(b.what, ", "))
+		sql.WriteString(strings.Join(b.quoteNames(b.what, identName), ", "))
 		sql.WriteString(" ")
 	}
 
 	sql.WriteString("FROM ")
-	sql.WriteString(b.from)
+	sql.WriteString(b.quoteName(b.from, identAliased))
 
 	if len(b.joins) > 0 {
 		sql.WriteString(" ")
@@ -150,7 +267,7 @@ func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if len(b.orderBys) > 0 {
 		sql.WriteString(" ORDER BY ")
-		sql.WriteString(strings.Join(b.orderBys, ", "))
+		sql.WriteString(strings.Join(b.quoteNames(b.orderBys, identOrderBy), ", "))
 	}
 
 	// TODO: limit == 0 and offswt == 0 are valid. Need to go dbr way and implement offsetValid and limitValid
@@ -165,6 +282,10 @@ func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	}
 
 	if len(b.returning) > 0 {
+		if !supports(b.dialect, FeatureReturning) {
+			err = fmt.Errorf("%s does not support RETURNING", b.dialect.Name())
+			return
+		}
 		args, err = b.returning.AppendToSql(sql, args)
 		if err != nil {
 			return
@@ -173,10 +294,12 @@ func (b *DeleteBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = b.suffixes.AppendToSql(sql, " ", args)
+		args, err = b.suffixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
 	}
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
 	return
 }
 
@@ -311,6 +434,7 @@ func (b *DeleteBuilder) Copy() *DeleteBuilder {
 	vb := *b
 	// ... then make a shallow copy
 	nb := vb
+	nb.memo = newMemo(nb.memoize)
 
 	// Then copy all reference types of the struct to make a deep copy
 	nb.returning = make(returning, len(vb.returning))
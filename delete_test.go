@@ -248,3 +248,13 @@ func TestDeleteCopy(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "DELETE FROM test WHERE a = ? AND c = ?", sql)
 }
+
+func BenchmarkDeleteBuilderToSqlAllocs(b *testing.B) {
+	qb := Delete("users").Where(Eq{"id": []int{1, 2, 3}}).PlaceholderFormat(Dollar)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		qb.ToSql()
+	}
+}
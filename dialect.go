@@ -0,0 +1,338 @@
+package sqrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect describes the database queries and dialect aware expressions are
+// rendered for. It is set on builders with Dialect, and on expressions with
+// For.
+//
+// Postgres, MySQL, SQLite, SQLServer and Oracle are the dialects known to
+// sqrl. Other databases can be supported by implementing Dialect, e.g. by
+// embedding a known dialect and overriding some of its methods.
+type Dialect interface {
+	// Name returns the name of the database, e.g. "postgres" or "mysql".
+	Name() string
+
+	PlaceholderFormatter
+	IdentifierQuoter
+
+	// LimitStyle returns how Limit and Offset of selects are rendered.
+	LimitStyle() LimitStyle
+
+	// UpsertStyle returns how the database handles conflicts on insert.
+	UpsertStyle() UpsertStyle
+
+	// Supports reports whether the database supports the feature f.
+	Supports(f Feature) bool
+}
+
+// LimitStyle is the way a Dialect limits the rows of selects.
+type LimitStyle int
+
+const (
+	// LimitOffset renders LIMIT n OFFSET m.
+	LimitOffset LimitStyle = iota
+
+	// OffsetFetch renders the standard FETCH FIRST n ROWS ONLY, or
+	// OFFSET m ROWS FETCH NEXT n ROWS ONLY.
+	OffsetFetch
+
+	// TopOffsetFetch renders OFFSET m ROWS FETCH NEXT n ROWS ONLY after
+	// ORDER BY, and SELECT TOP n without it, like SQL Server.
+	TopOffsetFetch
+)
+
+// UpsertStyle is the way a Dialect handles conflicts on insert.
+type UpsertStyle int
+
+const (
+	// UpsertOnConflict is INSERT ... ON CONFLICT, see InsertBuilder.OnConflict.
+	UpsertOnConflict UpsertStyle = iota
+
+	// UpsertOnDuplicateKey is INSERT ... ON DUPLICATE KEY UPDATE, see
+	// mysql.OnDuplicateKeyUpdate.
+	UpsertOnDuplicateKey
+
+	// UpsertMerge is MERGE, see MergeBuilder.
+	UpsertMerge
+)
+
+// Feature is an optional feature of a database, see Dialect.Supports.
+type Feature int
+
+const (
+	// FeatureReturning is the RETURNING clause of INSERT, UPDATE and DELETE.
+	FeatureReturning Feature = iota
+
+	// FeatureILike is the case insensitive ILIKE operator.
+	FeatureILike
+
+	// FeatureDistinctOn is SELECT DISTINCT ON (...).
+	FeatureDistinctOn
+
+	// FeatureInsertOr is INSERT OR action INTO, see InsertBuilder.Or.
+	FeatureInsertOr
+
+	// FeatureConflictOnConstraint is ON CONFLICT ON CONSTRAINT name.
+	FeatureConflictOnConstraint
+
+	// FeatureSelectWithoutFrom is SELECT without FROM clause. Without it,
+	// selects without From read from DUAL.
+	FeatureSelectWithoutFrom
+
+	// FeatureIfNotExists is CREATE ... IF NOT EXISTS.
+	FeatureIfNotExists
+
+	// FeatureIfExists is DROP ... IF EXISTS.
+	FeatureIfExists
+
+	// FeatureMerge is the MERGE statement, see MergeBuilder.
+	FeatureMerge
+)
+
+// Names of the dialects known to sqrl.
+const (
+	PostgresName  = "postgres"
+	MySQLName     = "mysql"
+	SQLiteName    = "sqlite"
+	SQLServerName = "sqlserver"
+	OracleName    = "oracle"
+)
+
+var (
+	// Postgres is the Dialect of PostgreSQL.
+	Postgres Dialect = namedDialect(PostgresName)
+
+	// MySQL is the Dialect of MySQL and MariaDB.
+	MySQL Dialect = namedDialect(MySQLName)
+
+	// SQLite is the Dialect of SQLite.
+	SQLite Dialect = namedDialect(SQLiteName)
+
+	// SQLServer is the Dialect of Microsoft SQL Server.
+	SQLServer Dialect = namedDialect(SQLServerName)
+
+	// Oracle is the Dialect of Oracle Database.
+	Oracle Dialect = namedDialect(OracleName)
+)
+
+type namedDialect string
+
+func (d namedDialect) Name() string {
+	return string(d)
+}
+
+func (d namedDialect) LimitStyle() LimitStyle {
+	switch d {
+	case OracleName:
+		return OffsetFetch
+	case SQLServerName:
+		return TopOffsetFetch
+	}
+	return LimitOffset
+}
+
+func (d namedDialect) UpsertStyle() UpsertStyle {
+	switch d {
+	case MySQLName:
+		return UpsertOnDuplicateKey
+	case SQLServerName, OracleName:
+		return UpsertMerge
+	}
+	return UpsertOnConflict
+}
+
+// namedFeatures lists the features of known dialects.
+var namedFeatures = map[namedDialect]map[Feature]bool{
+	PostgresName: {
+		FeatureReturning:            true,
+		FeatureILike:                true,
+		FeatureDistinctOn:           true,
+		FeatureConflictOnConstraint: true,
+		FeatureSelectWithoutFrom:    true,
+		FeatureIfNotExists:          true,
+		FeatureIfExists:             true,
+		FeatureMerge:                true,
+	},
+	MySQLName: {
+		FeatureSelectWithoutFrom: true,
+		FeatureIfNotExists:       true,
+		FeatureIfExists:          true,
+	},
+	SQLiteName: {
+		FeatureReturning:         true,
+		FeatureInsertOr:          true,
+		FeatureSelectWithoutFrom: true,
+		FeatureIfNotExists:       true,
+		FeatureIfExists:          true,
+	},
+	SQLServerName: {
+		FeatureSelectWithoutFrom: true,
+		FeatureIfExists:          true,
+		FeatureMerge:             true,
+	},
+	OracleName: {
+		FeatureMerge: true,
+	},
+}
+
+func (d namedDialect) Supports(f Feature) bool {
+	return namedFeatures[d][f]
+}
+
+// dialectAliases maps the names of database/sql drivers to their dialect.
+var dialectAliases = map[string]Dialect{
+	PostgresName:  Postgres,
+	"postgresql":  Postgres,
+	"pgx":         Postgres,
+	MySQLName:     MySQL,
+	"mariadb":     MySQL,
+	SQLiteName:    SQLite,
+	"sqlite3":     SQLite,
+	SQLServerName: SQLServer,
+	"mssql":       SQLServer,
+	OracleName:    Oracle,
+	"godror":      Oracle,
+	"oci8":        Oracle,
+}
+
+// LookupDialect returns the Dialect named name, which is the name of the
+// dialect or of a database/sql driver of its database, e.g. "postgres",
+// "pgx", "mysql", "sqlite3", "sqlserver" or "godror".
+func LookupDialect(name string) (Dialect, error) {
+	d, ok := dialectAliases[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown dialect %q", name)
+	}
+	return d, nil
+}
+
+// ForDialect returns a StatementBuilderType configured for the dialect
+// named name, like StatementBuilder.Dialect, so the database is configured
+// once. It panics if the dialect is unknown, see LookupDialect.
+// Ex:
+//     psql := ForDialect("postgres")
+//     psql.Select("*").From("users").Where("id = ?", 1) == "SELECT * FROM users WHERE id = $1"
+func ForDialect(name string) StatementBuilderType {
+	d, err := LookupDialect(name)
+	if err != nil {
+		panic(err)
+	}
+	return StatementBuilder.Dialect(d)
+}
+
+// dialectName returns the name of d or an empty string if d is not set.
+func dialectName(d Dialect) string {
+	if d == nil {
+		return ""
+	}
+	return d.Name()
+}
+
+// limitStyle returns the LimitStyle of d, or LimitOffset if d is not set.
+func limitStyle(d Dialect) LimitStyle {
+	if d == nil {
+		return LimitOffset
+	}
+	return d.LimitStyle()
+}
+
+// supports reports whether d supports f. Without dialect all features are
+// allowed, so queries are built as they are written.
+func supports(d Dialect, f Feature) bool {
+	return d == nil || d.Supports(f)
+}
+
+// PlaceholderFormatter is the interface that wraps the PlaceholderFormat
+// method of Dialect.
+type PlaceholderFormatter interface {
+	// PlaceholderFormat returns the placeholder format of the database.
+	PlaceholderFormat() PlaceholderFormat
+}
+
+func (d namedDialect) PlaceholderFormat() PlaceholderFormat {
+	switch d {
+	case PostgresName:
+		return Dollar
+	case OracleName:
+		return Colon
+	}
+	return Question
+}
+
+// Dialect sets the Dialect of the database the queries of child builders
+// are run on. Unless another PlaceholderFormat than Question was set, the
+// placeholder format of the dialect is used, e.g. Dollar for Postgres or
+// Colon for Oracle.
+//
+// With MySQL, the table and column names passed to child builders as
+// strings, e.g. to Columns, From, OrderBy, Into or Set, are quoted with
+// backticks, so reserved words can be used as names. Strings which aren't
+// identifiers, like expressions, are left as they are.
+// Ex:
+//     StatementBuilder.Dialect(MySQL).Select("id", "order").From("orders o").OrderBy("order DESC")
+//     == "SELECT `id`, `order` FROM `orders` `o` ORDER BY `order` DESC"
+func (b StatementBuilderType) Dialect(d Dialect) StatementBuilderType {
+	b.setDialect(d)
+	return b
+}
+
+// setDialect sets the dialect d, and its placeholder format unless a format
+// other than the default Question was set.
+func (b *StatementBuilderType) setDialect(d Dialect) {
+	b.dialect = d
+	if d != nil && (b.placeholderFormat == nil || b.placeholderFormat == Question) {
+		b.placeholderFormat = d.PlaceholderFormat()
+	}
+}
+
+// dialectExpr is implemented by expressions rendered differently for each
+// dialect, like NextVal. Unless their dialect is set, they are rendered for
+// the dialect of the builder they are used in.
+type dialectExpr interface {
+	defaultDialect(d Dialect) Sqlizer
+}
+
+// withDialect returns s with d as its dialect if it is a dialectExpr
+// without one.
+func withDialect(s Sqlizer, d Dialect) Sqlizer {
+	if e, ok := s.(dialectExpr); ok && d != nil {
+		return e.defaultDialect(d)
+	}
+	return s
+}
+
+// checkOperators returns an error if sql uses an operator d does not
+// support, which would fail with a syntax error on the database.
+func checkOperators(d Dialect, sql string) error {
+	if !supports(d, FeatureILike) && containsKeyword(sql, "ILIKE", dialectName(d)) {
+		return fmt.Errorf("%s does not support ILIKE, use LowerLikeOr", d.Name())
+	}
+	return nil
+}
+
+// containsKeyword reports whether sql has the word keyword, in any case,
+// outside of string literals and quoted identifiers.
+func containsKeyword(sql, keyword, dialect string) bool {
+	for i := 0; i < len(sql); i++ {
+		if n := quotedLen(sql, i, dialect); n > 0 {
+			i += n - 1
+			continue
+		}
+		if i > 0 && isWordChar(sql[i-1]) {
+			continue
+		}
+		end := i + len(keyword)
+		if end <= len(sql) && strings.EqualFold(sql[i:end], keyword) && (end == len(sql) || !isWordChar(sql[end])) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,126 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectPlaceholderFormat(t *testing.T) {
+	assert.Equal(t, Dollar, Postgres.PlaceholderFormat())
+	assert.Equal(t, Question, MySQL.PlaceholderFormat())
+	assert.Equal(t, Question, SQLite.PlaceholderFormat())
+	assert.Equal(t, Question, SQLServer.PlaceholderFormat())
+	assert.Equal(t, Colon, Oracle.PlaceholderFormat())
+}
+
+func TestDialectStyles(t *testing.T) {
+	assert.Equal(t, LimitOffset, Postgres.LimitStyle())
+	assert.Equal(t, OffsetFetch, Oracle.LimitStyle())
+	assert.Equal(t, TopOffsetFetch, SQLServer.LimitStyle())
+
+	assert.Equal(t, UpsertOnConflict, SQLite.UpsertStyle())
+	assert.Equal(t, UpsertOnDuplicateKey, MySQL.UpsertStyle())
+	assert.Equal(t, UpsertMerge, Oracle.UpsertStyle())
+}
+
+func TestDialectSupports(t *testing.T) {
+	assert.True(t, Postgres.Supports(FeatureReturning))
+	assert.True(t, SQLite.Supports(FeatureReturning))
+	assert.False(t, MySQL.Supports(FeatureReturning))
+	assert.True(t, SQLite.Supports(FeatureInsertOr))
+	assert.False(t, Postgres.Supports(FeatureInsertOr))
+	assert.False(t, Oracle.Supports(FeatureSelectWithoutFrom))
+	assert.False(t, SQLServer.Supports(FeatureIfNotExists))
+	assert.True(t, SQLServer.Supports(FeatureIfExists))
+	assert.True(t, Oracle.Supports(FeatureMerge))
+	assert.False(t, MySQL.Supports(FeatureMerge))
+	assert.True(t, supports(nil, FeatureDistinctOn))
+}
+
+// cockroach is a custom dialect based on Postgres, paginating with FETCH.
+type cockroach struct {
+	Dialect
+}
+
+func (cockroach) Name() string {
+	return "cockroach"
+}
+
+func (cockroach) LimitStyle() LimitStyle {
+	return OffsetFetch
+}
+
+func TestCustomDialect(t *testing.T) {
+	sql, args, err := StatementBuilder.Dialect(cockroach{Postgres}).
+		Select("id").From("users").Where("active = ?", true).OrderBy("id").Limit(10).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE active = $1 ORDER BY id FETCH FIRST 10 ROWS ONLY", sql)
+	assert.Equal(t, []interface{}{true}, args)
+	assert.Equal(t, `"order"`, QuoteIdentifier(cockroach{Postgres}, "order"))
+}
+
+func TestContainsKeyword(t *testing.T) {
+	assert.True(t, containsKeyword("name ILIKE ?", "ILIKE", ""))
+	assert.True(t, containsKeyword("name ilike ?", "ILIKE", ""))
+	assert.False(t, containsKeyword("name LIKE 'x ILIKE y'", "ILIKE", ""))
+	assert.False(t, containsKeyword(`"ILIKE" = 1`, "ILIKE", ""))
+	assert.False(t, containsKeyword("is_ilike = 1", "ILIKE", ""))
+	assert.False(t, containsKeyword(`name = 'it\'s ILIKE'`, "ILIKE", MySQLName))
+	assert.False(t, containsKeyword("[ILIKE] = 1", "ILIKE", SQLServerName))
+	assert.True(t, containsKeyword("a[1] ILIKE ?", "ILIKE", PostgresName))
+}
+
+func TestDialectCapabilityErrors(t *testing.T) {
+	invalid := []Sqlizer{
+		Insert("users").Values(1).Returning("id").Dialect(MySQL),
+		Update("users").Set("name", "Joe").Returning("id").Dialect(SQLServer),
+		Delete("users").Returning("id").Dialect(Oracle),
+		Select("*").From("users").Where(ILikeOr{"name": "joe%"}).Dialect(SQLServer),
+		Select("*").From("users").Where("name ILIKE ?", "joe%").Dialect(MySQL),
+		Update("users").Set("active", false).Where("name ILIKE ?", "joe%").Dialect(SQLite),
+		Select("id").DistinctOn("user_id").From("orders").Dialect(MySQL),
+	}
+	for _, s := range invalid {
+		_, _, err := s.ToSql()
+		assert.Error(t, err)
+	}
+
+	valid := []Sqlizer{
+		Insert("users").Values(1).Returning("id").Dialect(SQLite),
+		Select("*").From("users").Where(ILikeOr{"name": "joe%"}).Dialect(Postgres),
+		Select("*").From("users").Where(ILikeOr{"name": "joe%"}),
+		Select("*").From("users").Where("note = 'ILIKE'").Dialect(MySQL),
+	}
+	for _, s := range valid {
+		_, _, err := s.ToSql()
+		assert.NoError(t, err)
+	}
+}
+
+func TestLookupDialect(t *testing.T) {
+	d, err := LookupDialect("pgx")
+	assert.NoError(t, err)
+	assert.Equal(t, Postgres, d)
+
+	d, err = LookupDialect("MSSQL")
+	assert.NoError(t, err)
+	assert.Equal(t, SQLServer, d)
+
+	_, err = LookupDialect("db2")
+	assert.Error(t, err)
+}
+
+func TestForDialect(t *testing.T) {
+	sql, args, err := ForDialect("postgres").Select("*").From("users").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = ForDialect("mysql").Select("order").From("orders").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT `order` FROM `orders`", sql)
+
+	assert.Panics(t, func() { ForDialect("db2") })
+}
@@ -0,0 +1,156 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+)
+
+// stubDriver is a database/sql driver recording the statements run with it,
+// for tests needing a real *sql.DB, *sql.Tx or *sql.Rows.
+type stubDriver struct {
+	mu   sync.Mutex
+	log  []string
+	args []driver.NamedValue
+	cols []string
+	rows [][]driver.Value
+}
+
+func (d *stubDriver) record(s string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, s)
+}
+
+func (d *stubDriver) recordQuery(query string, args []driver.NamedValue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, query)
+	d.args = args
+}
+
+// Log returns the statements run and the transaction commands issued.
+func (d *stubDriver) Log() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.log...)
+}
+
+// Args returns the args of the last statement run.
+func (d *stubDriver) Args() []driver.NamedValue {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.args
+}
+
+func (d *stubDriver) Open(name string) (driver.Conn, error) {
+	return &stubConn{d}, nil
+}
+
+func (d *stubDriver) Connect(context.Context) (driver.Conn, error) {
+	return &stubConn{d}, nil
+}
+
+func (d *stubDriver) Driver() driver.Driver {
+	return d
+}
+
+// openStubDB returns a *sql.DB using d.
+func openStubDB(d *stubDriver) *sql.DB {
+	return sql.OpenDB(d)
+}
+
+type stubConn struct {
+	d *stubDriver
+}
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) {
+	return &stubStmt{c: c, query: query}, nil
+}
+
+func (c *stubConn) Close() error {
+	return nil
+}
+
+func (c *stubConn) Begin() (driver.Tx, error) {
+	c.d.record("BEGIN")
+	return &stubTx{c.d}, nil
+}
+
+func (c *stubConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.d.recordQuery(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *stubConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.recordQuery(query, args)
+	return &stubRows{cols: c.d.cols, rows: c.d.rows}, nil
+}
+
+// stubStmt is a prepared statement, run with the methods of its conn.
+type stubStmt struct {
+	c     *stubConn
+	query string
+}
+
+func (s *stubStmt) Close() error {
+	return nil
+}
+
+func (s *stubStmt) NumInput() int {
+	return -1
+}
+
+func (s *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+
+func (s *stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, driver.ErrSkip
+}
+
+func (s *stubStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.c.ExecContext(ctx, s.query, args)
+}
+
+func (s *stubStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.c.QueryContext(ctx, s.query, args)
+}
+
+type stubTx struct {
+	d *stubDriver
+}
+
+func (t *stubTx) Commit() error {
+	t.d.record("COMMIT")
+	return nil
+}
+
+func (t *stubTx) Rollback() error {
+	t.d.record("ROLLBACK")
+	return nil
+}
+
+type stubRows struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string {
+	return r.cols
+}
+
+func (r *stubRows) Close() error {
+	return nil
+}
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,284 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DropTableBuilder builds SQL DROP TABLE statements.
+type DropTableBuilder struct {
+	StatementBuilderType
+
+	tables   []string
+	ifExists bool
+	cascade  bool
+}
+
+// NewDropTableBuilder creates new instance of DropTableBuilder
+func NewDropTableBuilder(b StatementBuilderType) *DropTableBuilder {
+	return &DropTableBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *DropTableBuilder) RunWith(runner BaseRunnerContext) *DropTableBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *DropTableBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *DropTableBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext.
+func (b *DropTableBuilder) Timeout(d time.Duration) *DropTableBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on.
+//
+// See StatementBuilderType.Dialect.
+func (b *DropTableBuilder) Dialect(d Dialect) *DropTableBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its table names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *DropTableBuilder) QuoteIdentifiers() *DropTableBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *DropTableBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.tables) == 0 {
+		err = fmt.Errorf("drop table statements must specify at least one table")
+		return
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifierList(b.tables, identName); err != nil {
+			return
+		}
+	}
+	d := dialectName(b.dialect)
+	if len(b.tables) > 1 && (d == SQLiteName || d == OracleName) {
+		err = fmt.Errorf("%s does not support dropping several tables at once", d)
+		return
+	}
+	if b.cascade && (d == SQLiteName || d == SQLServerName) {
+		err = fmt.Errorf("%s does not support DROP TABLE ... CASCADE", d)
+		return
+	}
+
+	sql := getBuffer()
+	defer putBuffer(sql)
+
+	sql.WriteString("DROP TABLE ")
+	if b.ifExists {
+		if !supports(b.dialect, FeatureIfExists) {
+			err = fmt.Errorf("%s does not support DROP TABLE IF EXISTS", d)
+			return
+		}
+		sql.WriteString("IF EXISTS ")
+	}
+	sql.WriteString(strings.Join(b.quoteNames(b.tables, identName), ", "))
+	if b.cascade {
+		if d == OracleName {
+			sql.WriteString(" CASCADE CONSTRAINTS")
+		} else {
+			sql.WriteString(" CASCADE")
+		}
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+// Tables adds tables to drop.
+func (b *DropTableBuilder) Tables(tables ...string) *DropTableBuilder {
+	b.tables = append(b.tables, tables...)
+	return b
+}
+
+// IfExists adds IF EXISTS, so the statement does nothing for tables which
+// don't exist.
+func (b *DropTableBuilder) IfExists() *DropTableBuilder {
+	b.ifExists = true
+	return b
+}
+
+// Cascade also drops the objects depending on the tables, like views and
+// foreign keys. Oracle drops only the referencing constraints.
+func (b *DropTableBuilder) Cascade() *DropTableBuilder {
+	b.cascade = true
+	return b
+}
+
+// DropIndexBuilder builds SQL DROP INDEX statements.
+type DropIndexBuilder struct {
+	StatementBuilderType
+
+	name         string
+	table        string
+	ifExists     bool
+	concurrently bool
+	cascade      bool
+}
+
+// NewDropIndexBuilder creates new instance of DropIndexBuilder
+func NewDropIndexBuilder(b StatementBuilderType) *DropIndexBuilder {
+	return &DropIndexBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *DropIndexBuilder) RunWith(runner BaseRunnerContext) *DropIndexBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *DropIndexBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *DropIndexBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext.
+func (b *DropIndexBuilder) Timeout(d time.Duration) *DropIndexBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on.
+//
+// See StatementBuilderType.Dialect.
+func (b *DropIndexBuilder) Dialect(d Dialect) *DropIndexBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its index and table names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *DropIndexBuilder) QuoteIdentifiers() *DropIndexBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *DropIndexBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.name) == 0 {
+		err = fmt.Errorf("drop index statements must specify an index")
+		return
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifier(b.name, identName); err != nil {
+			return
+		}
+		if len(b.table) > 0 {
+			if err = b.checkIdentifier(b.table, identName); err != nil {
+				return
+			}
+		}
+	}
+	d := dialectName(b.dialect)
+	// MySQL and SQL Server name indexes per table
+	onTable := d == MySQLName || d == SQLServerName || (d == "" && len(b.table) > 0)
+	if onTable && len(b.table) == 0 {
+		err = fmt.Errorf("drop index statements must specify the table of the index on %s", d)
+		return
+	}
+	if b.concurrently && d != "" && d != PostgresName {
+		err = fmt.Errorf("%s does not support DROP INDEX CONCURRENTLY", d)
+		return
+	}
+	if b.cascade && d != "" && d != PostgresName {
+		err = fmt.Errorf("%s does not support DROP INDEX ... CASCADE", d)
+		return
+	}
+
+	sql := getBuffer()
+	defer putBuffer(sql)
+
+	sql.WriteString("DROP INDEX ")
+	if b.concurrently {
+		sql.WriteString("CONCURRENTLY ")
+	}
+	if b.ifExists {
+		if !supports(b.dialect, FeatureIfExists) || d == MySQLName {
+			err = fmt.Errorf("%s does not support DROP INDEX IF EXISTS", d)
+			return
+		}
+		sql.WriteString("IF EXISTS ")
+	}
+	sql.WriteString(b.quoteName(b.name, identName))
+	if onTable {
+		sql.WriteString(" ON ")
+		sql.WriteString(b.quoteName(b.table, identName))
+	}
+	if b.cascade {
+		sql.WriteString(" CASCADE")
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+// Name sets the name of the index to drop.
+func (b *DropIndexBuilder) Name(name string) *DropIndexBuilder {
+	b.name = name
+	return b
+}
+
+// On sets the table of the index, required by MySQL and SQL Server. It is
+// left out for databases whose index names are unique per schema.
+func (b *DropIndexBuilder) On(table string) *DropIndexBuilder {
+	b.table = table
+	return b
+}
+
+// IfExists adds IF EXISTS, so the statement does nothing if the index
+// doesn't exist.
+func (b *DropIndexBuilder) IfExists() *DropIndexBuilder {
+	b.ifExists = true
+	return b
+}
+
+// Concurrently adds the PostgreSQL CONCURRENTLY option, which drops the
+// index without locking out queries on its table.
+func (b *DropIndexBuilder) Concurrently() *DropIndexBuilder {
+	b.concurrently = true
+	return b
+}
+
+// Cascade also drops the objects depending on the index on PostgreSQL.
+func (b *DropIndexBuilder) Cascade() *DropIndexBuilder {
+	b.cascade = true
+	return b
+}
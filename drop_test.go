@@ -0,0 +1,73 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDropTableBuilderToSql(t *testing.T) {
+	sql, args, err := DropTable("orders", "users").IfExists().Cascade().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP TABLE IF EXISTS orders, users CASCADE", sql)
+	assert.Nil(t, args)
+
+	sql, _, err = DropTable("order").Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP TABLE `order`", sql)
+
+	sql, _, err = DropTable("orders").Cascade().Dialect(Oracle).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP TABLE orders CASCADE CONSTRAINTS", sql)
+
+	_, _, err = DropTable().ToSql()
+	assert.Error(t, err)
+	_, _, err = DropTable("orders").IfExists().Dialect(Oracle).ToSql()
+	assert.Error(t, err)
+	_, _, err = DropTable("orders").Cascade().Dialect(SQLite).ToSql()
+	assert.Error(t, err)
+	_, _, err = DropTable("orders", "users").Dialect(SQLite).ToSql()
+	assert.Error(t, err)
+}
+
+func TestDropIndexBuilderToSql(t *testing.T) {
+	sql, args, err := DropIndex("orders_user_idx").Concurrently().IfExists().Cascade().Dialect(Postgres).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP INDEX CONCURRENTLY IF EXISTS orders_user_idx CASCADE", sql)
+	assert.Nil(t, args)
+
+	sql, _, err = DropIndex("orders_user_idx").On("orders").Dialect(SQLServer).IfExists().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP INDEX IF EXISTS orders_user_idx ON orders", sql)
+
+	sql, _, err = DropIndex("orders_user_idx").On("orders").Dialect(SQLite).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP INDEX orders_user_idx", sql)
+
+	sql, _, err = DropIndex("orders_user_idx").On("orders").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP INDEX orders_user_idx ON orders", sql)
+
+	_, _, err = DropIndex("").ToSql()
+	assert.Error(t, err)
+	_, _, err = DropIndex("orders_user_idx").Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+	_, _, err = DropIndex("orders_user_idx").On("orders").IfExists().Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+	_, _, err = DropIndex("orders_user_idx").Concurrently().Dialect(SQLite).ToSql()
+	assert.Error(t, err)
+}
+
+func TestDropTableBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	_, err := DropTable("t").IfExists().RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP TABLE IF EXISTS t", db.LastExecSql)
+
+	_, err = DropIndex("i").RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP INDEX i", db.LastExecSql)
+
+	_, err = DropTable("t").Exec()
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
@@ -0,0 +1,383 @@
+package sqrl
+
+import (
+	"fmt"
+)
+
+// Types of queries in a QueryDef.
+const (
+	SelectQuery = "select"
+	InsertQuery = "insert"
+	UpdateQuery = "update"
+	DeleteQuery = "delete"
+)
+
+// Ops of expressions in an ExprDef.
+const (
+	// SQLOp is a raw SQL fragment with its args, like those passed to Expr
+	// or Where.
+	SQLOp = "sql"
+	// ValueOp is a value bound to a placeholder, e.g. of Values or Set.
+	ValueOp = "value"
+	// SelectOp is a nested SelectBuilder.
+	SelectOp = "select"
+	// SubQueryOp is a SelectBuilder wrapped by SubQuery.
+	SubQueryOp = "subquery"
+	// AliasOp is an expression aliased by Alias.
+	AliasOp = "alias"
+
+	EqOp     = "eq"
+	NotEqOp  = "not_eq"
+	LtOp     = "lt"
+	LtOrEqOp = "lt_or_eq"
+	GtOp     = "gt"
+	GtOrEqOp = "gt_or_eq"
+	AndOp    = "and"
+	OrOp     = "or"
+	NotOp    = "not"
+)
+
+// QueryDef is a structured, JSON serializable definition of a query, as
+// exported by the Export method of the builders.
+//
+// Args are exported as they are, so they must be serializable themselves to
+// marshal the definition. Note that JSON doesn't keep the types of numbers.
+type QueryDef struct {
+	Type        string `json:"type"`
+	Placeholder string `json:"placeholder,omitempty"`
+
+	Prefixes   []ExprDef `json:"prefixes,omitempty"`
+	Options    []string  `json:"options,omitempty"`
+	Distinct   bool      `json:"distinct,omitempty"`
+	DistinctOn []string  `json:"distinct_on,omitempty"`
+	Top        *uint64   `json:"top,omitempty"`
+	Columns    []ExprDef `json:"columns,omitempty"`
+
+	// Table is the table inserted into, updated or deleted from.
+	Table string   `json:"table,omitempty"`
+	Or    string   `json:"or,omitempty"`
+	What  []string `json:"what,omitempty"`
+
+	From  []ExprDef `json:"from,omitempty"`
+	Using []ExprDef `json:"using,omitempty"`
+	Joins []ExprDef `json:"joins,omitempty"`
+
+	InsertColumns []string    `json:"insert_columns,omitempty"`
+	Output        []string    `json:"output,omitempty"`
+	Values        [][]ExprDef `json:"values,omitempty"`
+	Select        *QueryDef   `json:"select,omitempty"`
+	Set           []SetDef    `json:"set,omitempty"`
+
+	Where   []ExprDef `json:"where,omitempty"`
+	GroupBy []string  `json:"group_by,omitempty"`
+	Having  []ExprDef `json:"having,omitempty"`
+	OrderBy []string  `json:"order_by,omitempty"`
+	Limit   *uint64   `json:"limit,omitempty"`
+	Offset  *uint64   `json:"offset,omitempty"`
+
+	OnConflict *ExprDef  `json:"on_conflict,omitempty"`
+	Returning  []ExprDef `json:"returning,omitempty"`
+	Suffixes   []ExprDef `json:"suffixes,omitempty"`
+}
+
+// ExprDef is the definition of an expression or predicate of a QueryDef.
+//
+// Expressions without a structured representation are exported as SQLOp
+// with their rendered SQL and args.
+type ExprDef struct {
+	Op      string                 `json:"op"`
+	SQL     string                 `json:"sql,omitempty"`
+	Args    []interface{}          `json:"args,omitempty"`
+	Columns map[string]interface{} `json:"columns,omitempty"`
+	Exprs   []ExprDef              `json:"exprs,omitempty"`
+	Query   *QueryDef              `json:"query,omitempty"`
+	Alias   string                 `json:"alias,omitempty"`
+}
+
+// SetDef is the definition of a SET clause of an UPDATE query.
+type SetDef struct {
+	Column string  `json:"column"`
+	Value  ExprDef `json:"value"`
+}
+
+// exportPlaceholder returns the name of the placeholder format f in a
+// QueryDef, see importPlaceholder.
+func exportPlaceholder(f PlaceholderFormat) (string, error) {
+	switch f := f.(type) {
+	case nil, questionFormat:
+		return "", nil
+	case dollarFormat:
+		return "dollar", nil
+	case colonFormat:
+		return "colon", nil
+	case NamedFormat:
+		switch f {
+		case AtNamed:
+			return "at_named", nil
+		case ColonNamed:
+			return "colon_named", nil
+		}
+	}
+	return "", fmt.Errorf("cannot export placeholder format %T", f)
+}
+
+// Export exports the query into a QueryDef.
+func (b *SelectBuilder) Export() (*QueryDef, error) {
+	var err error
+	d := &QueryDef{Type: SelectQuery, Options: b.options, Distinct: b.distinct, DistinctOn: b.distinctOn, GroupBy: b.groupBys, OrderBy: b.orderBys}
+	if d.Placeholder, err = exportPlaceholder(b.placeholderFormat); err != nil {
+		return nil, err
+	}
+	if b.topValid {
+		d.Top = &b.top
+	}
+	if b.limitValid {
+		d.Limit = &b.limit
+	}
+	if b.offsetValid {
+		d.Offset = &b.offset
+	}
+
+	for _, e := range []struct {
+		dst   *[]ExprDef
+		parts []Sqlizer
+	}{
+		{&d.Prefixes, b.prefixes.sqlizers()},
+		{&d.Columns, b.columns},
+		{&d.From, b.fromParts},
+		{&d.Joins, b.joins},
+		{&d.Where, b.whereParts},
+		{&d.Having, b.havingParts},
+		{&d.Suffixes, b.suffixes.sqlizers()},
+	} {
+		if *e.dst, err = exportExprs(e.parts); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// Export exports the query into a QueryDef.
+func (b *InsertBuilder) Export() (*QueryDef, error) {
+	var err error
+	d := &QueryDef{Type: InsertQuery, Options: b.options, Table: b.into, Or: string(b.orAction), InsertColumns: b.columns}
+	if d.Placeholder, err = exportPlaceholder(b.placeholderFormat); err != nil {
+		return nil, err
+	}
+	for _, column := range b.outputColumns {
+		// Output prefixes the columns with INSERTED.
+		d.Output = append(d.Output, column[len("INSERTED."):])
+	}
+
+	if b.iselect != nil {
+		if d.Select, err = b.iselect.Export(); err != nil {
+			return nil, err
+		}
+	}
+	for _, row := range b.values {
+		values := make([]ExprDef, len(row))
+		for i, v := range row {
+			if values[i], err = exportValue(v); err != nil {
+				return nil, err
+			}
+		}
+		d.Values = append(d.Values, values)
+	}
+
+	if d.Prefixes, err = exportExprs(b.prefixes.sqlizers()); err != nil {
+		return nil, err
+	}
+	if b.onConflict != nil {
+		onConflict, err := exportExpr(b.onConflict)
+		if err != nil {
+			return nil, err
+		}
+		d.OnConflict = &onConflict
+	}
+	if d.Returning, err = exportExprs(b.returning); err != nil {
+		return nil, err
+	}
+	if d.Suffixes, err = exportExprs(b.suffixes.sqlizers()); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Export exports the query into a QueryDef.
+func (b *UpdateBuilder) Export() (*QueryDef, error) {
+	var err error
+	d := &QueryDef{Type: UpdateQuery, Table: b.table, OrderBy: b.orderBys}
+	if d.Placeholder, err = exportPlaceholder(b.placeholderFormat); err != nil {
+		return nil, err
+	}
+	if b.limitValid {
+		d.Limit = &b.limit
+	}
+	if b.offsetValid {
+		d.Offset = &b.offset
+	}
+
+	for _, c := range b.setClauses {
+		value, err := exportValue(c.value)
+		if err != nil {
+			return nil, err
+		}
+		d.Set = append(d.Set, SetDef{Column: c.column, Value: value})
+	}
+
+	for _, e := range []struct {
+		dst   *[]ExprDef
+		parts []Sqlizer
+	}{
+		{&d.Prefixes, b.prefixes.sqlizers()},
+		{&d.From, b.fromParts},
+		{&d.Joins, b.joins},
+		{&d.Where, b.whereParts},
+		{&d.Returning, b.returning},
+		{&d.Suffixes, b.suffixes.sqlizers()},
+	} {
+		if *e.dst, err = exportExprs(e.parts); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// Export exports the query into a QueryDef.
+func (b *DeleteBuilder) Export() (*QueryDef, error) {
+	var err error
+	d := &QueryDef{Type: DeleteQuery, Table: b.from, What: b.what, OrderBy: b.orderBys}
+	if d.Placeholder, err = exportPlaceholder(b.placeholderFormat); err != nil {
+		return nil, err
+	}
+	if b.limitValid {
+		d.Limit = &b.limit
+	}
+	if b.offsetValid {
+		d.Offset = &b.offset
+	}
+
+	for _, e := range []struct {
+		dst   *[]ExprDef
+		parts []Sqlizer
+	}{
+		{&d.Prefixes, b.prefixes.sqlizers()},
+		{&d.Joins, b.joins},
+		{&d.Using, b.usingParts},
+		{&d.Where, b.whereParts},
+		{&d.Returning, b.returning},
+		{&d.Suffixes, b.suffixes.sqlizers()},
+	} {
+		if *e.dst, err = exportExprs(e.parts); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (es exprs) sqlizers() []Sqlizer {
+	parts := make([]Sqlizer, len(es))
+	for i, e := range es {
+		parts[i] = e
+	}
+	return parts
+}
+
+func exportExprs(parts []Sqlizer) ([]ExprDef, error) {
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	defs := make([]ExprDef, 0, len(parts))
+	for _, p := range parts {
+		d, err := exportExpr(p)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, d)
+	}
+	return defs, nil
+}
+
+// exportValue exports a value of Values or Set, which is either an
+// expression or bound to a placeholder.
+func exportValue(v interface{}) (ExprDef, error) {
+	if s, ok := v.(Sqlizer); ok {
+		return exportExpr(s)
+	}
+	return ExprDef{Op: ValueOp, Args: []interface{}{v}}, nil
+}
+
+func exportExpr(s Sqlizer) (ExprDef, error) {
+	switch s := s.(type) {
+	case expr:
+		if !hasSqlizer(s.args) {
+			return ExprDef{Op: SQLOp, SQL: s.sql, Args: s.args}, nil
+		}
+	case *part:
+		return exportPred(s.pred, s.args)
+	case *wherePart:
+		return exportPred(s.pred, s.args)
+	case Eq:
+		return exportColumns(EqOp, s)
+	case NotEq:
+		return exportColumns(NotEqOp, s)
+	case Lt:
+		return exportColumns(LtOp, s)
+	case LtOrEq:
+		return exportColumns(LtOrEqOp, s)
+	case Gt:
+		return exportColumns(GtOp, s)
+	case GtOrEq:
+		return exportColumns(GtOrEqOp, s)
+	case And:
+		return exportConj(AndOp, s)
+	case Or:
+		return exportConj(OrOp, s)
+	case not:
+		return exportConj(NotOp, []Sqlizer{s.pred})
+	case *SelectBuilder:
+		q, err := s.Export()
+		return ExprDef{Op: SelectOp, Query: q}, err
+	case subQuery:
+		q, err := s.sb.Export()
+		return ExprDef{Op: SubQueryOp, Query: q}, err
+	case aliasExpr:
+		inner, err := exportExpr(s.expr)
+		return ExprDef{Op: AliasOp, Exprs: []ExprDef{inner}, Alias: s.alias}, err
+	}
+
+	// no structured representation
+	sql, args, err := nestedToSql(s)
+	if err != nil {
+		return ExprDef{}, err
+	}
+	return ExprDef{Op: SQLOp, SQL: sql, Args: args}, nil
+}
+
+func exportPred(pred interface{}, args []interface{}) (ExprDef, error) {
+	switch pred := pred.(type) {
+	case string:
+		return exportExpr(Expr(pred, args...))
+	case map[string]interface{}:
+		return exportColumns(EqOp, pred)
+	case Sqlizer:
+		return exportExpr(pred)
+	case nil:
+		return ExprDef{Op: SQLOp}, nil
+	}
+	return ExprDef{}, fmt.Errorf("expected string-keyed map, string or Sqlizer, not %T", pred)
+}
+
+func exportColumns(op string, columns map[string]interface{}) (ExprDef, error) {
+	for _, v := range columns {
+		if _, ok := v.(Sqlizer); ok {
+			return ExprDef{}, fmt.Errorf("cannot export %s with expression values", op)
+		}
+	}
+	return ExprDef{Op: op, Columns: columns}, nil
+}
+
+func exportConj(op string, parts []Sqlizer) (ExprDef, error) {
+	defs, err := exportExprs(parts)
+	return ExprDef{Op: op, Exprs: defs}, err
+}
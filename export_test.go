@@ -0,0 +1,121 @@
+package sqrl
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectBuilderExport(t *testing.T) {
+	sub := Select("id").From("banned").Where(Eq{"reason": "spam"})
+	b := Select("a", "b").
+		Column(Alias(Expr("COUNT(*)"), "n")).
+		From("users").
+		Join("orders ON orders.user_id = users.id").
+		Where("age > ?", 18).
+		Where(Or{Eq{"status": "active"}, Expr("vip")}).
+		Where(Expr("id NOT IN (?)", sub)).
+		GroupBy("a").
+		OrderBy("b DESC").
+		Limit(10).
+		PlaceholderFormat(Dollar)
+
+	d, err := b.Export()
+	assert.NoError(t, err)
+
+	limit := uint64(10)
+	expected := &QueryDef{
+		Type:        SelectQuery,
+		Placeholder: "dollar",
+		Columns: []ExprDef{
+			{Op: SQLOp, SQL: "a"},
+			{Op: SQLOp, SQL: "b"},
+			{Op: AliasOp, Exprs: []ExprDef{{Op: SQLOp, SQL: "COUNT(*)"}}, Alias: "n"},
+		},
+		From:  []ExprDef{{Op: SQLOp, SQL: "users"}},
+		Joins: []ExprDef{{Op: SQLOp, SQL: "JOIN orders ON orders.user_id = users.id"}},
+		Where: []ExprDef{
+			{Op: SQLOp, SQL: "age > ?", Args: []interface{}{18}},
+			{Op: OrOp, Exprs: []ExprDef{
+				{Op: EqOp, Columns: map[string]interface{}{"status": "active"}},
+				{Op: SQLOp, SQL: "vip"},
+			}},
+			{Op: SQLOp, SQL: "id NOT IN (SELECT id FROM banned WHERE reason = ?)", Args: []interface{}{"spam"}},
+		},
+		GroupBy: []string{"a"},
+		OrderBy: []string{"b DESC"},
+		Limit:   &limit,
+	}
+	assert.Equal(t, expected, d)
+}
+
+func TestInsertBuilderExport(t *testing.T) {
+	d, err := Insert("users").Columns("name", "age").
+		Values("Joe", Expr("? + 1", 17)).
+		Returning("id").
+		Export()
+	assert.NoError(t, err)
+
+	expected := &QueryDef{
+		Type:          InsertQuery,
+		Table:         "users",
+		InsertColumns: []string{"name", "age"},
+		Values: [][]ExprDef{{
+			{Op: ValueOp, Args: []interface{}{"Joe"}},
+			{Op: SQLOp, SQL: "? + 1", Args: []interface{}{17}},
+		}},
+		Returning: []ExprDef{{Op: SQLOp, SQL: "id"}},
+	}
+	assert.Equal(t, expected, d)
+}
+
+func TestUpdateBuilderExport(t *testing.T) {
+	d, err := Update("users").Set("name", "Joe").Where(Eq{"id": 1}).Export()
+	assert.NoError(t, err)
+
+	expected := &QueryDef{
+		Type:  UpdateQuery,
+		Table: "users",
+		Set:   []SetDef{{Column: "name", Value: ExprDef{Op: ValueOp, Args: []interface{}{"Joe"}}}},
+		Where: []ExprDef{{Op: EqOp, Columns: map[string]interface{}{"id": 1}}},
+	}
+	assert.Equal(t, expected, d)
+}
+
+func TestDeleteBuilderExport(t *testing.T) {
+	d, err := Delete("users").Where(Lt{"age": 18}).Export()
+	assert.NoError(t, err)
+
+	expected := &QueryDef{
+		Type:  DeleteQuery,
+		Table: "users",
+		What:  []string{"users"},
+		Where: []ExprDef{{Op: LtOp, Columns: map[string]interface{}{"age": 18}}},
+	}
+	assert.Equal(t, expected, d)
+}
+
+func TestExportJSON(t *testing.T) {
+	d, err := Select("a").From("t").Where(Eq{"b": 1}).Export()
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.JSONEq(t,
+		`{"type":"select","columns":[{"op":"sql","sql":"a"}],"from":[{"op":"sql","sql":"t"}],`+
+			`"where":[{"op":"eq","columns":{"b":1}}]}`,
+		string(data))
+}
+
+func TestExportUnknownPlaceholderFormat(t *testing.T) {
+	_, err := Select("a").From("t").PlaceholderFormat(NamedFormat(0)).Export()
+	assert.Error(t, err)
+
+	f := FuncPlaceholderFormat(func(buf *bytes.Buffer, idx int) error { return nil })
+	_, err = Select("a").From("t").PlaceholderFormat(f).Export()
+	assert.Error(t, err)
+	_, err = Select("a").From("t").PlaceholderFormat(Dedup(Dollar)).Export()
+	assert.Error(t, err)
+}
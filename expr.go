@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
 	"strings"
+	"sync/atomic"
 )
 
 type expr struct {
@@ -28,19 +30,26 @@ func (lt expr) ToSql() (string, []interface{}, error) {
 	}
 
 	args := make([]interface{}, 0, len(lt.args))
-	sql, err := replacePlaceholders(lt.sql, func(buf *bytes.Buffer, i int) error {
+	sql, err := replacePlaceholdersEscaped(lt.sql, "??", func(buf *bytes.Buffer, i int) error {
 		if i > len(lt.args) {
 			buf.WriteRune('?')
 			return nil
 		}
 		switch arg := lt.args[i-1].(type) {
+		case *SelectBuilder:
+			sql, vs, err := arg.toSql(false)
+			if err != nil {
+				return err
+			}
+			args = append(args, vs...)
+			buf.WriteString(sql)
 		case Sqlizer:
 			sql, vs, err := arg.ToSql()
 			if err != nil {
 				return err
 			}
 			args = append(args, vs...)
-			fmt.Fprintf(buf, sql)
+			buf.WriteString(sql)
 		default:
 			args = append(args, arg)
 			buf.WriteRune('?')
@@ -53,6 +62,129 @@ func (lt expr) ToSql() (string, []interface{}, error) {
 	return sql, args, nil
 }
 
+func (lt expr) appendPart(w sqlWriter, sep string, args []interface{}) ([]interface{}, bool, error) {
+	if lt.sql == "" {
+		return args, false, nil
+	}
+	w.WriteString(sep)
+	if !hasSqlizer(lt.args) {
+		w.WriteString(lt.sql)
+		return append(args, lt.args...), true, nil
+	}
+
+	// Like ToSql, but nested Sqlizers are written to w directly
+	sql := lt.sql
+	var err error
+	for i := 0; ; i++ {
+		p := strings.IndexByte(sql, '?')
+		if p == -1 {
+			break
+		}
+		w.WriteString(sql[:p])
+
+		if len(sql[p:]) > 1 && sql[p+1] == '?' { // keep escaped ?? for the outer format
+			w.WriteString("??")
+			sql = sql[p+2:]
+			i--
+			continue
+		}
+		sql = sql[p+1:]
+
+		if i >= len(lt.args) {
+			w.WriteString("?")
+			continue
+		}
+		switch arg := lt.args[i].(type) {
+		case *SelectBuilder:
+			args, err = arg.AppendToSql(w, args)
+		case Sqlizer:
+			args, _, err = writePart(w, "", arg, args)
+		default:
+			w.WriteString("?")
+			args = append(args, arg)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	w.WriteString(sql)
+	return args, true, nil
+}
+
+type namedExpr struct {
+	sql  string
+	args map[string]interface{}
+}
+
+// ExprNamed builds value expressions with named parameters. Every :name token
+// is replaced by a placeholder and its value is bound in order of appearance.
+// Postgres casts like "::int" and tokens in string literals or quoted
+// identifiers are left untouched.
+//
+// Ex:
+//     .Where(ExprNamed("a = :a AND b = :b", map[string]interface{}{"a": 1, "b": 2}))
+func ExprNamed(sql string, args map[string]interface{}) Sqlizer {
+	return namedExpr{sql: sql, args: args}
+}
+
+func (ne namedExpr) ToSql() (string, []interface{}, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	var args []interface{}
+
+	sql := ne.sql
+	for i := 0; i < len(sql); {
+		if n := quotedLen(sql, i, ""); n > 0 {
+			// copy string literals and quoted identifiers as they are
+			buf.WriteString(sql[i : i+n])
+			i += n
+			continue
+		}
+		if sql[i] != ':' {
+			buf.WriteByte(sql[i])
+			i++
+			continue
+		}
+
+		if i+1 < len(sql) && sql[i+1] == ':' {
+			buf.WriteString("::")
+			i += 2
+			continue
+		}
+
+		j := i + 1
+		for j < len(sql) && isNameChar(sql[j], j == i+1) {
+			j++
+		}
+		if j == i+1 {
+			buf.WriteByte(':')
+			i++
+			continue
+		}
+
+		name := sql[i+1 : j]
+		val, ok := ne.args[name]
+		if !ok {
+			return "", nil, fmt.Errorf("no value for named parameter :%s", name)
+		}
+		buf.WriteByte('?')
+		args = append(args, val)
+		i = j
+	}
+
+	return Expr(buf.String(), args...).ToSql()
+}
+
+func isNameChar(c byte, first bool) bool {
+	switch {
+	case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		return true
+	case c >= '0' && c <= '9':
+		return !first
+	}
+	return false
+}
+
 type exprs []expr
 
 func (es exprs) AppendToSql(w io.Writer, sep string, args []interface{}) ([]interface{}, error) {
@@ -63,11 +195,14 @@ func (es exprs) AppendToSql(w io.Writer, sep string, args []interface{}) ([]inte
 				return nil, err
 			}
 		}
-		_, err := io.WriteString(w, lt.sql)
+		sql, eargs, err := lt.ToSql()
 		if err != nil {
 			return nil, err
 		}
-		args = append(args, lt.args...)
+		if _, err := io.WriteString(w, sql); err != nil {
+			return nil, err
+		}
+		args = append(args, eargs...)
 	}
 	return args, nil
 }
@@ -87,24 +222,107 @@ func Alias(expr Sqlizer, alias string) aliasExpr {
 }
 
 func (lt aliasExpr) ToSql() (sql string, args []interface{}, err error) {
-	sql, args, err = lt.expr.ToSql()
+	sql, args, err = nestedToSql(lt.expr)
 	if err == nil {
-		sql = fmt.Sprintf("(%s) AS %s", sql, lt.alias)
+		sql = "(" + sql + ") AS " + lt.alias
 	}
 	return
 }
 
+// AppendToSql writes the aliased expression to w.
+func (lt aliasExpr) AppendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
+	if _, err := io.WriteString(w, "("); err != nil {
+		return nil, err
+	}
+	args, err := appendNested(w, lt.expr, args)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.WriteString(w, ") AS "+lt.alias); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
+// subQuery wraps SelectBuilder to nest it into other statements
+type subQuery struct {
+	sb *SelectBuilder
+}
+
+// SubQuery wraps a SelectBuilder in parentheses so it can be used anywhere
+// a Sqlizer is accepted. Placeholders of the subquery are left untouched, so
+// the outer builder numbers them together with its own.
+// Ex:
+//		.Where(Expr("EXISTS ?", SubQuery(Select("1").From("b").Where("b.a_id = a.id"))))
+func SubQuery(sb *SelectBuilder) Sqlizer {
+	return subQuery{sb}
+}
+
+func (sq subQuery) ToSql() (sql string, args []interface{}, err error) {
+	sql, args, err = sq.sb.toSql(false)
+	if err == nil {
+		sql = "(" + sql + ")"
+	}
+	return
+}
+
+// AppendToSql writes the subquery in parentheses to w.
+func (sq subQuery) AppendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
+	if _, err := io.WriteString(w, "("); err != nil {
+		return nil, err
+	}
+	args, err := sq.sb.AppendToSql(w, args)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.WriteString(w, ")"); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
+// nestedToSql builds SQL of s for embedding it into an outer statement.
+// Subqueries are rendered without parentheses and their placeholders are not
+// replaced, that is left to the outer statement.
+func nestedToSql(s Sqlizer) (string, []interface{}, error) {
+	switch v := s.(type) {
+	case *SelectBuilder:
+		return v.toSql(false)
+	case subQuery:
+		return v.sb.toSql(false)
+	}
+	return s.ToSql()
+}
+
+// appendNested writes the SQL of s to w like nestedToSql builds it.
+func appendNested(w io.Writer, s Sqlizer, args []interface{}) ([]interface{}, error) {
+	if sq, ok := s.(subQuery); ok {
+		s = sq.sb
+	}
+	if a, ok := s.(SqlAppender); ok {
+		return a.AppendToSql(w, args)
+	}
+
+	sql, sargs, err := s.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.WriteString(w, sql); err != nil {
+		return nil, err
+	}
+	return append(args, sargs...), nil
+}
+
 // Eq is syntactic sugar for use with Where/Having/Set methods.
 // Ex:
 //     .Where(Eq{"id": 1})
 type Eq map[string]interface{}
 
-func (eq Eq) toSql(useNotOpr, useOr, useLike, insensitiveLike bool) (sql string, args []interface{}, err error) {
+func (eq Eq) toSql(o operators, useOr bool) (sql string, args []interface{}, err error) {
 	var exprs []string
-	o := newOperators(useNotOpr, useLike, insensitiveLike)
 
-	for key, val := range eq {
-		expr, sargs, err := keyVal(key, val, useLike, o)
+	for _, cv := range mapColumnValues(eq) {
+		expr, sargs, err := keyVal(cv.column, cv.value, o)
 		if err != nil {
 			return sql, args, err
 		}
@@ -124,7 +342,34 @@ func (eq Eq) toSql(useNotOpr, useOr, useLike, insensitiveLike bool) (sql string,
 
 // ToSql builds the query into a SQL string and bound args.
 func (eq Eq) ToSql() (sql string, args []interface{}, err error) {
-	return eq.toSql(false, false, false, false)
+	return eq.toSql(newOperators(false, false, false), false)
+}
+
+func (eq Eq) defaultDialect(d Dialect) Sqlizer {
+	return Eq(mapWithDialect(eq, d))
+}
+
+// mapWithDialect returns m with d as the dialect of its Sqlizer values, see
+// withDialect. m is copied if any value changes.
+func mapWithDialect(m map[string]interface{}, d Dialect) map[string]interface{} {
+	var c map[string]interface{}
+	for k, v := range m {
+		s, ok := v.(dialectExpr)
+		if !ok {
+			continue
+		}
+		if c == nil {
+			c = make(map[string]interface{}, len(m))
+			for k, v := range m {
+				c[k] = v
+			}
+		}
+		c[k] = s.defaultDialect(d)
+	}
+	if c == nil {
+		return m
+	}
+	return c
 }
 
 // NotEq is syntactic sugar for use with Where/Having/Set methods.
@@ -134,7 +379,11 @@ type NotEq Eq
 
 // ToSql builds the query into a SQL string and bound args.
 func (s NotEq) ToSql() (sql string, args []interface{}, err error) {
-	return Eq(s).toSql(true, false, false, false)
+	return Eq(s).toSql(newOperators(true, false, false), false)
+}
+
+func (s NotEq) defaultDialect(d Dialect) Sqlizer {
+	return NotEq(mapWithDialect(s, d))
 }
 
 // EqOr is syntactic sugar for use with Where/Having/Set methods.
@@ -144,7 +393,11 @@ type EqOr Eq
 
 // ToSql builds the query into a SQL string and bound args.
 func (eqor EqOr) ToSql() (sql string, args []interface{}, err error) {
-	return Eq(eqor).toSql(false, true, false, false)
+	return Eq(eqor).toSql(newOperators(false, false, false), true)
+}
+
+func (eqor EqOr) defaultDialect(d Dialect) Sqlizer {
+	return EqOr(mapWithDialect(eqor, d))
 }
 
 // LikeOr is syntactic sugar for use with Where/Having/Set methods.
@@ -154,7 +407,19 @@ type LikeOr Eq
 
 // ToSql builds the query into a SQL string and bound args.
 func (likeor LikeOr) ToSql() (sql string, args []interface{}, err error) {
-	return Eq(likeor).toSql(false, true, true, false)
+	return Eq(likeor).toSql(newOperators(false, true, false), true)
+}
+
+func (likeor LikeOr) defaultDialect(d Dialect) Sqlizer {
+	return LikeOr(mapWithDialect(likeor, d))
+}
+
+// Escape sets the character used to escape wildcards in the patterns,
+// which adds an ESCAPE clause to every LIKE expression.
+func (likeor LikeOr) Escape(escape rune) Sqlizer {
+	o := newOperators(false, true, false)
+	o.escape = escape
+	return escapedLike{Eq(likeor), o}
 }
 
 // ILikeOr is syntactic sugar for use with Where/Having/Set methods.
@@ -164,7 +429,91 @@ type ILikeOr Eq
 
 // ToSql builds the query into a SQL string and bound args.
 func (likeor ILikeOr) ToSql() (sql string, args []interface{}, err error) {
-	return Eq(likeor).toSql(false, true, true, true)
+	return Eq(likeor).toSql(newOperators(false, true, true), true)
+}
+
+func (likeor ILikeOr) defaultDialect(d Dialect) Sqlizer {
+	return ILikeOr(mapWithDialect(likeor, d))
+}
+
+// Escape sets the character used to escape wildcards in the patterns,
+// which adds an ESCAPE clause to every LIKE expression.
+func (likeor ILikeOr) Escape(escape rune) Sqlizer {
+	o := newOperators(false, true, true)
+	o.escape = escape
+	return escapedLike{Eq(likeor), o}
+}
+
+// EqFold is syntactic sugar for use with Where/Having/Set methods.
+// It matches values case-insensitively without the wildcard hazards of ILIKE.
+// Subqueries are not supported as values.
+// Ex:
+//     .Where(EqFold{"email": "Joe@Example.com"}) == "LOWER(email) = LOWER('Joe@Example.com')"
+type EqFold Eq
+
+// ToSql builds the query into a SQL string and bound args.
+func (eq EqFold) ToSql() (sql string, args []interface{}, err error) {
+	return Eq(eq).toSql(newLowerOperators(false, false), false)
+}
+
+func (eq EqFold) defaultDialect(d Dialect) Sqlizer {
+	return EqFold(mapWithDialect(eq, d))
+}
+
+// NotEqFold is syntactic sugar for use with Where/Having/Set methods.
+// Ex:
+//     .Where(NotEqFold{"email": "Joe@Example.com"}) == "LOWER(email) <> LOWER('Joe@Example.com')"
+type NotEqFold Eq
+
+// ToSql builds the query into a SQL string and bound args.
+func (eq NotEqFold) ToSql() (sql string, args []interface{}, err error) {
+	return Eq(eq).toSql(newLowerOperators(true, false), false)
+}
+
+func (eq NotEqFold) defaultDialect(d Dialect) Sqlizer {
+	return NotEqFold(mapWithDialect(eq, d))
+}
+
+// LowerLikeOr is syntactic sugar for use with Where/Having/Set methods.
+// It is a portable alternative to ILikeOr for databases without ILIKE.
+// Ex:
+//     .Where(LowerLikeOr{"email": "joe%", "name": "Joe%"}) == "LOWER(email) LIKE LOWER('joe%') OR LOWER(name) LIKE LOWER('Joe%')"
+type LowerLikeOr Eq
+
+// ToSql builds the query into a SQL string and bound args.
+func (likeor LowerLikeOr) ToSql() (sql string, args []interface{}, err error) {
+	return Eq(likeor).toSql(newLowerOperators(false, true), true)
+}
+
+func (likeor LowerLikeOr) defaultDialect(d Dialect) Sqlizer {
+	return LowerLikeOr(mapWithDialect(likeor, d))
+}
+
+// Escape sets the character used to escape wildcards in the patterns,
+// which adds an ESCAPE clause to every LIKE expression.
+func (likeor LowerLikeOr) Escape(escape rune) Sqlizer {
+	o := newLowerOperators(false, true)
+	o.escape = escape
+	return escapedLike{Eq(likeor), o}
+}
+
+// escapedLike is a LIKE predicate map with an ESCAPE character set
+type escapedLike struct {
+	eq Eq
+	o  operators
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (l escapedLike) ToSql() (sql string, args []interface{}, err error) {
+	return l.eq.toSql(l.o, true)
+}
+
+func (l escapedLike) defaultDialect(d Dialect) Sqlizer {
+	l.eq = mapWithDialect(l.eq, d)
+	if l.o.dialect == nil {
+		l.o.dialect = d
+	}
+	return l
 }
 
 // Lt is syntactic sugar for use with Where/Having/Set methods.
@@ -183,32 +532,17 @@ func (lt Lt) toSql(opposite, orEq bool) (sql string, args []interface{}, err err
 	}
 
 	if orEq {
-		opr = fmt.Sprintf("%s%s", opr, "=")
+		opr += "="
 	}
 
-	for key, val := range lt {
-		expr := ""
-
-		switch v := val.(type) {
-		case driver.Valuer:
-			if val, err = v.Value(); err != nil {
-				return
-			}
+	for _, cv := range mapColumnValues(lt) {
+		expr, sargs, err := compareKeyVal(cv.column, cv.value, opr)
+		if err != nil {
+			return sql, args, err
 		}
 
-		if val == nil {
-			err = fmt.Errorf("cannot use null with less than or greater than operators")
-			return
-		} else {
-			if isListType(val) {
-				err = fmt.Errorf("cannot use array or slice with less than or greater than operators")
-				return
-			} else {
-				expr = fmt.Sprintf("%s %s ?", key, opr)
-				args = append(args, val)
-			}
-		}
 		exprs = append(exprs, expr)
+		args = append(args, sargs...)
 	}
 
 	sql = strings.Join(exprs, " AND ")
@@ -220,6 +554,10 @@ func (lt Lt) ToSql() (sql string, args []interface{}, err error) {
 	return lt.toSql(false, false)
 }
 
+func (lt Lt) defaultDialect(d Dialect) Sqlizer {
+	return Lt(mapWithDialect(lt, d))
+}
+
 // LtOrEq is syntactic sugar for use with Where/Having/Set methods.
 // Ex:
 //     .Where(LtOrEq{"id": 1}) == "id <= 1"
@@ -229,6 +567,10 @@ func (ltOrEq LtOrEq) ToSql() (sql string, args []interface{}, err error) {
 	return Lt(ltOrEq).toSql(false, true)
 }
 
+func (ltOrEq LtOrEq) defaultDialect(d Dialect) Sqlizer {
+	return LtOrEq(mapWithDialect(ltOrEq, d))
+}
+
 // Gt is syntactic sugar for use with Where/Having/Set methods.
 // Ex:
 //     .Where(Gt{"id": 1}) == "id > 1"
@@ -238,6 +580,10 @@ func (gt Gt) ToSql() (sql string, args []interface{}, err error) {
 	return Lt(gt).toSql(true, false)
 }
 
+func (gt Gt) defaultDialect(d Dialect) Sqlizer {
+	return Gt(mapWithDialect(gt, d))
+}
+
 // GtOrEq is syntactic sugar for use with Where/Having/Set methods.
 // Ex:
 //     .Where(GtOrEq{"id": 1}) == "id >= 1"
@@ -247,26 +593,70 @@ func (gtOrEq GtOrEq) ToSql() (sql string, args []interface{}, err error) {
 	return Lt(gtOrEq).toSql(true, true)
 }
 
+func (gtOrEq GtOrEq) defaultDialect(d Dialect) Sqlizer {
+	return GtOrEq(mapWithDialect(gtOrEq, d))
+}
+
 type conj []Sqlizer
 
 func (c conj) join(sep string) (sql string, args []interface{}, err error) {
-	var sqlParts []string
+	buf := getBuffer()
+	defer putBuffer(buf)
 	for _, sqlizer := range c {
 		partSql, partArgs, err := sqlizer.ToSql()
 		if err != nil {
 			return "", nil, err
 		}
 		if partSql != "" {
-			sqlParts = append(sqlParts, partSql)
+			if buf.Len() == 0 {
+				buf.WriteByte('(')
+			} else {
+				buf.WriteString(sep)
+			}
+			buf.WriteString(partSql)
 			args = append(args, partArgs...)
 		}
 	}
-	if len(sqlParts) > 0 {
-		sql = fmt.Sprintf("(%s)", strings.Join(sqlParts, sep))
+	if buf.Len() > 0 {
+		buf.WriteByte(')')
+		sql = buf.String()
 	}
 	return
 }
 
+// withDialect returns c with d as the dialect of its parts, see withDialect.
+func (c conj) withDialect(d Dialect) conj {
+	parts := make(conj, len(c))
+	for i, s := range c {
+		parts[i] = withDialect(s, d)
+	}
+	return parts
+}
+
+// appendJoined writes the parts of c joined by joinSep in parentheses,
+// preceded by sep, to w unless all parts are empty.
+func (c conj) appendJoined(w sqlWriter, sep, joinSep string, args []interface{}) ([]interface{}, bool, error) {
+	written := false
+	for _, s := range c {
+		partSep := joinSep
+		if !written {
+			partSep = sep + "("
+		}
+
+		var ok bool
+		var err error
+		args, ok, err = writePart(w, partSep, s, args)
+		if err != nil {
+			return nil, false, err
+		}
+		written = written || ok
+	}
+	if written {
+		w.WriteString(")")
+	}
+	return args, written, nil
+}
+
 // And is syntactic sugar that glues where/having parts with AND clause
 // Ex:
 //     .Where(And{Expr("a > ?", 15), Expr("b < ?", 20), Expr("c is TRUE")})
@@ -277,6 +667,14 @@ func (a And) ToSql() (string, []interface{}, error) {
 	return conj(a).join(" AND ")
 }
 
+func (a And) appendPart(w sqlWriter, sep string, args []interface{}) ([]interface{}, bool, error) {
+	return conj(a).appendJoined(w, sep, " AND ", args)
+}
+
+func (a And) defaultDialect(d Dialect) Sqlizer {
+	return And(conj(a).withDialect(d))
+}
+
 // Or is syntactic sugar that glues where/having parts with OR clause
 // Ex:
 //     .Where(Or{Expr("a > ?", 15), Expr("b < ?", 20), Expr("c is TRUE")})
@@ -287,7 +685,47 @@ func (o Or) ToSql() (string, []interface{}, error) {
 	return conj(o).join(" OR ")
 }
 
+func (o Or) appendPart(w sqlWriter, sep string, args []interface{}) ([]interface{}, bool, error) {
+	return conj(o).appendJoined(w, sep, " OR ", args)
+}
+
+func (o Or) defaultDialect(d Dialect) Sqlizer {
+	return Or(conj(o).withDialect(d))
+}
+
+type not struct {
+	pred Sqlizer
+}
+
+// Not is syntactic sugar that negates any where/having part
+// Ex:
+//     .Where(Not(Or{Eq{"a": 1}, Expr("b IS NULL")}))
+func Not(pred Sqlizer) Sqlizer {
+	return not{pred}
+}
+
+func (n not) defaultDialect(d Dialect) Sqlizer {
+	return not{withDialect(n.pred, d)}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (n not) ToSql() (sql string, args []interface{}, err error) {
+	predSql, args, err := n.pred.ToSql()
+	if err != nil || predSql == "" {
+		return "", args, err
+	}
+
+	sql = "NOT (" + predSql + ")"
+	return
+}
+
 func isListType(val interface{}) bool {
+	switch val.(type) {
+	case []byte:
+		return false
+	case []interface{}, []string, []int, []int64:
+		return true
+	}
 	if driver.IsValue(val) {
 		return false
 	}
@@ -295,6 +733,33 @@ func isListType(val interface{}) bool {
 	return valVal.Kind() == reflect.Array || valVal.Kind() == reflect.Slice
 }
 
+// appendListValues appends the elements of the list val to args. The common
+// slice types are handled without reflection.
+func appendListValues(args []interface{}, val interface{}) []interface{} {
+	switch v := val.(type) {
+	case []interface{}:
+		return append(args, v...)
+	case []string:
+		for _, e := range v {
+			args = append(args, e)
+		}
+	case []int:
+		for _, e := range v {
+			args = append(args, e)
+		}
+	case []int64:
+		for _, e := range v {
+			args = append(args, e)
+		}
+	default:
+		valVal := reflect.ValueOf(val)
+		for i := 0; i < valVal.Len(); i++ {
+			args = append(args, valVal.Index(i).Interface())
+		}
+	}
+	return args
+}
+
 func hasSqlizer(args []interface{}) bool {
 	for _, arg := range args {
 		_, ok := arg.(Sqlizer)
@@ -313,8 +778,15 @@ func NewEq() *EqSlice {
 	return &EqSlice{}
 }
 
+// NewEqFromMap creates EqSlice from a map, e.g. Eq, with its columns sorted by name
+func NewEqFromMap(m map[string]interface{}) *EqSlice {
+	return &EqSlice{slice: sortedColumnValues(m)}
+}
+
 type EqSlice struct {
-	slice []columnValue
+	slice   []columnValue
+	escape  rune
+	dialect Dialect
 }
 
 func (lt *EqSlice) Append(column string, value interface{}) *EqSlice {
@@ -326,15 +798,14 @@ func (lt *EqSlice) Append(column string, value interface{}) *EqSlice {
 	return lt
 }
 
-func (lt EqSlice) toSql(useNotOpr, useOr, useLike, insensitiveLike bool) (sql string, args []interface{}, err error) {
+func (lt EqSlice) toSql(o operators, useOr bool) (sql string, args []interface{}, err error) {
 	var exprs []string
-	o := newOperators(useNotOpr, useLike, insensitiveLike)
 
 	for _, cv := range lt.slice {
 		key := cv.column
 		val := cv.value
 
-		expr, sargs, err := keyVal(key, val, useLike, o)
+		expr, sargs, err := keyVal(key, val, o)
 		if err != nil {
 			return sql, args, err
 		}
@@ -359,7 +830,7 @@ func (eq EqSlice) Len() int {
 
 // ToSql builds the query into a SQL string and bound args.
 func (lt EqSlice) ToSql() (string, []interface{}, error) {
-	return lt.toSql(false, false, false, false)
+	return lt.toSql(newOperators(false, false, false), false)
 }
 
 // NotEq is syntactic sugar for use with Where/Having/Set methods.
@@ -369,13 +840,18 @@ func NewNotEq() *NotEqSlice {
 	return &NotEqSlice{}
 }
 
+// NewNotEqFromMap creates NotEqSlice from a map, e.g. NotEq, with its columns sorted by name
+func NewNotEqFromMap(m map[string]interface{}) *NotEqSlice {
+	return &NotEqSlice{EqSlice{slice: sortedColumnValues(m)}}
+}
+
 type NotEqSlice struct {
 	EqSlice
 }
 
 // ToSql builds the query into a SQL string and bound args.
 func (s NotEqSlice) ToSql() (sql string, args []interface{}, err error) {
-	return s.toSql(true, false, false, false)
+	return s.toSql(newOperators(true, false, false), false)
 }
 
 func (s *NotEqSlice) Append(column string, value interface{}) *NotEqSlice {
@@ -395,13 +871,18 @@ func NewEqOr() *EqOrSlice {
 	return &EqOrSlice{}
 }
 
+// NewEqOrFromMap creates EqOrSlice from a map, e.g. EqOr, with its columns sorted by name
+func NewEqOrFromMap(m map[string]interface{}) *EqOrSlice {
+	return &EqOrSlice{EqSlice{slice: sortedColumnValues(m)}}
+}
+
 type EqOrSlice struct {
 	EqSlice
 }
 
 // ToSql builds the query into a SQL string and bound args.
 func (eqor EqOrSlice) ToSql() (sql string, args []interface{}, err error) {
-	return eqor.toSql(false, true, false, false)
+	return eqor.toSql(newOperators(false, false, false), true)
 }
 
 func (s *EqOrSlice) Append(column string, value interface{}) *EqOrSlice {
@@ -421,13 +902,34 @@ func NewLikeOr() *LikeOrSlice {
 	return &LikeOrSlice{}
 }
 
+// NewLikeOrFromMap creates LikeOrSlice from a map, e.g. LikeOr, with its columns sorted by name
+func NewLikeOrFromMap(m map[string]interface{}) *LikeOrSlice {
+	return &LikeOrSlice{EqSlice{slice: sortedColumnValues(m)}}
+}
+
 type LikeOrSlice struct {
 	EqSlice
 }
 
 // ToSql builds the query into a SQL string and bound args.
 func (likeor LikeOrSlice) ToSql() (sql string, args []interface{}, err error) {
-	return likeor.toSql(false, true, true, false)
+	o := newOperators(false, true, false)
+	o.escape, o.dialect = likeor.escape, likeor.dialect
+	return likeor.toSql(o, true)
+}
+
+func (likeor LikeOrSlice) defaultDialect(d Dialect) Sqlizer {
+	if likeor.dialect == nil {
+		likeor.dialect = d
+	}
+	return likeor
+}
+
+// Escape sets the character used to escape wildcards in the patterns,
+// which adds an ESCAPE clause to every LIKE expression.
+func (s *LikeOrSlice) Escape(escape rune) *LikeOrSlice {
+	s.escape = escape
+	return s
 }
 
 func (s *LikeOrSlice) Append(column string, value interface{}) *LikeOrSlice {
@@ -447,13 +949,34 @@ func NewILikeOr() *ILikeOrSlice {
 	return &ILikeOrSlice{}
 }
 
+// NewILikeOrFromMap creates ILikeOrSlice from a map, e.g. ILikeOr, with its columns sorted by name
+func NewILikeOrFromMap(m map[string]interface{}) *ILikeOrSlice {
+	return &ILikeOrSlice{EqSlice{slice: sortedColumnValues(m)}}
+}
+
 type ILikeOrSlice struct {
 	EqSlice
 }
 
 // ToSql builds the query into a SQL string and bound args.
 func (likeor ILikeOrSlice) ToSql() (sql string, args []interface{}, err error) {
-	return likeor.toSql(false, true, true, true)
+	o := newOperators(false, true, true)
+	o.escape, o.dialect = likeor.escape, likeor.dialect
+	return likeor.toSql(o, true)
+}
+
+func (likeor ILikeOrSlice) defaultDialect(d Dialect) Sqlizer {
+	if likeor.dialect == nil {
+		likeor.dialect = d
+	}
+	return likeor
+}
+
+// Escape sets the character used to escape wildcards in the patterns,
+// which adds an ESCAPE clause to every LIKE expression.
+func (s *ILikeOrSlice) Escape(escape rune) *ILikeOrSlice {
+	s.escape = escape
+	return s
 }
 
 func (s *ILikeOrSlice) Append(column string, value interface{}) *ILikeOrSlice {
@@ -466,21 +989,147 @@ func (s ILikeOrSlice) Len() int {
 	return s.EqSlice.Len()
 }
 
+// EqFold is syntactic sugar for use with Where/Having/Set methods.
+// Ex:
+//     .Where(NewEqFold().Append("email", "Joe@Example.com")) == "LOWER(email) = LOWER('Joe@Example.com')"
+func NewEqFold() *EqFoldSlice {
+	return &EqFoldSlice{}
+}
+
+// NewEqFoldFromMap creates EqFoldSlice from a map, e.g. EqFold, with its columns sorted by name
+func NewEqFoldFromMap(m map[string]interface{}) *EqFoldSlice {
+	return &EqFoldSlice{EqSlice{slice: sortedColumnValues(m)}}
+}
+
+type EqFoldSlice struct {
+	EqSlice
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (eq EqFoldSlice) ToSql() (sql string, args []interface{}, err error) {
+	return eq.toSql(newLowerOperators(false, false), false)
+}
+
+func (s *EqFoldSlice) Append(column string, value interface{}) *EqFoldSlice {
+	s.EqSlice.Append(column, value)
+	return s
+}
+
+// Gives back the length of how many items are appended
+func (s EqFoldSlice) Len() int {
+	return s.EqSlice.Len()
+}
+
+// LowerLikeOr is syntactic sugar for use with Where/Having/Set methods.
+// Ex:
+//     .Where(NewLowerLikeOr().Append("email", "joe%")) == "LOWER(email) LIKE LOWER('joe%')"
+func NewLowerLikeOr() *LowerLikeOrSlice {
+	return &LowerLikeOrSlice{}
+}
+
+// NewLowerLikeOrFromMap creates LowerLikeOrSlice from a map, e.g. LowerLikeOr, with its columns sorted by name
+func NewLowerLikeOrFromMap(m map[string]interface{}) *LowerLikeOrSlice {
+	return &LowerLikeOrSlice{EqSlice{slice: sortedColumnValues(m)}}
+}
+
+type LowerLikeOrSlice struct {
+	EqSlice
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (likeor LowerLikeOrSlice) ToSql() (sql string, args []interface{}, err error) {
+	o := newLowerOperators(false, true)
+	o.escape, o.dialect = likeor.escape, likeor.dialect
+	return likeor.toSql(o, true)
+}
+
+func (likeor LowerLikeOrSlice) defaultDialect(d Dialect) Sqlizer {
+	if likeor.dialect == nil {
+		likeor.dialect = d
+	}
+	return likeor
+}
+
+// Escape sets the character used to escape wildcards in the patterns,
+// which adds an ESCAPE clause to every LIKE expression.
+func (s *LowerLikeOrSlice) Escape(escape rune) *LowerLikeOrSlice {
+	s.escape = escape
+	return s
+}
+
+func (s *LowerLikeOrSlice) Append(column string, value interface{}) *LowerLikeOrSlice {
+	s.EqSlice.Append(column, value)
+	return s
+}
+
+// Gives back the length of how many items are appended
+func (s LowerLikeOrSlice) Len() int {
+	return s.EqSlice.Len()
+}
+
 type columnValue struct {
 	column string
 	value  interface{}
 }
 
-func keyVal(key string, val interface{}, useLike bool, o operators) (expr string, args []interface{}, err error) {
+// sortMapKeys is set to 1 by SortMapKeys, accessed atomically.
+var sortMapKeys int32
+
+// SortMapKeys sets whether map based predicates, like Eq, EqOr or Lt, build
+// their columns sorted by name. By default they follow the random map
+// order, so the same predicate may build different SQL. Sorting makes it
+// stable, e.g. for golden tests or caching plans, at some cost.
+func SortMapKeys(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&sortMapKeys, v)
+}
+
+// mapColumnValues returns the columns of m, sorted if SortMapKeys is set.
+func mapColumnValues(m map[string]interface{}) []columnValue {
+	if atomic.LoadInt32(&sortMapKeys) != 0 {
+		return sortedColumnValues(m)
+	}
+	cvs := make([]columnValue, 0, len(m))
+	for key, val := range m {
+		cvs = append(cvs, columnValue{column: key, value: val})
+	}
+	return cvs
+}
+
+// sortedColumnValues converts m into columnValues ordered by column name
+func sortedColumnValues(m map[string]interface{}) []columnValue {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	cvs := make([]columnValue, len(keys))
+	for i, key := range keys {
+		cvs[i] = columnValue{column: key, value: m[key]}
+	}
+	return cvs
+}
+
+func keyVal(key string, val interface{}, o operators) (expr string, args []interface{}, err error) {
 	switch v := val.(type) {
-	case *SelectBuilder:
+	case *SelectBuilder, subQuery:
+		if o.lower {
+			// the columns of the subquery can't be wrapped in LOWER()
+			err = fmt.Errorf("cannot compare %s case-insensitively with a subquery", key)
+			return
+		}
+
 		// Placeholders will not be replaced
-		selectSql, sargs, err := v.toSql(false)
+		selectSql, sargs, err := nestedToSql(v.(Sqlizer))
 		if err != nil {
 			return expr, args, err
 		}
 
-		expr = fmt.Sprintf("%s %s (%s)", key, o.inOpr, selectSql)
+		expr = key + " " + o.inOpr + " (" + selectSql + ")"
 		args = append(args, sargs...)
 
 		return expr, args, err
@@ -489,37 +1138,48 @@ func keyVal(key string, val interface{}, useLike bool, o operators) (expr string
 			return
 		}
 		break // Continue after break
+	case Sqlizer:
+		sqlizerSql, sargs, err := v.ToSql()
+		if err != nil {
+			return expr, args, err
+		}
+
+		expr = o.wrap(key) + " " + o.equalOpr + " " + o.wrap(sqlizerSql)
+		args = append(args, sargs...)
+
+		return expr, args, err
 	}
 
 	if val == nil {
-		if useLike {
+		if o.like {
 			err = fmt.Errorf("cannot use like with a slice or an array")
 			return
 		}
 
-		expr = fmt.Sprintf("%s %s NULL", key, o.nullOpr)
+		expr = key + " " + o.nullOpr + " NULL"
 	} else {
 		if isListType(val) {
-			valVal := reflect.ValueOf(val)
-
-			if useLike {
+			if o.like {
 				err = fmt.Errorf("cannot use like with a slice or an array")
 				return
 			}
 
-			if valVal.Len() == 0 {
+			args = appendListValues(args, val)
+			if len(args) == 0 {
 				expr = o.inEmptyExpr
-				if args == nil {
-					args = []interface{}{}
-				}
+				args = []interface{}{}
 			} else {
-				for i := 0; i < valVal.Len(); i++ {
-					args = append(args, valVal.Index(i).Interface())
-				}
-				expr = fmt.Sprintf("%s %s (%s)", key, o.inOpr, Placeholders(valVal.Len()))
+				expr = o.wrap(key) + " " + o.inOpr + " (" + o.placeholders(len(args)) + ")"
 			}
 		} else {
-			expr = fmt.Sprintf("%s %s ?", key, o.equalOpr)
+			expr = o.wrap(key) + " " + o.equalOpr + " " + o.wrap("?")
+			if o.like && o.escape != 0 {
+				escape, err := escapeLiteral(string(o.escape), o.dialect)
+				if err != nil {
+					return expr, args, err
+				}
+				expr += " ESCAPE " + escape
+			}
 			args = append(args, val)
 		}
 	}
@@ -527,8 +1187,70 @@ func keyVal(key string, val interface{}, useLike bool, o operators) (expr string
 	return
 }
 
+// compareKeyVal renders a single "<key> <opr> <value>" comparison for the
+// Lt/Gt family. Subqueries and other Sqlizers are inlined in parentheses.
+func compareKeyVal(key string, val interface{}, opr string) (expr string, args []interface{}, err error) {
+	switch v := val.(type) {
+	case *SelectBuilder, subQuery:
+		// Placeholders will not be replaced
+		selectSql, sargs, err := nestedToSql(v.(Sqlizer))
+		if err != nil {
+			return expr, args, err
+		}
+
+		expr = key + " " + opr + " (" + selectSql + ")"
+		return expr, sargs, nil
+	case driver.Valuer:
+		if val, err = v.Value(); err != nil {
+			return
+		}
+	case Sqlizer:
+		sqlizerSql, sargs, err := v.ToSql()
+		if err != nil {
+			return expr, args, err
+		}
+
+		expr = key + " " + opr + " (" + sqlizerSql + ")"
+		return expr, sargs, nil
+	}
+
+	if val == nil {
+		err = fmt.Errorf("cannot use null with less than or greater than operators")
+		return
+	}
+
+	if isListType(val) {
+		err = fmt.Errorf("cannot use array or slice with less than or greater than operators")
+		return
+	}
+
+	expr = key + " " + opr + " ?"
+	args = append(args, val)
+	return
+}
+
 type operators struct {
 	equalOpr, inOpr, nullOpr, inEmptyExpr string
+	like, lower                           bool
+	escape                                rune
+	// dialect is used to render the ESCAPE character
+	dialect Dialect
+}
+
+// wrap applies LOWER() to s when values are compared case-insensitively
+func (o operators) wrap(s string) string {
+	if o.lower {
+		return "LOWER(" + s + ")"
+	}
+	return s
+}
+
+// placeholders returns count placeholders for an IN list
+func (o operators) placeholders(count int) string {
+	if o.lower {
+		return strings.Repeat(",LOWER(?)", count)[1:]
+	}
+	return Placeholders(count)
 }
 
 func newOperators(useNotOpr, useLike, insensitiveLike bool) (o operators) {
@@ -537,6 +1259,7 @@ func newOperators(useNotOpr, useLike, insensitiveLike bool) (o operators) {
 		inOpr:       "IN",
 		nullOpr:     "IS",
 		inEmptyExpr: "(1=0)", // Portable FALSE
+		like:        useLike,
 	}
 
 	switch {
@@ -572,6 +1295,14 @@ func newOperators(useNotOpr, useLike, insensitiveLike bool) (o operators) {
 	return
 }
 
+// newLowerOperators returns operators which compare LOWER() of both sides,
+// a portable way to match case-insensitively
+func newLowerOperators(useNotOpr, useLike bool) (o operators) {
+	o = newOperators(useNotOpr, useLike, false)
+	o.lower = true
+	return
+}
+
 // LtSlice is syntactic sugar for use with Where/Having/Set methods.
 // It provides a stable alternative to Lt (which is a map in which order is random, this makes it hard to test)
 // Ex:
@@ -580,6 +1311,11 @@ func NewLt() *LtSlice {
 	return &LtSlice{}
 }
 
+// NewLtFromMap creates LtSlice from a map, e.g. Lt, with its columns sorted by name
+func NewLtFromMap(m map[string]interface{}) *LtSlice {
+	return &LtSlice{lts: sortedColumnValues(m)}
+}
+
 type LtSlice struct {
 	lts []columnValue
 }
@@ -604,34 +1340,17 @@ func (lt LtSlice) toSql(opposite, orEq bool) (sql string, args []interface{}, er
 	}
 
 	if orEq {
-		opr = fmt.Sprintf("%s%s", opr, "=")
+		opr += "="
 	}
 
 	for _, cv := range lt.lts {
-		expr := ""
-		key := cv.column
-		val := cv.value
-
-		switch v := val.(type) {
-		case driver.Valuer:
-			if val, err = v.Value(); err != nil {
-				return
-			}
+		expr, sargs, err := compareKeyVal(cv.column, cv.value, opr)
+		if err != nil {
+			return sql, args, err
 		}
 
-		if val == nil {
-			err = fmt.Errorf("cannot use null with less than or greater than operators")
-			return
-		} else {
-			if isListType(val) {
-				err = fmt.Errorf("cannot use array or slice with less than or greater than operators")
-				return
-			} else {
-				expr = fmt.Sprintf("%s %s ?", key, opr)
-				args = append(args, val)
-			}
-		}
 		exprs = append(exprs, expr)
+		args = append(args, sargs...)
 	}
 
 	sql = strings.Join(exprs, " AND ")
@@ -655,6 +1374,11 @@ func NewLtOrEq() *LtOrEqSlice {
 	return &LtOrEqSlice{}
 }
 
+// NewLtOrEqFromMap creates LtOrEqSlice from a map, e.g. LtOrEq, with its columns sorted by name
+func NewLtOrEqFromMap(m map[string]interface{}) *LtOrEqSlice {
+	return &LtOrEqSlice{LtSlice{lts: sortedColumnValues(m)}}
+}
+
 type LtOrEqSlice struct {
 	LtSlice
 }
@@ -680,6 +1404,11 @@ func NewGt() *GtSlice {
 	return &GtSlice{}
 }
 
+// NewGtFromMap creates GtSlice from a map, e.g. Gt, with its columns sorted by name
+func NewGtFromMap(m map[string]interface{}) *GtSlice {
+	return &GtSlice{LtSlice{lts: sortedColumnValues(m)}}
+}
+
 type GtSlice struct {
 	LtSlice
 }
@@ -705,6 +1434,11 @@ func NewGtOrEq() *GtOrEqSlice {
 	return &GtOrEqSlice{}
 }
 
+// NewGtOrEqFromMap creates GtOrEqSlice from a map, e.g. GtOrEq, with its columns sorted by name
+func NewGtOrEqFromMap(m map[string]interface{}) *GtOrEqSlice {
+	return &GtOrEqSlice{LtSlice{lts: sortedColumnValues(m)}}
+}
+
 type GtOrEqSlice struct {
 	LtSlice
 }
@@ -722,3 +1456,80 @@ func (s *GtOrEqSlice) Append(column string, value interface{}) *GtOrEqSlice {
 func (s GtOrEqSlice) Len() int {
 	return s.LtSlice.Len()
 }
+
+// EqCol is syntactic sugar for use with Where/Having/Join methods.
+// Unlike Eq, the values are column names which are not bound as args.
+// Ex:
+//     .Where(EqCol{"a.id": "b.a_id"}) == "a.id = b.a_id"
+type EqCol map[string]string
+
+func (c EqCol) toSql(opr string) (sql string, args []interface{}, err error) {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	exprs := make([]string, len(keys))
+	for i, key := range keys {
+		exprs[i] = key + " " + opr + " " + c[key]
+	}
+
+	sql = strings.Join(exprs, " AND ")
+	return
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (c EqCol) ToSql() (sql string, args []interface{}, err error) {
+	return c.toSql("=")
+}
+
+// NotEqCol is syntactic sugar for use with Where/Having/Join methods.
+// Ex:
+//     .Where(NotEqCol{"a.id": "b.a_id"}) == "a.id <> b.a_id"
+type NotEqCol EqCol
+
+// ToSql builds the query into a SQL string and bound args.
+func (c NotEqCol) ToSql() (sql string, args []interface{}, err error) {
+	return EqCol(c).toSql("<>")
+}
+
+// LtCol is syntactic sugar for use with Where/Having/Join methods.
+// Ex:
+//     .Where(LtCol{"a.start": "b.end"}) == "a.start < b.end"
+type LtCol EqCol
+
+// ToSql builds the query into a SQL string and bound args.
+func (c LtCol) ToSql() (sql string, args []interface{}, err error) {
+	return EqCol(c).toSql("<")
+}
+
+// LtOrEqCol is syntactic sugar for use with Where/Having/Join methods.
+// Ex:
+//     .Where(LtOrEqCol{"a.start": "b.end"}) == "a.start <= b.end"
+type LtOrEqCol EqCol
+
+// ToSql builds the query into a SQL string and bound args.
+func (c LtOrEqCol) ToSql() (sql string, args []interface{}, err error) {
+	return EqCol(c).toSql("<=")
+}
+
+// GtCol is syntactic sugar for use with Where/Having/Join methods.
+// Ex:
+//     .Where(GtCol{"a.end": "b.start"}) == "a.end > b.start"
+type GtCol EqCol
+
+// ToSql builds the query into a SQL string and bound args.
+func (c GtCol) ToSql() (sql string, args []interface{}, err error) {
+	return EqCol(c).toSql(">")
+}
+
+// GtOrEqCol is syntactic sugar for use with Where/Having/Join methods.
+// Ex:
+//     .Where(GtOrEqCol{"a.end": "b.start"}) == "a.end >= b.start"
+type GtOrEqCol EqCol
+
+// ToSql builds the query into a SQL string and bound args.
+func (c GtOrEqCol) ToSql() (sql string, args []interface{}, err error) {
+	return EqCol(c).toSql(">=")
+}
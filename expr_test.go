@@ -56,6 +56,9 @@ func TestEqNotInToSql(t *testing.T) {
 }
 
 func TestEqOrInToSql(t *testing.T) {
+	SortMapKeys(true)
+	defer SortMapKeys(false)
+
 	b := EqOr{
 		"id":   []int{1, 2, 3},
 		"name": "Joe",
@@ -71,6 +74,9 @@ func TestEqOrInToSql(t *testing.T) {
 }
 
 func TestLikeOrInToSql(t *testing.T) {
+	SortMapKeys(true)
+	defer SortMapKeys(false)
+
 	b := LikeOr{
 		"id":   1,
 		"name": "Joe",
@@ -86,6 +92,9 @@ func TestLikeOrInToSql(t *testing.T) {
 }
 
 func TestILikeOrInToSql(t *testing.T) {
+	SortMapKeys(true)
+	defer SortMapKeys(false)
+
 	b := ILikeOr{
 		"id":   1,
 		"name": "Joe",
@@ -100,6 +109,24 @@ func TestILikeOrInToSql(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
+func TestSortMapKeys(t *testing.T) {
+	SortMapKeys(true)
+	defer SortMapKeys(false)
+
+	b := And{
+		Eq{"c": 3, "a": 1, "b": 2},
+		Gt{"z": 26, "y": 25},
+	}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "(a = ? AND b = ? AND c = ? AND y > ? AND z > ?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{1, 2, 3, 25, 26}
+	assert.Equal(t, expectedArgs, args)
+}
+
 func TestEqInEmptyToSql(t *testing.T) {
 	b := Eq{"id": []int{}}
 	sql, args, err := b.ToSql()
@@ -311,6 +338,30 @@ func TestNestedExprQueryWithDollarPlaceholders(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
+func TestEqListTypesToSql(t *testing.T) {
+	type ids []uint
+	vals := []interface{}{
+		[]int{1, 2},
+		[]int64{1, 2},
+		[]string{"a", "b"},
+		[]interface{}{1, "b"},
+		[2]int{1, 2},
+		ids{1, 2},
+	}
+	for _, val := range vals {
+		sql, args, err := Eq{"id": val}.ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, "id IN (?,?)", sql)
+		assert.Len(t, args, 2)
+	}
+
+	_, args, _ := Eq{"id": []int64{1, 2}}.ToSql()
+	assert.Equal(t, []interface{}{int64(1), int64(2)}, args)
+
+	assert.False(t, isListType([]byte("test")))
+	assert.False(t, isListType("test"))
+}
+
 func TestEqSliceToSql(t *testing.T) {
 	b := NewEq().Append("id", 1)
 	sql, args, err := b.ToSql()
@@ -601,3 +652,449 @@ func TestNestedExprSliceQueryWithDollarPlaceholders(t *testing.T) {
 	expectedArgs := []interface{}{"c", "ccc", "ddd"}
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestLtSubqueryToSql(t *testing.T) {
+	subQ := Select("avg(price)").From("products").Where(Eq{"category": "books"})
+	b := Update("products").
+		PlaceholderFormat(Dollar).
+		Set("discounted", true).
+		Where(Lt{"price": subQ})
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "UPDATE products SET discounted = $1 " +
+		"WHERE price < (SELECT avg(price) FROM products WHERE category = $2)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{true, "books"}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestGtOrEqSqlizerToSql(t *testing.T) {
+	b := GtOrEq{"created_at": Expr("NOW() - ?", "1 day")}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "created_at >= (NOW() - ?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"1 day"}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestGtSliceSubqueryToSql(t *testing.T) {
+	subQ := Select("max(score)").From("scores").Where("team = ?", 3)
+	b := NewGt().Append("score", subQ).Append("level", 2)
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "score > (SELECT max(score) FROM scores WHERE team = ?) AND level > ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{3, 2}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestEqColToSql(t *testing.T) {
+	b := EqCol{"a.id": "b.a_id", "a.org_id": "b.org_id"}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "a.id = b.a_id AND a.org_id = b.org_id"
+	assert.Equal(t, expectedSql, sql)
+	assert.Empty(t, args)
+}
+
+func TestColComparisonsToSql(t *testing.T) {
+	tests := []struct {
+		pred Sqlizer
+		sql  string
+	}{
+		{NotEqCol{"a.id": "b.id"}, "a.id <> b.id"},
+		{LtCol{"a.start": "b.end"}, "a.start < b.end"},
+		{LtOrEqCol{"a.start": "b.end"}, "a.start <= b.end"},
+		{GtCol{"a.end": "b.start"}, "a.end > b.start"},
+		{GtOrEqCol{"a.end": "b.start"}, "a.end >= b.start"},
+	}
+
+	for _, test := range tests {
+		sql, args, err := test.pred.ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Empty(t, args)
+	}
+}
+
+func TestEqColInJoin(t *testing.T) {
+	sql, args, err := Select("*").
+		From("a").
+		JoinClause(Expr("JOIN b ON ?", EqCol{"a.id": "b.a_id"})).
+		Where(Eq{"b.kind": 1}).
+		ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SELECT * FROM a JOIN b ON a.id = b.a_id WHERE b.kind = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
+
+func TestNotToSql(t *testing.T) {
+	b := Not(Or{Expr("a > ?", 15), And{Eq{"b": 20}, Expr("c IS NULL")}})
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "NOT ((a > ? OR (b = ? AND c IS NULL)))"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{15, 20}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestNotEmptyToSql(t *testing.T) {
+	b := And{Not(And{}), Expr("a = ?", 1)}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "(a = ?)", sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
+
+func TestSubQueryToSql(t *testing.T) {
+	subQ := Select("1").From("b").Where("b.a_id = a.id AND b.kind = ?", 2)
+	b := Select("a.id").
+		From("a").
+		Where("a.state = ?", 1).
+		Where(Expr("EXISTS ?", SubQuery(subQ))).
+		Where(Eq{"a.owner": 3}).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT a.id FROM a WHERE a.state = $1 " +
+		"AND EXISTS (SELECT 1 FROM b WHERE b.a_id = a.id AND b.kind = $2) " +
+		"AND a.owner = $3"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{1, 2, 3}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestSubQueryKeepsPlaceholders(t *testing.T) {
+	sb := StatementBuilder.PlaceholderFormat(Dollar)
+	subQ := sb.Select("id").From("b").Where("kind = ?", 2)
+
+	sql, args, err := sb.Select("*").
+		From("a").
+		Where("x = ?", 1).
+		Where(Eq{"b_id": SubQuery(subQ)}).
+		ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SELECT * FROM a WHERE x = $1 AND b_id IN (SELECT id FROM b WHERE kind = $2)", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, args, err = sb.Select("*").
+		Column(Alias(subQ, "sub")).
+		Where("x = ?", 1).
+		ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SELECT *, (SELECT id FROM b WHERE kind = $1) AS sub WHERE x = $2", sql)
+	assert.Equal(t, []interface{}{2, 1}, args)
+}
+
+func TestLowerLikeOrToSql(t *testing.T) {
+	b := LowerLikeOr{"name": "Joe%"}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "LOWER(name) LIKE LOWER(?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"Joe%"}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestLowerLikeOrSliceToSql(t *testing.T) {
+	b := NewLowerLikeOr().
+		Append("email", "joe%").
+		Append("name", "Joe%")
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "LOWER(email) LIKE LOWER(?) OR LOWER(name) LIKE LOWER(?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"joe%", "Joe%"}
+	assert.Equal(t, expectedArgs, args)
+
+	_, _, err = NewLowerLikeOr().Append("name", []string{"a"}).ToSql()
+	assert.Error(t, err)
+}
+
+func TestLikeOrEscapeToSql(t *testing.T) {
+	b := LikeOr{"code": `50\%%`}.Escape('\\')
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := `code LIKE ? ESCAPE E'\\'`
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{`50\%%`}
+	assert.Equal(t, expectedArgs, args)
+
+	sql, _, err = ILikeOr{"code": "a'_%"}.Escape('\'').ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "code ILIKE ? ESCAPE ''''", sql)
+}
+
+func TestLikeOrEscapeDialect(t *testing.T) {
+	sql, _, err := Select("*").From("t").Dialect(MySQL).Where(LikeOr{"code": `50\%%`}.Escape('\\')).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `t` WHERE code LIKE ? ESCAPE '\\\\'", sql)
+
+	sql, _, err = Select("*").From("t").Dialect(Postgres).Where(LowerLikeOr{"code": `50\%%`}.Escape('\\')).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT * FROM t WHERE LOWER(code) LIKE LOWER($1) ESCAPE E'\\'`, sql)
+
+	sql, _, err = Select("*").From("t").Dialect(SQLite).Where(NewLikeOr().Append("code", `50\%%`).Escape('\\')).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT * FROM t WHERE code LIKE ? ESCAPE '\'`, sql)
+
+	sql, _, err = Select("*").From("t").Dialect(MySQL).Where(NewLowerLikeOr().Append("code", `50\%%`).Escape('\\')).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `t` WHERE LOWER(code) LIKE LOWER(?) ESCAPE '\\\\'", sql)
+}
+
+func TestLikeOrSliceEscapeToSql(t *testing.T) {
+	b := NewLikeOr().
+		Append("code", "a!_%").
+		Append("name", "b!%").
+		Escape('!')
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "code LIKE ? ESCAPE '!' OR name LIKE ? ESCAPE '!'"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"a!_%", "b!%"}
+	assert.Equal(t, expectedArgs, args)
+
+	sql, _, err = NewLowerLikeOr().Append("code", "a!_%").Escape('!').ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "LOWER(code) LIKE LOWER(?) ESCAPE '!'", sql)
+}
+
+func TestEqFoldToSql(t *testing.T) {
+	b := EqFold{"email": "Joe@Example.com"}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "LOWER(email) = LOWER(?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"Joe@Example.com"}
+	assert.Equal(t, expectedArgs, args)
+
+	sql, args, err = NotEqFold{"email": []string{"a@b.c", "D@e.f"}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "LOWER(email) NOT IN (LOWER(?),LOWER(?))", sql)
+	assert.Equal(t, []interface{}{"a@b.c", "D@e.f"}, args)
+
+	sql, args, err = EqFold{"email": nil}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "email IS NULL", sql)
+	assert.Empty(t, args)
+
+	sub := Select("email").From("banned")
+	for _, b := range []Sqlizer{
+		EqFold{"email": sub},
+		NotEqFold{"email": SubQuery(sub)},
+		LowerLikeOr{"email": sub},
+		NewEqFold().Append("email", sub),
+	} {
+		_, _, err = b.ToSql()
+		assert.Error(t, err)
+	}
+}
+
+func TestEqFoldSliceToSql(t *testing.T) {
+	b := NewEqFold().
+		Append("email", "Joe@Example.com").
+		Append("login", []string{"Joe", "JOE"})
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "LOWER(email) = LOWER(?) AND LOWER(login) IN (LOWER(?),LOWER(?))"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"Joe@Example.com", "Joe", "JOE"}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestExprNamedToSql(t *testing.T) {
+	b := ExprNamed(
+		"a = :a AND b = :b AND (c = :a OR d::text = :d_1) AND e IN :sub AND t > '10:30'",
+		map[string]interface{}{"a": 1, "b": 2, "d_1": "x", "sub": SubQuery(Select("id").From("f").Where("g = ?", 3))},
+	)
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "a = ? AND b = ? AND (c = ? OR d::text = ?) AND e IN (SELECT id FROM f WHERE g = ?) AND t > '10:30'"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{1, 2, 1, "x", 3}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestExprNamedQuoted(t *testing.T) {
+	sql, args, err := ExprNamed(`a = 'x:y' AND "c:d" = :b AND e = 'it''s :e'`, map[string]interface{}{"b": 2}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `a = 'x:y' AND "c:d" = ? AND e = 'it''s :e'`, sql)
+	assert.Equal(t, []interface{}{2}, args)
+}
+
+func TestExprNamedMissingArg(t *testing.T) {
+	_, _, err := ExprNamed("a = :a AND b = :b", map[string]interface{}{"a": 1}).ToSql()
+	assert.Error(t, err)
+}
+
+func TestEqSqlizerValueToSql(t *testing.T) {
+	b := Eq{"updated_at": Expr("date_trunc('day', ?)", "2019-12-18")}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "updated_at = date_trunc('day', ?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{"2019-12-18"}
+	assert.Equal(t, expectedArgs, args)
+
+	sql, args, err = NotEq{"state": Case("kind").When("1", "'a'").Else("'b'")}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "state <> CASE kind WHEN 1 THEN 'a' ELSE 'b' END", sql)
+	assert.Empty(t, args)
+}
+
+func TestEqSliceSqlizerValueToSql(t *testing.T) {
+	b := NewEq().
+		Append("a", 1).
+		Append("b", Expr("COALESCE(c, ?)", 2))
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "a = ? AND b = COALESCE(c, ?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{1, 2}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestSliceFromMapToSql(t *testing.T) {
+	tests := []struct {
+		pred Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{NewEqFromMap(Eq{"b": 2, "a": []int{1, 3}}), "a IN (?,?) AND b = ?", []interface{}{1, 3, 2}},
+		{NewNotEqFromMap(NotEq{"b": 2, "a": nil}), "a IS NOT NULL AND b <> ?", []interface{}{2}},
+		{NewEqOrFromMap(EqOr{"name": "Joe", "id": 1}), "id = ? OR name = ?", []interface{}{1, "Joe"}},
+		{NewLikeOrFromMap(LikeOr{"name": "Joe%", "email": "joe%"}), "email LIKE ? OR name LIKE ?", []interface{}{"joe%", "Joe%"}},
+		{NewILikeOrFromMap(ILikeOr{"name": "Joe%", "email": "joe%"}), "email ILIKE ? OR name ILIKE ?", []interface{}{"joe%", "Joe%"}},
+		{NewLowerLikeOrFromMap(LowerLikeOr{"name": "Joe%"}), "LOWER(name) LIKE LOWER(?)", []interface{}{"Joe%"}},
+		{NewEqFoldFromMap(EqFold{"name": "Joe"}), "LOWER(name) = LOWER(?)", []interface{}{"Joe"}},
+		{NewLtFromMap(Lt{"b": 2, "a": 1}), "a < ? AND b < ?", []interface{}{1, 2}},
+		{NewLtOrEqFromMap(LtOrEq{"b": 2, "a": 1}), "a <= ? AND b <= ?", []interface{}{1, 2}},
+		{NewGtFromMap(Gt{"b": 2, "a": 1}), "a > ? AND b > ?", []interface{}{1, 2}},
+		{NewGtOrEqFromMap(GtOrEq{"b": 2, "a": 1}), "a >= ? AND b >= ?", []interface{}{1, 2}},
+	}
+
+	for _, test := range tests {
+		sql, args, err := test.pred.ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func BenchmarkEqToSql(b *testing.B) {
+	eq := Eq{"id": 1}
+	in := Eq{"id": []int{1, 2, 3}}
+	strs := Eq{"name": []string{"a", "b", "c"}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		eq.ToSql()
+		in.ToSql()
+		strs.ToSql()
+	}
+}
+
+func BenchmarkLtToSql(b *testing.B) {
+	lt := Lt{"id": 1}
+	gtOrEq := GtOrEq{"id": 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		lt.ToSql()
+		gtOrEq.ToSql()
+	}
+}
+
+func BenchmarkConjToSql(b *testing.B) {
+	c := And{Expr("a = ?", 1), Or{Expr("b = ?", 2), Expr("c = ?", 3)}, Not(Expr("d"))}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.ToSql()
+	}
+}
+
+func BenchmarkAliasToSql(b *testing.B) {
+	a := Alias(Expr("COUNT(*)"), "n")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.ToSql()
+	}
+}
+
+func TestNestedPartsToSql(t *testing.T) {
+	cond := Or{
+		And{Expr("a = ?", 1), Expr(""), And{}, Eq{"b": []int{2, 3}}},
+		Expr("c IN ?", SubQuery(Select("id").From("d").Where("e ?? ?", 4))),
+		Expr("f = ? OR g = ?", Expr("LOWER(?)", "h")),
+	}
+
+	condSql, condArgs, err := cond.ToSql()
+	assert.NoError(t, err)
+
+	sql, args, err := Select("*").From("t").Where(cond).Where(map[string]interface{}{"i": 5}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE "+condSql+" AND i = ?", sql)
+	assert.Equal(t, append(condArgs, 5), args)
+	assert.Equal(t, "SELECT * FROM t WHERE ((a = ? AND b IN (?,?)) OR c IN (SELECT id FROM d WHERE e ?? ?) "+
+		"OR f = LOWER(?) OR g = ?) AND i = ?", sql)
+
+	sql, args, err = Select("*").From("t").Where(cond).PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE ((a = $1 AND b IN ($2,$3)) OR c IN (SELECT id FROM d WHERE e ? $4) "+
+		"OR f = LOWER($5) OR g = $6)", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, "h"}, args)
+
+	sql, _, err = Select("*").From("t").Where(And{Or{}}).Where("x").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE x", sql)
+
+	_, _, err = Select("*").From("t").Where(Or{Expr("a"), Lt{"b": nil}}).ToSql()
+	assert.Error(t, err)
+}
+
+func BenchmarkNestedPartsToSql(b *testing.B) {
+	qb := Select("*").From("t").Where(Or{
+		And{Eq{"a": 1}, Expr("b > ?", 2)},
+		And{Expr("c IN ?", SubQuery(Select("id").From("d").Where(Eq{"e": 3}))), Or{Expr("f"), Expr("g")}},
+	}).PlaceholderFormat(Dollar)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		qb.ToSql()
+	}
+}
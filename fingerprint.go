@@ -0,0 +1,96 @@
+package sqrl
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+)
+
+// Fingerprint returns a stable hash of the shape of the SQL of s: queries
+// differing only in their args, literals or the length of their IN lists
+// share a fingerprint. It can be used to group executions of the same
+// logical query, e.g. for metrics, statement caches or rate limiters.
+//
+// See NormalizeSQL.
+func Fingerprint(s Sqlizer) (string, error) {
+	sql, _, err := s.ToSql()
+	if err != nil {
+		return "", err
+	}
+	return FingerprintSQL(sql), nil
+}
+
+// FingerprintSQL returns the fingerprint of a SQL string, as Fingerprint
+// does for Sqlizers.
+func FingerprintSQL(query string) string {
+	return fingerprint(NormalizeSQL(query))
+}
+
+// fingerprint hashes normalized SQL.
+func fingerprint(normalized string) string {
+	h := fnv.New64a()
+	h.Write([]byte(normalized))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+var (
+	placeholderListRe = regexp.MustCompile(`\(\?(?:\s*,\s*\?)*\)`)
+	valuesListRe      = regexp.MustCompile(`\(\?\)(?:\s*,\s*\(\?\))+`)
+)
+
+// NormalizeSQL normalizes query to identify it regardless of its args:
+// literals and placeholders are replaced by ?, lists of them, like those of
+// IN or VALUES, are collapsed to (?) and whitespace is collapsed.
+// Ex:
+//     NormalizeSQL("SELECT * FROM t WHERE a IN ($1,$2) AND b = 'x'") == "SELECT * FROM t WHERE a IN (?) AND b = ?"
+func NormalizeSQL(query string) string {
+	buf := &strings.Builder{}
+	buf.Grow(len(query))
+
+	space := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			space = buf.Len() > 0
+			continue
+		}
+		if space {
+			buf.WriteByte(' ')
+			space = false
+		}
+
+		switch {
+		case c == '\'':
+			i += quotedLen(query, i, "") - 1
+			buf.WriteByte('?')
+		case c == '"' || c == '`':
+			// quoted identifiers are kept
+			n := quotedLen(query, i, "")
+			buf.WriteString(query[i : i+n])
+			i += n - 1
+		case (c == '$' || c == ':') && i+1 < len(query) && isDigit(query[i+1]):
+			// numbered placeholders
+			for i+1 < len(query) && isDigit(query[i+1]) {
+				i++
+			}
+			buf.WriteByte('?')
+		case isDigit(c) && (i == 0 || !isNameChar(query[i-1], false) && query[i-1] != '.'):
+			// numeric literals
+			for i+1 < len(query) && (isDigit(query[i+1]) || query[i+1] == '.') {
+				i++
+			}
+			buf.WriteByte('?')
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	fingerprint := placeholderListRe.ReplaceAllString(buf.String(), "(?)")
+	return valuesListRe.ReplaceAllString(fingerprint, "(?)")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,55 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	cases := map[string]string{
+		"SELECT * FROM t WHERE a IN ($1,$2) AND b = 'it''s'":       "SELECT * FROM t WHERE a IN (?) AND b = ?",
+		"SELECT  *\n FROM t1\tWHERE a = 1.5 LIMIT 10":              "SELECT * FROM t1 WHERE a = ? LIMIT ?",
+		"INSERT INTO t (a,b) VALUES (?,?),(?, ?)":                  "INSERT INTO t (a,b) VALUES (?)",
+		"SELECT a::int FROM t WHERE b IN (?,?,?) OR c IN (:1, :2)": "SELECT a::int FROM t WHERE b IN (?) OR c IN (?)",
+	}
+	for query, expected := range cases {
+		assert.Equal(t, expected, NormalizeSQL(query), query)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a, err := Fingerprint(Select("*").From("t").Where(Eq{"id": []int{1, 2}}).Where("b = 'x'"))
+	assert.NoError(t, err)
+	b, err := Fingerprint(Select("*").From("t").Where(Eq{"id": []int{3, 4, 5}}).Where("b = 'y'").PlaceholderFormat(Dollar))
+	assert.NoError(t, err)
+	c, err := Fingerprint(Select("*").From("t").Where(Eq{"name": "a"}))
+	assert.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Len(t, a, 16)
+
+	_, err = Fingerprint(Select())
+	assert.Error(t, err)
+}
+
+func TestFingerprintSQL(t *testing.T) {
+	cases := map[string]string{
+		"SELECT * FROM t WHERE a IN ($1,$2) AND b = 'it''s'":       "SELECT * FROM t WHERE a IN (?,?,?) AND b = 'x'",
+		"SELECT  *\n FROM t1\tWHERE a = 1.5 LIMIT 10":              "SELECT * FROM t1 WHERE a = 2 LIMIT 20",
+		"INSERT INTO t (a,b) VALUES (?,?),(?, ?)":                  "INSERT INTO t (a,b) VALUES ($1,$2)",
+		"SELECT a::int FROM t WHERE b IN (?,?,?) OR c IN (:1, :2)": "SELECT a::int FROM t WHERE b IN (?) OR c IN (:1)",
+	}
+	for a, b := range cases {
+		assert.Equal(t, FingerprintSQL(a), FingerprintSQL(b), a)
+		assert.Len(t, FingerprintSQL(a), 16)
+	}
+	assert.NotEqual(t, FingerprintSQL("SELECT a FROM t"), FingerprintSQL("SELECT b FROM t"))
+
+	s := Select("*").From("t").Where(Eq{"id": 1})
+	sql, _, _ := s.ToSql()
+	fp, err := Fingerprint(s)
+	assert.NoError(t, err)
+	assert.Equal(t, FingerprintSQL(sql), fp)
+}
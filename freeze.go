@@ -0,0 +1,105 @@
+package sqrl
+
+// frozen holds the SQL built for a builder snapshot when it was frozen.
+type frozen struct {
+	sql  string
+	args []interface{}
+	err  error
+}
+
+func freeze(s Sqlizer) frozen {
+	sql, args, err := s.ToSql()
+	return frozen{sql: sql, args: args, err: err}
+}
+
+// ToSql returns the SQL and a copy of the args built when the builder was
+// frozen.
+func (f frozen) ToSql() (string, []interface{}, error) {
+	if f.err != nil {
+		return "", nil, f.err
+	}
+	var args []interface{}
+	if f.args != nil {
+		args = make([]interface{}, len(f.args))
+		copy(args, f.args)
+	}
+	return f.sql, args, nil
+}
+
+// FrozenSelectBuilder is a read-only snapshot of a SelectBuilder, returned by
+// Freeze. It is safe for concurrent use, and Thaw returns a copy of the
+// builder to specialize.
+type FrozenSelectBuilder struct {
+	frozen
+	b *SelectBuilder
+}
+
+// Freeze returns a read-only snapshot of the query, built once, which
+// goroutines can share and specialize without calling Copy themselves.
+// Ex:
+//     base := Select("*").From("users").Where("deleted_at IS NULL").Freeze()
+//
+//     active := base.Thaw().Where(Eq{"active": true})
+func (b *SelectBuilder) Freeze() FrozenSelectBuilder {
+	nb := b.Copy()
+	return FrozenSelectBuilder{frozen: freeze(nb), b: nb}
+}
+
+// Thaw returns a new SelectBuilder copied from the snapshot.
+func (f FrozenSelectBuilder) Thaw() *SelectBuilder {
+	return f.b.Copy()
+}
+
+// FrozenInsertBuilder is a read-only snapshot of an InsertBuilder, returned by
+// Freeze.
+type FrozenInsertBuilder struct {
+	frozen
+	b *InsertBuilder
+}
+
+// Freeze returns a read-only snapshot of the query, built once.
+func (b *InsertBuilder) Freeze() FrozenInsertBuilder {
+	nb := b.Copy()
+	return FrozenInsertBuilder{frozen: freeze(nb), b: nb}
+}
+
+// Thaw returns a new InsertBuilder copied from the snapshot.
+func (f FrozenInsertBuilder) Thaw() *InsertBuilder {
+	return f.b.Copy()
+}
+
+// FrozenUpdateBuilder is a read-only snapshot of an UpdateBuilder, returned by
+// Freeze.
+type FrozenUpdateBuilder struct {
+	frozen
+	b *UpdateBuilder
+}
+
+// Freeze returns a read-only snapshot of the query, built once.
+func (b *UpdateBuilder) Freeze() FrozenUpdateBuilder {
+	nb := b.Copy()
+	return FrozenUpdateBuilder{frozen: freeze(nb), b: nb}
+}
+
+// Thaw returns a new UpdateBuilder copied from the snapshot.
+func (f FrozenUpdateBuilder) Thaw() *UpdateBuilder {
+	return f.b.Copy()
+}
+
+// FrozenDeleteBuilder is a read-only snapshot of a DeleteBuilder, returned by
+// Freeze.
+type FrozenDeleteBuilder struct {
+	frozen
+	b *DeleteBuilder
+}
+
+// Freeze returns a read-only snapshot of the query, built once.
+func (b *DeleteBuilder) Freeze() FrozenDeleteBuilder {
+	nb := b.Copy()
+	return FrozenDeleteBuilder{frozen: freeze(nb), b: nb}
+}
+
+// Thaw returns a new DeleteBuilder copied from the snapshot.
+func (f FrozenDeleteBuilder) Thaw() *DeleteBuilder {
+	return f.b.Copy()
+}
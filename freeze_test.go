@@ -0,0 +1,76 @@
+package sqrl
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectBuilderFreeze(t *testing.T) {
+	b := Select("*").From("users").Where(Eq{"deleted": false})
+	base := b.Freeze()
+
+	// Changes to the builder after Freeze don't change the snapshot
+	b.Where("x")
+
+	sql, args, err := base.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE deleted = ?", sql)
+	assert.Equal(t, []interface{}{false}, args)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sql, args, err := base.Thaw().Where(Eq{"id": i}).ToSql()
+			assert.NoError(t, err)
+			assert.Equal(t, "SELECT * FROM users WHERE deleted = ? AND id = ?", sql)
+			assert.Equal(t, []interface{}{false, i}, args)
+		}(i)
+	}
+	wg.Wait()
+
+	sql, _, _ = base.ToSql()
+	assert.Equal(t, "SELECT * FROM users WHERE deleted = ?", sql)
+
+	_, _, err = Select().Freeze().ToSql()
+	assert.Error(t, err)
+}
+
+func TestSelectBuilderThawColumns(t *testing.T) {
+	base := Select("a", "b").Columns("c").From("t").Freeze()
+
+	x := base.Thaw().Columns("x")
+	y := base.Thaw().Columns("y")
+
+	sql, _, err := x.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a, b, c, x FROM t", sql)
+
+	sql, _, err = y.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a, b, c, y FROM t", sql)
+}
+
+func TestFreeze(t *testing.T) {
+	ins := Insert("users").Columns("name").Freeze()
+	sql, _, err := ins.Thaw().Values("Joe").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name) VALUES (?)", sql)
+
+	upd := Update("users").Set("active", false).Freeze()
+	sql, args, err := upd.Thaw().Where(Eq{"id": 1}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET active = ? WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{false, 1}, args)
+
+	del := Delete("users").Freeze()
+	sql, _, err = del.Thaw().Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM users WHERE id = ?", sql)
+	sql, _, err = del.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM users", sql)
+}
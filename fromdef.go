@@ -0,0 +1,276 @@
+package sqrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Schema whitelists the tables, and their columns, a query built from a
+// QueryDef may reference.
+type Schema map[string][]string
+
+// SelectFromDef builds a SelectBuilder from d, e.g. a QueryDef unmarshalled
+// from a request of a reporting API.
+//
+// Only tables and columns whitelisted by schema may be referenced, and
+// only a structured subset of QueryDef is accepted: a single table,
+// columns optionally aliased, DISTINCT ON, Eq, NotEq, Lt, LtOrEq, Gt,
+// GtOrEq, And, Or and Not predicates, GROUP BY, ORDER BY, LIMIT and
+// OFFSET. Raw SQL, joins and subqueries are rejected. Without columns all whitelisted
+// columns of the table are selected.
+// Ex:
+//     schema := Schema{"users": {"id", "name", "age"}}
+//     var d QueryDef
+//     err := json.Unmarshal(body, &d)
+//     b, err := SelectFromDef(&d, schema)
+func SelectFromDef(d *QueryDef, schema Schema) (*SelectBuilder, error) {
+	return StatementBuilder.SelectFromDef(d, schema)
+}
+
+// SelectFromDef builds a SelectBuilder for this StatementBuilder from d.
+//
+// See SelectFromDef.
+func (b StatementBuilderType) SelectFromDef(d *QueryDef, schema Schema) (*SelectBuilder, error) {
+	if d.Type != SelectQuery {
+		return nil, fmt.Errorf("cannot build a select query from a %q query", d.Type)
+	}
+	if len(d.Prefixes) > 0 || len(d.Options) > 0 || d.Top != nil || len(d.Joins) > 0 || len(d.Suffixes) > 0 ||
+		d.Table != "" || len(d.What) > 0 || len(d.Using) > 0 || len(d.InsertColumns) > 0 || len(d.Output) > 0 ||
+		len(d.Values) > 0 || d.Select != nil || len(d.Set) > 0 || len(d.Returning) > 0 {
+		return nil, fmt.Errorf("query definition has unsupported clauses")
+	}
+
+	if d.Placeholder != "" {
+		f, err := importPlaceholder(d.Placeholder)
+		if err != nil {
+			return nil, err
+		}
+		b = b.PlaceholderFormat(f)
+	}
+
+	if len(d.From) != 1 {
+		return nil, fmt.Errorf("query definition must select from exactly 1 table, not %d", len(d.From))
+	}
+	table, err := d.From[0].column()
+	if err != nil {
+		return nil, err
+	}
+	columns, ok := schema[table]
+	if !ok {
+		return nil, fmt.Errorf("table %q is not allowed", table)
+	}
+	s := &defSchema{table: table, columns: columns}
+
+	sb := b.Select().From(table)
+	if d.Distinct {
+		sb = sb.Distinct()
+	}
+	for _, c := range d.DistinctOn {
+		if err := s.checkColumn(c); err != nil {
+			return nil, err
+		}
+	}
+	sb = sb.DistinctOn(d.DistinctOn...)
+
+	if len(d.Columns) == 0 {
+		sb = sb.Columns(columns...)
+	}
+	for _, c := range d.Columns {
+		column, err := s.importColumn(c)
+		if err != nil {
+			return nil, err
+		}
+		sb = sb.Column(column)
+	}
+
+	for _, w := range d.Where {
+		pred, err := s.importPred(w)
+		if err != nil {
+			return nil, err
+		}
+		sb = sb.Where(pred)
+	}
+
+	for _, g := range d.GroupBy {
+		if err := s.checkColumn(g); err != nil {
+			return nil, err
+		}
+	}
+	sb = sb.GroupBy(d.GroupBy...)
+
+	for _, h := range d.Having {
+		pred, err := s.importPred(h)
+		if err != nil {
+			return nil, err
+		}
+		sb = sb.Having(pred)
+	}
+
+	for _, o := range d.OrderBy {
+		if err := s.checkOrderBy(o); err != nil {
+			return nil, err
+		}
+	}
+	sb = sb.OrderBy(d.OrderBy...)
+
+	if d.Limit != nil {
+		sb = sb.Limit(*d.Limit)
+	}
+	if d.Offset != nil {
+		sb = sb.Offset(*d.Offset)
+	}
+	return sb, nil
+}
+
+func importPlaceholder(name string) (PlaceholderFormat, error) {
+	switch name {
+	case "":
+		return Question, nil
+	case "dollar":
+		return Dollar, nil
+	case "colon":
+		return Colon, nil
+	case "at_named":
+		return AtNamed, nil
+	case "colon_named":
+		return ColonNamed, nil
+	}
+	return nil, fmt.Errorf("unknown placeholder format %q", name)
+}
+
+// column returns the identifier e consists of.
+func (e ExprDef) column() (string, error) {
+	if e.Op != SQLOp || len(e.Args) > 0 || !isIdentifier(strings.Replace(e.SQL, ".", "_", 1)) {
+		return "", fmt.Errorf("expected a column or table name, not %s %q", e.Op, e.SQL)
+	}
+	return e.SQL, nil
+}
+
+// defSchema is the whitelist of the table of a query definition.
+type defSchema struct {
+	table   string
+	columns []string
+}
+
+// checkColumn checks that column is a whitelisted column of the table,
+// optionally qualified by the table name.
+func (s *defSchema) checkColumn(column string) error {
+	name := column
+	if i := strings.IndexByte(column, '.'); i >= 0 && column[:i] == s.table {
+		name = column[i+1:]
+	}
+	for _, c := range s.columns {
+		if c == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("column %q is not allowed", column)
+}
+
+func (s *defSchema) checkOrderBy(orderBy string) error {
+	fields := strings.Fields(orderBy)
+	if len(fields) == 2 {
+		switch strings.ToUpper(fields[1]) {
+		case "ASC", "DESC":
+			return s.checkColumn(fields[0])
+		}
+	}
+	if len(fields) != 1 {
+		return fmt.Errorf("invalid ORDER BY %q", orderBy)
+	}
+	return s.checkColumn(fields[0])
+}
+
+func (s *defSchema) importColumn(e ExprDef) (Sqlizer, error) {
+	if e.Op == AliasOp {
+		if len(e.Exprs) != 1 || !isIdentifier(e.Alias) {
+			return nil, fmt.Errorf("invalid alias %q", e.Alias)
+		}
+		column, err := s.importColumn(e.Exprs[0])
+		if err != nil {
+			return nil, err
+		}
+		return Alias(column, e.Alias), nil
+	}
+
+	column, err := e.column()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkColumn(column); err != nil {
+		return nil, err
+	}
+	return Expr(column), nil
+}
+
+func (s *defSchema) importPred(e ExprDef) (Sqlizer, error) {
+	switch e.Op {
+	case EqOp, NotEqOp, LtOp, LtOrEqOp, GtOp, GtOrEqOp:
+		if len(e.Columns) == 0 {
+			return nil, fmt.Errorf("%s predicate without columns", e.Op)
+		}
+		for column, v := range e.Columns {
+			if err := s.checkColumn(column); err != nil {
+				return nil, err
+			}
+			if err := checkValue(column, v); err != nil {
+				return nil, err
+			}
+		}
+		switch e.Op {
+		case EqOp:
+			return Eq(e.Columns), nil
+		case NotEqOp:
+			return NotEq(e.Columns), nil
+		case LtOp:
+			return Lt(e.Columns), nil
+		case LtOrEqOp:
+			return LtOrEq(e.Columns), nil
+		case GtOp:
+			return Gt(e.Columns), nil
+		default:
+			return GtOrEq(e.Columns), nil
+		}
+
+	case AndOp, OrOp, NotOp:
+		preds := make([]Sqlizer, len(e.Exprs))
+		for i, x := range e.Exprs {
+			pred, err := s.importPred(x)
+			if err != nil {
+				return nil, err
+			}
+			preds[i] = pred
+		}
+		switch {
+		case e.Op == AndOp:
+			return And(preds), nil
+		case e.Op == OrOp:
+			return Or(preds), nil
+		case len(preds) != 1:
+			return nil, fmt.Errorf("not predicate must have 1 expression, not %d", len(preds))
+		default:
+			return Not(preds[0]), nil
+		}
+	}
+	return nil, fmt.Errorf("%s expressions are not allowed in predicates", e.Op)
+}
+
+// checkValue checks that v compared to column is a scalar or a list of
+// scalars.
+func checkValue(column string, v interface{}) error {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, x := range v {
+			if err := checkValue(column, x); err != nil {
+				return err
+			}
+			if _, ok := x.([]interface{}); ok {
+				return fmt.Errorf("invalid value of %q: nested list", column)
+			}
+		}
+		return nil
+	case map[string]interface{}, Sqlizer:
+		return fmt.Errorf("invalid value of %q: %T", column, v)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package sqrl
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSchema = Schema{"users": {"id", "name", "age"}}
+
+func TestSelectFromDef(t *testing.T) {
+	data := `{
+		"type": "select",
+		"placeholder": "dollar",
+		"columns": [{"op": "sql", "sql": "id"}, {"op": "alias", "exprs": [{"op": "sql", "sql": "users.name"}], "alias": "n"}],
+		"from": [{"op": "sql", "sql": "users"}],
+		"where": [
+			{"op": "gt", "columns": {"age": 18}},
+			{"op": "or", "exprs": [{"op": "eq", "columns": {"id": [1, 2]}}, {"op": "not", "exprs": [{"op": "eq", "columns": {"name": null}}]}]}
+		],
+		"order_by": ["age DESC"],
+		"limit": 10,
+		"offset": 20
+	}`
+	var d QueryDef
+	assert.NoError(t, json.Unmarshal([]byte(data), &d))
+
+	b, err := SelectFromDef(&d, testSchema)
+	assert.NoError(t, err)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"SELECT id, (users.name) AS n FROM users WHERE age > $1 AND (id IN ($2,$3) OR NOT (name IS NULL)) "+
+			"ORDER BY age DESC LIMIT 10 OFFSET 20",
+		sql)
+	assert.Equal(t, []interface{}{float64(18), float64(1), float64(2)}, args)
+}
+
+func TestSelectFromDefAllColumns(t *testing.T) {
+	b, err := SelectFromDef(&QueryDef{Type: SelectQuery, From: []ExprDef{{Op: SQLOp, SQL: "users"}}}, testSchema)
+	assert.NoError(t, err)
+
+	sql, _, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, name, age FROM users", sql)
+}
+
+func TestSelectFromDefRoundTrip(t *testing.T) {
+	d, err := Select("id", "name").From("users").Where(Lt{"age": 30}).GroupBy("name").OrderBy("id").Export()
+	assert.NoError(t, err)
+
+	b, err := SelectFromDef(d, testSchema)
+	assert.NoError(t, err)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, name FROM users WHERE age < ? GROUP BY name ORDER BY id", sql)
+	assert.Equal(t, []interface{}{30}, args)
+
+	d, err = Select("id", "name").DistinctOn("name").From("users").OrderBy("name", "id DESC").Export()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name"}, d.DistinctOn)
+
+	b, err = StatementBuilder.Dialect(Postgres).SelectFromDef(d, testSchema)
+	assert.NoError(t, err)
+
+	sql, _, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT DISTINCT ON (name) id, name FROM users ORDER BY name, id DESC", sql)
+
+	for _, f := range []PlaceholderFormat{Question, Dollar, Colon, AtNamed, ColonNamed} {
+		name, err := exportPlaceholder(f)
+		assert.NoError(t, err)
+		imported, err := importPlaceholder(name)
+		assert.NoError(t, err)
+		assert.Equal(t, f, imported)
+	}
+}
+
+func TestSelectFromDefNotAllowed(t *testing.T) {
+	from := []ExprDef{{Op: SQLOp, SQL: "users"}}
+	defs := []*QueryDef{
+		{Type: DeleteQuery, From: from},
+		{Type: SelectQuery, From: []ExprDef{{Op: SQLOp, SQL: "secrets"}}},
+		{Type: SelectQuery, From: []ExprDef{{Op: SQLOp, SQL: "users; DROP TABLE users"}}},
+		{Type: SelectQuery, From: from, Columns: []ExprDef{{Op: SQLOp, SQL: "password"}}},
+		{Type: SelectQuery, From: from, Columns: []ExprDef{{Op: SQLOp, SQL: "*"}}},
+		{Type: SelectQuery, From: from, Joins: []ExprDef{{Op: SQLOp, SQL: "JOIN secrets"}}},
+		{Type: SelectQuery, From: from, Where: []ExprDef{{Op: SQLOp, SQL: "1 = 1"}}},
+		{Type: SelectQuery, From: from, Where: []ExprDef{{Op: EqOp, Columns: map[string]interface{}{"password": "x"}}}},
+		{Type: SelectQuery, From: from, Where: []ExprDef{{Op: EqOp, Columns: map[string]interface{}{"id": Expr("1 OR 1 = 1")}}}},
+		{Type: SelectQuery, From: from, OrderBy: []string{"id; DROP TABLE users"}},
+		{Type: SelectQuery, From: from, GroupBy: []string{"password"}},
+		{Type: SelectQuery, From: from, DistinctOn: []string{"password"}},
+		{Type: SelectQuery, From: from, Placeholder: "unknown"},
+	}
+	for _, d := range defs {
+		_, err := SelectFromDef(d, testSchema)
+		assert.Error(t, err, "%+v", d)
+	}
+}
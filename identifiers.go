@@ -0,0 +1,254 @@
+package sqrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StrictIdentifiers makes child builders verify on ToSql that the table and
+// column names passed to them as strings, e.g. to Columns, From, GroupBy,
+// OrderBy, Into or Set, are identifiers, since they are put into the query
+// as they are.
+//
+// An identifier is a dot separated list of names, which are letters, digits
+// and underscores or quoted with " or `. Table and column names may have an
+// alias, ORDER BY names a direction and NULLS FIRST or LAST. If allowed
+// names are given, every name of an identifier must be one of them.
+//
+// Strings passed to Column with args are considered expressions, like those
+// passed to Where. Pass other expressions as Expr.
+// Ex:
+//     StatementBuilder.StrictIdentifiers("users", "id", "name").
+//         Select("id", "name").From("users").OrderBy(userInput)
+func (b StatementBuilderType) StrictIdentifiers(allowed ...string) StatementBuilderType {
+	b.strictIdentifiers = true
+	b.allowedIdentifiers = identifierSet(allowed)
+	return b
+}
+
+func identifierSet(allowed []string) map[string]bool {
+	if len(allowed) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		set[name] = true
+	}
+	return set
+}
+
+// Kinds of identifiers, by what may follow the name.
+const (
+	identName = iota
+	identAliased
+	identOrderBy
+)
+
+// checkIdentifier returns an error if s isn't an identifier of kind, or has
+// names which aren't allowed.
+func (b StatementBuilderType) checkIdentifier(s string, kind int) error {
+	names, rest, ok := parseIdentifier(s)
+	if ok {
+		ok = checkIdentifierRest(rest, kind)
+	}
+	if !ok {
+		return fmt.Errorf("invalid identifier %q", s)
+	}
+
+	if b.allowedIdentifiers == nil {
+		return nil
+	}
+	for _, name := range names {
+		if name != "*" && !b.allowedIdentifiers[name] {
+			return fmt.Errorf("identifier %q is not allowed", name)
+		}
+	}
+	return nil
+}
+
+func (b StatementBuilderType) checkIdentifierList(ss []string, kind int) error {
+	for _, s := range ss {
+		if err := b.checkIdentifier(s, kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkIdentifierParts checks the identifiers of parts, which were passed
+// as strings without args.
+func (b StatementBuilderType) checkIdentifierParts(parts []Sqlizer, kind int) error {
+	for _, p := range parts {
+		if p, ok := p.(*part); ok && len(p.args) == 0 {
+			if s, ok := p.pred.(string); ok {
+				if err := b.checkIdentifier(s, kind); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseIdentifier parses the identifier at the start of s into its unquoted
+// names. A name of * is only allowed last.
+func parseIdentifier(s string) (names []string, rest string, ok bool) {
+	for {
+		var name string
+		switch {
+		case s == "":
+			return nil, "", false
+		case s[0] == '"' || s[0] == '`':
+			end := strings.IndexByte(s[1:], s[0])
+			if end <= 0 {
+				return nil, "", false
+			}
+			name, s = s[1:end+1], s[end+2:]
+		case s[0] == '*':
+			name, s = "*", s[1:]
+		default:
+			i := 0
+			for i < len(s) && isNameChar(s[i], i == 0) {
+				i++
+			}
+			if i == 0 {
+				return nil, "", false
+			}
+			name, s = s[:i], s[i:]
+		}
+		names = append(names, name)
+
+		if s == "" || s[0] != '.' {
+			return names, s, true
+		}
+		if name == "*" {
+			return nil, "", false
+		}
+		s = s[1:]
+	}
+}
+
+// checkIdentifierRest checks what follows an identifier of kind.
+func checkIdentifierRest(rest string, kind int) bool {
+	if rest == "" {
+		return true
+	}
+	if rest[0] != ' ' {
+		return false
+	}
+	words := strings.Fields(rest)
+
+	switch kind {
+	case identAliased:
+		if len(words) == 2 && strings.EqualFold(words[0], "AS") {
+			words = words[1:]
+		}
+		if len(words) != 1 {
+			return false
+		}
+		names, rest, ok := parseIdentifier(words[0])
+		return ok && rest == "" && len(names) == 1 && names[0] != "*"
+
+	case identOrderBy:
+		if len(words) > 0 && (strings.EqualFold(words[0], "ASC") || strings.EqualFold(words[0], "DESC")) {
+			words = words[1:]
+		}
+		if len(words) == 2 && strings.EqualFold(words[0], "NULLS") &&
+			(strings.EqualFold(words[1], "FIRST") || strings.EqualFold(words[1], "LAST")) {
+			words = words[2:]
+		}
+		return len(words) == 0
+	}
+	return false
+}
+
+func (b *SelectBuilder) checkIdentifiers() error {
+	if err := b.checkIdentifierParts(b.columns, identAliased); err != nil {
+		return err
+	}
+	if err := b.checkIdentifierParts(b.fromParts, identAliased); err != nil {
+		return err
+	}
+	if err := b.checkIdentifierList(b.groupBys, identName); err != nil {
+		return err
+	}
+	return b.checkIdentifierList(b.orderBys, identOrderBy)
+}
+
+func (b *InsertBuilder) checkIdentifiers() error {
+	if err := b.checkIdentifier(b.into, identName); err != nil {
+		return err
+	}
+	return b.checkIdentifierList(b.columns, identName)
+}
+
+func (b *UpdateBuilder) checkIdentifiers() error {
+	if err := b.checkIdentifier(b.table, identAliased); err != nil {
+		return err
+	}
+	for _, c := range b.setClauses {
+		if err := b.checkIdentifier(c.column, identName); err != nil {
+			return err
+		}
+	}
+	if err := b.checkIdentifierParts(b.fromParts, identAliased); err != nil {
+		return err
+	}
+	return b.checkIdentifierList(b.orderBys, identOrderBy)
+}
+
+func (b *DeleteBuilder) checkIdentifiers() error {
+	if err := b.checkIdentifier(b.from, identAliased); err != nil {
+		return err
+	}
+	if err := b.checkIdentifierList(b.what, identName); err != nil {
+		return err
+	}
+	return b.checkIdentifierList(b.orderBys, identOrderBy)
+}
+
+func (b *CreateTableBuilder) checkIdentifiers() error {
+	if err := b.checkIdentifier(b.table, identName); err != nil {
+		return err
+	}
+	for _, c := range b.columns {
+		if err := b.checkIdentifier(c.name, identName); err != nil {
+			return err
+		}
+	}
+	for _, c := range b.constraints {
+		if err := b.checkIdentifierList(c.columns, identName); err != nil {
+			return err
+		}
+		if c.refTable == "" {
+			continue
+		}
+		if err := b.checkIdentifier(c.refTable, identName); err != nil {
+			return err
+		}
+		if err := b.checkIdentifierList(c.refColumns, identName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *CreateIndexBuilder) checkIdentifiers() error {
+	if len(b.name) > 0 {
+		if err := b.checkIdentifier(b.name, identName); err != nil {
+			return err
+		}
+	}
+	if err := b.checkIdentifier(b.table, identName); err != nil {
+		return err
+	}
+	for _, c := range b.columns {
+		if c.expr {
+			continue
+		}
+		if err := b.checkIdentifier(c.sql, identOrderBy); err != nil {
+			return err
+		}
+	}
+	return b.checkIdentifierList(b.include, identName)
+}
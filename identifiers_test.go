@@ -0,0 +1,55 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictIdentifiers(t *testing.T) {
+	valid := []Sqlizer{
+		Select("id", "u.name AS n", `"weird name"`, "t.*").From("users u").GroupBy("u.id").OrderBy("id DESC NULLS LAST", "name").StrictIdentifiers(),
+		Select().Column(Expr("COUNT(*)")).Column("x + ?", 1).Column(Expr("y * 2")).From("users").StrictIdentifiers(),
+		Insert("users").Columns("id", "name").Values(1, "Joe").StrictIdentifiers(),
+		Update("users").Set("name", "Joe").Where(Eq{"id": 1}).OrderBy("id").StrictIdentifiers(),
+		Delete("users").Where(Eq{"id": 1}).StrictIdentifiers(),
+	}
+	for _, b := range valid {
+		_, _, err := b.ToSql()
+		assert.NoError(t, err)
+	}
+
+	invalid := []Sqlizer{
+		Select("id; DROP TABLE users").From("users").StrictIdentifiers(),
+		Select("id").From("users WHERE 1 = 1").StrictIdentifiers(),
+		Select("id").From("users").GroupBy("id--").StrictIdentifiers(),
+		Select("id").From("users").OrderBy("id DESC, (SELECT password FROM users)").StrictIdentifiers(),
+		Select("id").From("users").OrderBy(`"id`).StrictIdentifiers(),
+		Select("*.id").From("users").StrictIdentifiers(),
+		Insert("users (id) SELECT 1 --").Columns("id").Values(1).StrictIdentifiers(),
+		Insert("users").Columns("id) VALUES (1); --").Values(1).StrictIdentifiers(),
+		Update("users").Set("name = 'x', admin", true).StrictIdentifiers(),
+		Delete("users").OrderBy("1 OR 1").StrictIdentifiers(),
+	}
+	for _, b := range invalid {
+		_, _, err := b.ToSql()
+		assert.Error(t, err)
+	}
+}
+
+func TestStrictIdentifiersAllowed(t *testing.T) {
+	sb := StatementBuilder.StrictIdentifiers("users", "id", "name")
+
+	sql, _, err := sb.Select("id", "users.name AS n").From("users").OrderBy("name DESC").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, users.name AS n FROM users ORDER BY name DESC", sql)
+
+	_, _, err = sb.Select("password").From("users").ToSql()
+	assert.EqualError(t, err, `identifier "password" is not allowed`)
+
+	_, _, err = sb.Select("id").From("secrets").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Update("users").Set("admin", true).ToSql()
+	assert.Error(t, err)
+}
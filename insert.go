@@ -1,13 +1,13 @@
 package sqrl
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 )
 
 // InsertBuilder builds SQL INSERT statements.
@@ -24,15 +24,32 @@ type InsertBuilder struct {
 	suffixes      exprs
 	iselect       *SelectBuilder
 	outputColumns []string
+	onConflict    Sqlizer
+	orAction      ConflictAction
+
+	memo *memo
 }
 
+// ConflictAction is the action SQLite takes on constraint violations of an
+// INSERT, set by InsertBuilder.Or.
+type ConflictAction string
+
+// Conflict actions of SQLite.
+const (
+	OrAbort    ConflictAction = "ABORT"
+	OrFail     ConflictAction = "FAIL"
+	OrIgnore   ConflictAction = "IGNORE"
+	OrReplace  ConflictAction = "REPLACE"
+	OrRollback ConflictAction = "ROLLBACK"
+)
+
 // NewInsertBuilder creates new instance of InsertBuilder
 func NewInsertBuilder(b StatementBuilderType) *InsertBuilder {
-	return &InsertBuilder{StatementBuilderType: b}
+	return &InsertBuilder{StatementBuilderType: b, memo: newMemo(b.memoize)}
 }
 
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
-func (b *InsertBuilder) RunWith(runner BaseRunner) *InsertBuilder {
+func (b *InsertBuilder) RunWith(runner BaseRunnerContext) *InsertBuilder {
 	b.runWith = wrapRunner(runner)
 	return b
 }
@@ -47,9 +64,24 @@ func (b *InsertBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 	if b.runWith == nil {
 		return nil, ErrRunnerNotSet
 	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
 	return ExecWithContext(ctx, b.runWith, b)
 }
 
+// ExecAffected builds and Execs the query with the Runner set by RunWith
+// using given context and returns the number of rows affected.
+//
+// See ExecAffectedWithContext.
+func (b *InsertBuilder) ExecAffected(ctx context.Context) (int64, error) {
+	if b.runWith == nil {
+		return 0, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecAffectedWithContext(ctx, b.runWith, b)
+}
+
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b *InsertBuilder) Query() (*sql.Rows, error) {
 	return b.QueryContext(context.Background())
@@ -60,7 +92,7 @@ func (b *InsertBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
 	if b.runWith == nil {
 		return nil, ErrRunnerNotSet
 	}
-	return QueryWithContext(ctx, b.runWith, b)
+	return QueryWithContext(b.queryTimeoutContext(ctx), b.runWith, b)
 }
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
@@ -77,7 +109,8 @@ func (b *InsertBuilder) QueryRowContext(ctx context.Context) RowScanner {
 	if !ok {
 		return &Row{err: ErrRunnerNotQueryRunnerContext}
 	}
-	return QueryRowWithContext(ctx, queryRower, b)
+	ctx, cancel := b.timeoutContext(ctx)
+	return &timeoutRow{RowScanner: QueryRowWithContext(ctx, queryRower, b), cancel: cancel}
 }
 
 // Scan is a shortcut for QueryRow().Scan.
@@ -85,6 +118,57 @@ func (b *InsertBuilder) Scan(dest ...interface{}) error {
 	return b.QueryRow().Scan(dest...)
 }
 
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext. For Query the timeout covers reading the rows.
+func (b *InsertBuilder) Timeout(d time.Duration) *InsertBuilder {
+	b.timeout = d
+	return b
+}
+
+// StrictPlaceholders makes ToSql verify that the raw SQL fragments of the
+// query have as many ? placeholders as args.
+func (b *InsertBuilder) StrictPlaceholders() *InsertBuilder {
+	b.strictPlaceholders = true
+	return b
+}
+
+// StrictIdentifiers makes ToSql verify that the table and column names of
+// the query are identifiers, and one of allowed if given.
+//
+// See StatementBuilderType.StrictIdentifiers.
+func (b *InsertBuilder) StrictIdentifiers(allowed ...string) *InsertBuilder {
+	b.strictIdentifiers = true
+	b.allowedIdentifiers = identifierSet(allowed)
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on.
+//
+// See StatementBuilderType.Dialect.
+func (b *InsertBuilder) Dialect(d Dialect) *InsertBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its table and column names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *InsertBuilder) QuoteIdentifiers() *InsertBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// Memoize makes ToSql cache the query until it is changed.
+//
+// See StatementBuilderType.Memoize.
+func (b *InsertBuilder) Memoize() *InsertBuilder {
+	b.memoize = true
+	if b.memo == nil {
+		b.memo = newMemo(true)
+	}
+	return b
+}
+
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b *InsertBuilder) PlaceholderFormat(f PlaceholderFormat) *InsertBuilder {
@@ -92,8 +176,44 @@ func (b *InsertBuilder) PlaceholderFormat(f PlaceholderFormat) *InsertBuilder {
 	return b
 }
 
+// ToSqlNamed builds the query into a SQL string with named placeholders.
+//
+// See ToSqlNamed.
+func (b *InsertBuilder) ToSqlNamed(f NamedFormat) (string, []sql.NamedArg, error) {
+	nb := *b
+	nb.placeholderFormat = Question
+	return ToSqlNamed(&nb, f)
+}
+
 // ToSql builds the query into a SQL string and bound args.
-func (b *InsertBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+func (b *InsertBuilder) ToSql() (string, []interface{}, error) {
+	if b.memoize {
+		return memoized(b.memo, b, b.toSql)
+	}
+	return b.toSql()
+}
+
+func (b *InsertBuilder) toSql() (string, []interface{}, error) {
+	sql := getQueryBuffer(b.placeholderFormat)
+	defer putQueryBuffer(sql)
+
+	args := makeArgs(countExprArgs(b.prefixes) + b.countValueArgs() + countExprArgs(b.suffixes))
+	args, err := appendQuery(sql, args, b.dialect, b.appendToSql)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql.finish(args)
+}
+
+// AppendToSql writes the query to w and appends its args to args, to build
+// it into a larger statement without an intermediate string. Placeholders
+// are written as ?, to be replaced by the enclosing statement.
+func (b *InsertBuilder) AppendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
+	return appendQuery(w, args, b.dialect, b.appendToSql)
+}
+
+func (b *InsertBuilder) appendToSql(sql *queryBuffer, in []interface{}) (args []interface{}, err error) {
+	args = in
 	if len(b.into) == 0 {
 		err = fmt.Errorf("insert statements must specify a table")
 		return
@@ -102,28 +222,49 @@ func (b *InsertBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 		err = fmt.Errorf("insert statements must have at least one set of values or select clause")
 		return
 	}
-
-	sql := &bytes.Buffer{}
+	if b.strictPlaceholders {
+		if err = checkPlaceholders(b.placeholderParts()...); err != nil {
+			return
+		}
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifiers(); err != nil {
+			return
+		}
+	}
 
 	if len(b.prefixes) > 0 {
-		args, _ = b.prefixes.AppendToSql(sql, " ", args)
+		args, err = b.prefixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
 	sql.WriteString("INSERT ")
 
+	if b.orAction != "" {
+		if !supports(b.dialect, FeatureInsertOr) {
+			err = fmt.Errorf("%s does not support INSERT OR %s", b.dialect.Name(), b.orAction)
+			return
+		}
+		sql.WriteString("OR ")
+		sql.WriteString(string(b.orAction))
+		sql.WriteString(" ")
+	}
+
 	if len(b.options) > 0 {
 		sql.WriteString(strings.Join(b.options, " "))
 		sql.WriteString(" ")
 	}
 
 	sql.WriteString("INTO ")
-	sql.WriteString(b.into)
+	sql.WriteString(b.quoteName(b.into, identName))
 	sql.WriteString(" ")
 
 	if len(b.columns) > 0 {
 		sql.WriteString("(")
-		sql.WriteString(strings.Join(b.columns, ","))
+		sql.WriteString(strings.Join(b.quoteNames(b.columns, identName), ","))
 		sql.WriteString(") ")
 	}
 
@@ -142,7 +283,18 @@ func (b *InsertBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 		return
 	}
 
+	if b.onConflict != nil {
+		args, err = b.appendOnConflictToSQL(sql, args)
+		if err != nil {
+			return
+		}
+	}
+
 	if len(b.returning) > 0 {
+		if !supports(b.dialect, FeatureReturning) {
+			err = fmt.Errorf("%s does not support RETURNING", b.dialect.Name())
+			return
+		}
 		args, err = b.returning.AppendToSql(sql, args)
 		if err != nil {
 			return
@@ -151,10 +303,12 @@ func (b *InsertBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = b.suffixes.AppendToSql(sql, " ", args)
+		args, err = b.suffixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
 	}
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
 	return
 }
 
@@ -165,54 +319,94 @@ func (b *InsertBuilder) appendValuesToSQL(w io.Writer, args []interface{}) ([]in
 
 	io.WriteString(w, "VALUES ")
 
-	valuesStrings := make([]string, len(b.values))
 	for r, row := range b.values {
-		valueStrings := make([]string, len(row))
+		if r > 0 {
+			io.WriteString(w, ",")
+		}
+		io.WriteString(w, "(")
 		for v, val := range row {
+			if v > 0 {
+				io.WriteString(w, ",")
+			}
 
 			switch typedVal := val.(type) {
 			case expr:
-				valueStrings[v] = typedVal.sql
+				io.WriteString(w, typedVal.sql)
 				args = append(args, typedVal.args...)
 			case Sqlizer:
-				var valSql string
-				var valArgs []interface{}
-				var err error
-
-				valSql, valArgs, err = typedVal.ToSql()
+				valSql, valArgs, err := withDialect(typedVal, b.dialect).ToSql()
 				if err != nil {
 					return nil, err
 				}
 
-				valueStrings[v] = valSql
+				io.WriteString(w, valSql)
 				args = append(args, valArgs...)
 			default:
-				valueStrings[v] = "?"
+				io.WriteString(w, "?")
 				args = append(args, val)
 			}
 		}
-		valuesStrings[r] = fmt.Sprintf("(%s)", strings.Join(valueStrings, ","))
+		io.WriteString(w, ")")
 	}
 
-	io.WriteString(w, strings.Join(valuesStrings, ","))
-
 	return args, nil
 }
 
+// countValueArgs returns the number of args of the values, or 1 for a
+// select.
+func (b *InsertBuilder) countValueArgs() int {
+	if b.iselect != nil {
+		return 1
+	}
+	n := 0
+	for _, row := range b.values {
+		for _, val := range row {
+			if e, ok := val.(expr); ok {
+				n += len(e.args)
+			} else {
+				n++
+			}
+		}
+	}
+	return n
+}
+
 func (b *InsertBuilder) appendSelectToSQL(w io.Writer, args []interface{}) ([]interface{}, error) {
 	if b.iselect == nil {
 		return args, errors.New("select clause for insert statements are not set")
 	}
 
-	selectClause, sArgs, err := b.iselect.ToSql()
+	iselect := b.iselect
+	if b.onConflict != nil && dialectName(b.dialect) == SQLiteName && len(iselect.whereParts) == 0 {
+		// SQLite parses the ON of ON CONFLICT as join constraint without
+		// a WHERE clause
+		iselect = iselect.Copy().Where("true")
+	}
+
+	return iselect.AppendToSql(w, args)
+}
+
+func (b *InsertBuilder) appendOnConflictToSQL(w io.Writer, args []interface{}) ([]interface{}, error) {
+	if b.dialect != nil {
+		switch b.dialect.UpsertStyle() {
+		case UpsertOnDuplicateKey:
+			return args, fmt.Errorf("%s does not support ON CONFLICT, use ON DUPLICATE KEY UPDATE", b.dialect.Name())
+		case UpsertMerge:
+			return args, fmt.Errorf("%s does not support ON CONFLICT, use MERGE", b.dialect.Name())
+		}
+	}
+
+	clause, cArgs, err := b.onConflict.ToSql()
 	if err != nil {
 		return args, err
 	}
+	if !supports(b.dialect, FeatureConflictOnConstraint) && strings.HasPrefix(clause, "ON CONFLICT ON CONSTRAINT") {
+		return args, fmt.Errorf("%s does not support ON CONFLICT ON CONSTRAINT", b.dialect.Name())
+	}
 
-	io.WriteString(w, selectClause)
-	args = append(args, sArgs...)
-
-	return args, nil
+	io.WriteString(w, " ")
+	io.WriteString(w, clause)
+	return append(args, cArgs...), nil
 }
 
 // Prefix adds an expression to the beginning of the query
@@ -245,9 +439,35 @@ func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
 	return b
 }
 
+// Or sets the SQLite action taken on constraint violations, rendered as
+// INSERT OR action INTO. It is rejected with dialects other than SQLite.
+// Ex:
+//     Insert("users").Or(OrReplace).Values(1, "Joe") == "INSERT OR REPLACE INTO users VALUES (?,?)"
+func (b *InsertBuilder) Or(action ConflictAction) *InsertBuilder {
+	b.orAction = action
+	return b
+}
+
+// OnConflict sets the clause handling conflicts with unique indexes, e.g.
+// pg.OnConflict, which is rendered before RETURNING.
+//
+// ON CONFLICT is supported by PostgreSQL and SQLite 3.24+. With the SQLite
+// dialect, conflicts on constraints are rejected, since SQLite can't name
+// them, and a WHERE clause is added to INSERT ... SELECT without one.
+// With the MySQL dialect it is rejected, use mysql.OnDuplicateKeyUpdate as
+// Suffix instead.
+// Ex:
+//     Insert("users").Columns("email", "name").Values("joe@example.com", "Joe").
+//         OnConflict(pg.OnConflict("email").SetExcluded("name")).Returning("id")
+//     == "INSERT INTO users (email,name) VALUES (?,?) ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name RETURNING id"
+func (b *InsertBuilder) OnConflict(clause Sqlizer) *InsertBuilder {
+	b.onConflict = clause
+	return b
+}
+
 // Returning adds columns to RETURNING clause of the query
 //
-// INSERT ... RETURNING is PostgreSQL specific extension
+// INSERT ... RETURNING is supported by PostgreSQL and SQLite 3.35+
 func (b *InsertBuilder) Returning(columns ...string) *InsertBuilder {
 	b.returning.Returning(columns...)
 	return b
@@ -255,7 +475,7 @@ func (b *InsertBuilder) Returning(columns ...string) *InsertBuilder {
 
 // ReturningSelect adds subquery to RETURNING clause of the query
 //
-// INSERT ... RETURNING is PostgreSQL specific extension
+// INSERT ... RETURNING is supported by PostgreSQL and SQLite 3.35+
 func (b *InsertBuilder) ReturningSelect(from *SelectBuilder, alias string) *InsertBuilder {
 	b.returning.ReturningSelect(from, alias)
 	return b
@@ -306,6 +526,7 @@ func (b *InsertBuilder) Copy() *InsertBuilder {
 	vb := *b
 	// ... then make a shallow copy
 	nb := vb
+	nb.memo = newMemo(nb.memoize)
 
 	// Then copy all reference types of the struct to make a deep copy
 	nb.returning = make(returning, len(vb.returning))
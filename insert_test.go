@@ -39,6 +39,21 @@ func TestInsertBuilderToSqlErr(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestInsertBuilderSuffixSqlizer(t *testing.T) {
+	b := Insert("test").Values(1).
+		Prefix("WITH x AS (?)", Select("a").From("b").Where(Eq{"c": 2})).
+		Suffix("ON CONFLICT DO UPDATE SET a = ? WHERE b LIKE '%a%'", Expr("a + ?", 3))
+
+	sql, args, err := b.PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "WITH x AS (SELECT a FROM b WHERE c = $1) INSERT INTO test VALUES ($2) "+
+		"ON CONFLICT DO UPDATE SET a = a + $3 WHERE b LIKE '%a%'", sql)
+	assert.Equal(t, []interface{}{2, 1, 3}, args)
+
+	_, _, err = Insert("test").Values(1).Suffix("?", Lt{"a": nil}).ToSql()
+	assert.Error(t, err)
+}
+
 func TestInsertBuilderPlaceholders(t *testing.T) {
 	b := Insert("test").Values(1, 2)
 
@@ -164,3 +179,60 @@ func TestInsertCopy(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "INSERT INTO test (field1,field3) VALUES (?,?)", sql)
 }
+
+func TestInsertBuilderOnConflict(t *testing.T) {
+	onConflict := Expr("ON CONFLICT (email) DO UPDATE SET name = excluded.name, visits = visits + ?", 1)
+
+	sql, args, err := Insert("users").Columns("email", "name").Values("joe@example.com", "Joe").
+		OnConflict(onConflict).Returning("id").Dialect(SQLite).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (email,name) VALUES (?,?) "+
+		"ON CONFLICT (email) DO UPDATE SET name = excluded.name, visits = visits + ? RETURNING id", sql)
+	assert.Equal(t, []interface{}{"joe@example.com", "Joe", 1}, args)
+
+	sql, _, err = Insert("users").Columns("email").Select(Select("email").From("staff")).
+		OnConflict(Expr("ON CONFLICT DO NOTHING")).Dialect(SQLite).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (email) SELECT email FROM staff WHERE true ON CONFLICT DO NOTHING", sql)
+
+	sql, _, err = Insert("users").Columns("email").Select(Select("email").From("staff")).
+		OnConflict(Expr("ON CONFLICT DO NOTHING")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (email) SELECT email FROM staff ON CONFLICT DO NOTHING", sql)
+
+	_, _, err = Insert("users").Values(1).OnConflict(Expr("ON CONFLICT ON CONSTRAINT users_pkey DO NOTHING")).
+		Dialect(SQLite).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = Insert("users").Values(1).OnConflict(Expr("ON CONFLICT DO NOTHING")).Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+	_, _, err = Insert("users").Values(1).OnConflict(Expr("ON CONFLICT DO NOTHING")).Dialect(SQLServer).ToSql()
+	assert.Error(t, err)
+}
+
+func TestInsertBuilderOr(t *testing.T) {
+	sql, args, err := Insert("users").Or(OrReplace).Values(1, "Joe").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT OR REPLACE INTO users VALUES (?,?)", sql)
+	assert.Equal(t, []interface{}{1, "Joe"}, args)
+
+	sql, _, err = Insert("users").Or(OrIgnore).Columns("id").Values(1).Dialect(SQLite).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT OR IGNORE INTO users (id) VALUES (?)", sql)
+
+	_, _, err = Insert("users").Or(OrAbort).Values(1).Dialect(Postgres).ToSql()
+	assert.Error(t, err)
+}
+
+func BenchmarkInsertBuilderToSqlAllocs(b *testing.B) {
+	qb := Insert("users").Columns("id", "name", "email").PlaceholderFormat(Dollar)
+	for i := 0; i < 100; i++ {
+		qb.Values(i, "name", "email")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		qb.ToSql()
+	}
+}
@@ -0,0 +1,153 @@
+package sqrl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// intervalExpr renders an interval, optionally added to or subtracted from
+// a date expression
+type intervalExpr struct {
+	base    string
+	op      string
+	d       time.Duration
+	dialect Dialect
+}
+
+// Interval builds an interval literal of duration d.
+//
+// Without a dialect the standard SQL form is used, which is understood by
+// PostgreSQL and MySQL alike.
+// Ex:
+//     Interval(90 * time.Minute) == "INTERVAL '90' MINUTE"
+func Interval(d time.Duration) intervalExpr {
+	return intervalExpr{d: d}
+}
+
+// Ago builds an expression for the moment d before now.
+// Ex:
+//     .Where(Gt{"created_at": Ago(24 * time.Hour).For(Postgres)}) == "created_at > (NOW() - INTERVAL '1 day')"
+func Ago(d time.Duration) intervalExpr {
+	return intervalExpr{op: "-", d: d}
+}
+
+// FromNow builds an expression for the moment d after now.
+func FromNow(d time.Duration) intervalExpr {
+	return intervalExpr{op: "+", d: d}
+}
+
+// DateAdd builds an expression adding d to the date expression base.
+// Ex:
+//     .Set("expires_at", DateAdd("created_at", time.Hour).For(MySQL)) == "expires_at = DATE_ADD(created_at, INTERVAL 1 HOUR)"
+func DateAdd(base string, d time.Duration) intervalExpr {
+	return intervalExpr{base: base, op: "+", d: d}
+}
+
+// DateSub builds an expression subtracting d from the date expression base.
+func DateSub(base string, d time.Duration) intervalExpr {
+	return intervalExpr{base: base, op: "-", d: d}
+}
+
+// For sets the dialect the expression is rendered for.
+func (e intervalExpr) For(dialect Dialect) intervalExpr {
+	e.dialect = dialect
+	return e
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (e intervalExpr) ToSql() (sql string, args []interface{}, err error) {
+	name := dialectName(e.dialect)
+
+	if e.op != "" && e.d < 0 {
+		// adding a negative duration subtracts it, and vice versa
+		e.d = -e.d
+		if e.op == "+" {
+			e.op = "-"
+		} else {
+			e.op = "+"
+		}
+	}
+
+	if name == SQLiteName {
+		if e.op == "" {
+			err = fmt.Errorf("sqlite does not support interval literals, use Ago, FromNow, DateAdd or DateSub")
+			return
+		}
+
+		base := e.base
+		if base == "" {
+			base = "'now'"
+		}
+		sql = fmt.Sprintf("datetime(%s, '%s%s seconds')", base, e.op, formatFloat(e.d.Seconds()))
+		return
+	}
+
+	count, unit := intervalUnit(e.d, name == MySQLName)
+
+	var literal string
+	switch name {
+	case PostgresName:
+		literal = fmt.Sprintf("INTERVAL '%s %s'", count, strings.ToLower(unit))
+	case MySQLName:
+		literal = fmt.Sprintf("INTERVAL %s %s", count, unit)
+	default:
+		literal = fmt.Sprintf("INTERVAL '%s' %s", count, unit)
+	}
+
+	if e.op == "" {
+		sql = literal
+		return
+	}
+
+	base := e.base
+	if base == "" {
+		base = "CURRENT_TIMESTAMP"
+		if name != "" {
+			base = "NOW()"
+		}
+	}
+
+	if name == MySQLName {
+		fn := "DATE_ADD"
+		if e.op == "-" {
+			fn = "DATE_SUB"
+		}
+		sql = fmt.Sprintf("%s(%s, %s)", fn, base, literal)
+		return
+	}
+
+	sql = fmt.Sprintf("%s %s %s", base, e.op, literal)
+	return
+}
+
+var intervalUnits = []struct {
+	d    time.Duration
+	name string
+}{
+	{24 * time.Hour, "DAY"},
+	{time.Hour, "HOUR"},
+	{time.Minute, "MINUTE"},
+	{time.Second, "SECOND"},
+}
+
+// intervalUnit returns the count of the largest unit d is a multiple of.
+// Fractions of a second are rendered as MICROSECOND for MySQL and as
+// fractional SECOND otherwise.
+func intervalUnit(d time.Duration, microseconds bool) (string, string) {
+	for _, u := range intervalUnits {
+		if d%u.d == 0 {
+			return strconv.FormatInt(int64(d/u.d), 10), u.name
+		}
+	}
+
+	if microseconds {
+		return strconv.FormatInt(int64(d/time.Microsecond), 10), "MICROSECOND"
+	}
+	return formatFloat(d.Seconds()), "SECOND"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,55 @@
+package sqrl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalToSql(t *testing.T) {
+	tests := []struct {
+		expr Sqlizer
+		sql  string
+	}{
+		{Interval(90 * time.Minute), "INTERVAL '90' MINUTE"},
+		{Interval(48 * time.Hour).For(Postgres), "INTERVAL '2 day'"},
+		{Interval(1500 * time.Millisecond), "INTERVAL '1.5' SECOND"},
+		{Interval(1500 * time.Millisecond).For(MySQL), "INTERVAL 1500000 MICROSECOND"},
+		{Ago(time.Hour), "CURRENT_TIMESTAMP - INTERVAL '1' HOUR"},
+		{Ago(time.Hour).For(Postgres), "NOW() - INTERVAL '1 hour'"},
+		{Ago(time.Hour).For(MySQL), "DATE_SUB(NOW(), INTERVAL 1 HOUR)"},
+		{Ago(time.Hour).For(SQLite), "datetime('now', '-3600 seconds')"},
+		{FromNow(30 * time.Second).For(Postgres), "NOW() + INTERVAL '30 second'"},
+		{DateAdd("created_at", 24*time.Hour).For(MySQL), "DATE_ADD(created_at, INTERVAL 1 DAY)"},
+		{DateSub("created_at", time.Minute).For(SQLite), "datetime(created_at, '-60 seconds')"},
+		{DateAdd("created_at", -time.Hour).For(SQLite), "datetime(created_at, '-3600 seconds')"},
+		{Ago(-time.Hour).For(SQLite), "datetime('now', '+3600 seconds')"},
+		{DateAdd("created_at", -time.Hour).For(Postgres), "created_at - INTERVAL '1 hour'"},
+	}
+
+	for _, test := range tests {
+		sql, args, err := test.expr.ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Empty(t, args)
+	}
+}
+
+func TestIntervalSQLiteErr(t *testing.T) {
+	_, _, err := Interval(time.Hour).For(SQLite).ToSql()
+	assert.Error(t, err)
+}
+
+func TestAgoInWhere(t *testing.T) {
+	sql, args, err := Select("id").
+		From("events").
+		Where(Gt{"created_at": Ago(24 * time.Hour).For(Postgres)}).
+		Where("kind = ?", 1).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SELECT id FROM events WHERE created_at > (NOW() - INTERVAL '1 day') AND kind = $1", sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
@@ -0,0 +1,136 @@
+package sqrl
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// jsonPathExpr extracts a value from a JSON column
+type jsonPathExpr struct {
+	column  string
+	path    []string
+	dialect Dialect
+}
+
+// JSONPath builds an expression extracting the value at path from the JSON
+// column as text. Numeric path elements are treated as array indexes.
+//
+// Without a dialect the standard SQL JSON_VALUE function is used.
+// Ex:
+//     .Where(Eq{JSONPath("data", "a", "b").For(Postgres).Column(): 1}) == "data->'a'->>'b' = 1"
+//     JSONPath("data", "a", "0").For(MySQL) == "JSON_UNQUOTE(JSON_EXTRACT(data, '$.a[0]'))"
+func JSONPath(column string, path ...string) jsonPathExpr {
+	return jsonPathExpr{column: column, path: path}
+}
+
+// For sets the dialect the expression is rendered for.
+func (e jsonPathExpr) For(dialect Dialect) jsonPathExpr {
+	e.dialect = dialect
+	return e
+}
+
+// Column returns the rendered expression, so it can be used as a key of
+// Eq, Lt and friends.
+func (e jsonPathExpr) Column() string {
+	sql, _, _ := e.ToSql()
+	return sql
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (e jsonPathExpr) ToSql() (sql string, args []interface{}, err error) {
+	if len(e.path) == 0 {
+		err = fmt.Errorf("json path of %s must have at least one element", e.column)
+		return
+	}
+
+	if dialectName(e.dialect) == PostgresName {
+		sql, err = e.postgresPath()
+		return
+	}
+
+	var path string
+	if path, err = escapeLiteral(jsonPath(e.path), e.dialect); err != nil {
+		return
+	}
+	switch dialectName(e.dialect) {
+	case MySQLName:
+		sql = fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s))", e.column, path)
+	case SQLiteName:
+		sql = fmt.Sprintf("json_extract(%s, %s)", e.column, path)
+	default:
+		sql = fmt.Sprintf("JSON_VALUE(%s, %s)", e.column, path)
+	}
+	return
+}
+
+// postgresPath renders the expression with the PostgreSQL -> and ->>
+// operators.
+func (e jsonPathExpr) postgresPath() (string, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(e.column)
+	for i, elem := range e.path {
+		if i == len(e.path)-1 {
+			buf.WriteString("->>")
+		} else {
+			buf.WriteString("->")
+		}
+
+		if isIndex(elem) {
+			buf.WriteString(elem)
+			continue
+		}
+		key, err := escapeLiteral(elem, e.dialect)
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(key)
+	}
+	return buf.String(), nil
+}
+
+// jsonPath renders path as SQL/JSON path, e.g. $.a[0]."b c"
+func jsonPath(path []string) string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("$")
+	for _, elem := range path {
+		switch {
+		case isIndex(elem):
+			buf.WriteString("[")
+			buf.WriteString(elem)
+			buf.WriteString("]")
+		case isIdentifier(elem):
+			buf.WriteString(".")
+			buf.WriteString(elem)
+		default:
+			buf.WriteString(".\"")
+			buf.WriteString(strings.Replace(strings.Replace(elem, `\`, `\\`, -1), `"`, `\"`, -1))
+			buf.WriteString("\"")
+		}
+	}
+	return buf.String()
+}
+
+func isIndex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isNameChar(s[i], i == 0) {
+			return false
+		}
+	}
+	return true
+}
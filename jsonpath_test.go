@@ -0,0 +1,52 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPathToSql(t *testing.T) {
+	tests := []struct {
+		expr Sqlizer
+		sql  string
+	}{
+		{JSONPath("data", "a", "b"), "JSON_VALUE(data, '$.a.b')"},
+		{JSONPath("data", "a", "b").For(Postgres), "data->'a'->>'b'"},
+		{JSONPath("data", "tags", "0").For(Postgres), "data->'tags'->>0"},
+		{JSONPath("data", "it's").For(Postgres), "data->>'it''s'"},
+		{JSONPath("data", "a", "0").For(MySQL), "JSON_UNQUOTE(JSON_EXTRACT(data, '$.a[0]'))"},
+		{JSONPath("data", "first name").For(SQLite), `json_extract(data, '$."first name"')`},
+		{JSONPath("data", `a\'`).For(Postgres), `data->>E'a\\'''`},
+		{JSONPath("data", `a\'`).For(MySQL), `JSON_UNQUOTE(JSON_EXTRACT(data, '$."a\\\\''"'))`},
+		{JSONPath("data", `a\'`).For(SQLite), `json_extract(data, '$."a\\''"')`},
+	}
+
+	for _, test := range tests {
+		sql, args, err := test.expr.ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Empty(t, args)
+	}
+
+	_, _, err := JSONPath("data").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = JSONPath("data", "a\x00").For(Postgres).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = JSONPath("data", "a\x00").For(MySQL).ToSql()
+	assert.Error(t, err)
+}
+
+func TestJSONPathInWhere(t *testing.T) {
+	sql, args, err := Select("id").
+		From("users").
+		Where(Eq{JSONPath("profile", "address", "city").For(MySQL).Column(): "Berlin"}).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT id FROM users WHERE JSON_UNQUOTE(JSON_EXTRACT(profile, '$.address.city')) = ?"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"Berlin"}, args)
+}
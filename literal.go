@@ -0,0 +1,95 @@
+package sqrl
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// EscapeLiteral renders arg as SQL literal of dialect d, for statements
+// which can't bind args. Unlike Interpolate it is safe against SQL
+// injection: only NULL, booleans, finite numbers and valid UTF-8 strings
+// without NUL bytes are rendered, other args return an error.
+// Ex:
+//     EscapeLiteral("it's", MySQL) == "'it''s'"
+func EscapeLiteral(arg interface{}, d Dialect) (string, error) {
+	return escapeLiteral(arg, d)
+}
+
+// escapeLiteral renders arg as SQL literal for statements which can't bind
+// args, like SET and the predicate of CREATE INDEX.
+//
+// Unlike sqlLiteral it is safe to put the result into an executed statement
+// with untrusted args: it only renders NULL, booleans, finite numbers and
+// valid UTF-8 strings without NUL bytes, and returns an error for other
+// types. Strings with backslashes are rendered as E'...' on PostgreSQL, so
+// they don't depend on standard_conforming_strings, and MySQL strings escape
+// backslashes, which is still safe with NO_BACKSLASH_ESCAPES.
+func escapeLiteral(arg interface{}, d Dialect) (string, error) {
+	if v, ok := arg.(driver.Valuer); ok {
+		var err error
+		if arg, err = v.Value(); err != nil {
+			return "", err
+		}
+	}
+
+	name := dialectName(d)
+	switch v := arg.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return sqlLiteral(v, d)
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return "", fmt.Errorf("cannot escape non-finite float %v", v)
+		}
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", fmt.Errorf("cannot escape non-finite float %v", v)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case string:
+		if !utf8.ValidString(v) || strings.IndexByte(v, 0) >= 0 {
+			return "", fmt.Errorf("cannot escape string %q with invalid UTF-8 or NUL bytes", v)
+		}
+		if (name == "" || name == PostgresName) && strings.IndexByte(v, '\\') >= 0 {
+			v = strings.Replace(v, `\`, `\\`, -1)
+			return "E'" + strings.Replace(v, "'", "''", -1) + "'", nil
+		}
+		return quoteString(v, name), nil
+	}
+	return "", fmt.Errorf("cannot escape arg of type %T", arg)
+}
+
+// escapeSql builds s and inlines its args with escapeLiteral, for
+// statements which can't bind args. Escaped ?? outside of string literals
+// are unescaped, since the statement has no placeholders anymore, e.g. for
+// the PostgreSQL jsonb ? operator.
+func escapeSql(s Sqlizer, d Dialect) (string, error) {
+	sql, err := interpolate(s, d, escapeLiteral)
+	if err != nil || !strings.Contains(sql, "??") {
+		return sql, err
+	}
+
+	buf := &bytes.Buffer{}
+	for i := 0; i < len(sql); i++ {
+		if n := quotedLen(sql, i, dialectName(d)); n > 0 {
+			buf.WriteString(sql[i : i+n])
+			i += n - 1
+			continue
+		}
+		switch c := sql[i]; c {
+		case '?':
+			buf.WriteByte(c)
+			if i+1 < len(sql) && sql[i+1] == '?' {
+				i++
+			}
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String(), nil
+}
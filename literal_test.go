@@ -0,0 +1,59 @@
+package sqrl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeLiteral(t *testing.T) {
+	cases := []struct {
+		arg  interface{}
+		d    Dialect
+		want string
+	}{
+		{nil, nil, "NULL"},
+		{true, Postgres, "TRUE"},
+		{true, MySQL, "1"},
+		{42, nil, "42"},
+		{1.5, nil, "1.5"},
+		{"it's", nil, "'it''s'"},
+		{`a\b`, nil, `E'a\\b'`},
+		{`a\'b`, Postgres, `E'a\\''b'`},
+		{`a\b`, SQLite, `'a\b'`},
+		{`a\'b`, MySQL, `'a\\''b'`},
+		{`a\'b`, SQLServer, `'a\''b'`},
+	}
+	for _, c := range cases {
+		got, err := escapeLiteral(c.arg, c.d)
+		assert.NoError(t, err)
+		assert.Equal(t, c.want, got)
+	}
+
+	invalid := []interface{}{
+		math.NaN(),
+		math.Inf(1),
+		"a\x00b",
+		"\xbf\x27",
+		[]byte("x"),
+		struct{}{},
+	}
+	for _, arg := range invalid {
+		_, err := escapeLiteral(arg, MySQL)
+		assert.Error(t, err, "%#v", arg)
+	}
+}
+
+func TestEscapeSql(t *testing.T) {
+	sql, err := escapeSql(Expr("status = ? AND name <> '?'", "it's"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "status = 'it''s' AND name <> '?'", sql)
+
+	sql, err = escapeSql(Expr("tags ?? ? AND note = '??' AND \"a??\" = 1", "x??"), Postgres)
+	assert.NoError(t, err)
+	assert.Equal(t, "tags ? 'x??' AND note = '??' AND \"a??\" = 1", sql)
+
+	_, err = escapeSql(Expr("id = ?", struct{}{}), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LockMode is a table lock mode, see LockTableBuilder.In.
+type LockMode string
+
+// PostgreSQL table lock modes, from the weakest to the strongest. Oracle
+// supports the ROW SHARE, ROW EXCLUSIVE, SHARE, SHARE ROW EXCLUSIVE and
+// EXCLUSIVE modes.
+const (
+	LockAccessShare          LockMode = "ACCESS SHARE"
+	LockRowShare             LockMode = "ROW SHARE"
+	LockRowExclusive         LockMode = "ROW EXCLUSIVE"
+	LockShareUpdateExclusive LockMode = "SHARE UPDATE EXCLUSIVE"
+	LockShare                LockMode = "SHARE"
+	LockShareRowExclusive    LockMode = "SHARE ROW EXCLUSIVE"
+	LockExclusive            LockMode = "EXCLUSIVE"
+	LockAccessExclusive      LockMode = "ACCESS EXCLUSIVE"
+)
+
+// MySQL table lock modes.
+const (
+	LockRead      LockMode = "READ"
+	LockReadLocal LockMode = "READ LOCAL"
+	LockWrite     LockMode = "WRITE"
+)
+
+// LockTableBuilder builds SQL LOCK TABLE statements.
+//
+// PostgreSQL and Oracle hold table locks until the end of the transaction,
+// so the statement must be run in one, see RunInTx. MySQL holds them until
+// UNLOCK TABLES is run on the same connection, so the statement should be
+// run with a *sql.Conn rather than a *sql.DB.
+type LockTableBuilder struct {
+	StatementBuilderType
+
+	tables []string
+	mode   LockMode
+	nowait bool
+}
+
+// NewLockTableBuilder creates new instance of LockTableBuilder
+func NewLockTableBuilder(b StatementBuilderType) *LockTableBuilder {
+	return &LockTableBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *LockTableBuilder) RunWith(runner BaseRunnerContext) *LockTableBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *LockTableBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *LockTableBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext.
+func (b *LockTableBuilder) Timeout(d time.Duration) *LockTableBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on. MySQL
+// locks tables with LOCK TABLES, which takes the mode after each table.
+//
+// See StatementBuilderType.Dialect.
+func (b *LockTableBuilder) Dialect(d Dialect) *LockTableBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its table names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *LockTableBuilder) QuoteIdentifiers() *LockTableBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *LockTableBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.tables) == 0 {
+		err = fmt.Errorf("lock table statements must specify at least one table")
+		return
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifierList(b.tables, identName); err != nil {
+			return
+		}
+	}
+	d := dialectName(b.dialect)
+	mysql := d == MySQLName
+	switch {
+	case d == SQLiteName || d == SQLServerName:
+		err = fmt.Errorf("%s does not support LOCK TABLE", d)
+		return
+	case (mysql || d == OracleName) && len(b.mode) == 0:
+		err = fmt.Errorf("lock table statements must specify a lock mode on %s", d)
+		return
+	case mysql && !isMySQLLockMode(b.mode):
+		err = fmt.Errorf("invalid lock mode %q for %s", b.mode, d)
+		return
+	case !mysql && isMySQLLockMode(b.mode):
+		err = fmt.Errorf("lock mode %q is specific to %s", b.mode, MySQLName)
+		return
+	case len(b.mode) > 0 && !isObjectType(string(b.mode)):
+		err = fmt.Errorf("invalid lock mode %q", b.mode)
+		return
+	case b.nowait && mysql:
+		err = fmt.Errorf("%s does not support LOCK TABLES ... NOWAIT", d)
+		return
+	}
+
+	sql := getBuffer()
+	defer putBuffer(sql)
+
+	tables := b.quoteNames(b.tables, identName)
+	if mysql {
+		sql.WriteString("LOCK TABLES ")
+		for i, table := range tables {
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			sql.WriteString(table)
+			sql.WriteString(" ")
+			sql.WriteString(string(b.mode))
+		}
+		sqlStr = sql.String()
+		return
+	}
+
+	sql.WriteString("LOCK TABLE ")
+	sql.WriteString(strings.Join(tables, ", "))
+	if len(b.mode) > 0 {
+		sql.WriteString(" IN ")
+		sql.WriteString(string(b.mode))
+		sql.WriteString(" MODE")
+	}
+	if b.nowait {
+		sql.WriteString(" NOWAIT")
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+// isMySQLLockMode reports whether mode is a MySQL lock mode.
+func isMySQLLockMode(mode LockMode) bool {
+	return mode == LockRead || mode == LockReadLocal || mode == LockWrite
+}
+
+// Tables adds tables to lock.
+func (b *LockTableBuilder) Tables(tables ...string) *LockTableBuilder {
+	b.tables = append(b.tables, tables...)
+	return b
+}
+
+// In sets the lock mode, like LockAccessExclusive on PostgreSQL or
+// LockWrite on MySQL. PostgreSQL takes an ACCESS EXCLUSIVE lock if it
+// isn't set.
+// Ex:
+//     LockTable("users").In(LockAccessExclusive).Nowait()
+//     == "LOCK TABLE users IN ACCESS EXCLUSIVE MODE NOWAIT"
+func (b *LockTableBuilder) In(mode LockMode) *LockTableBuilder {
+	b.mode = mode
+	return b
+}
+
+// Nowait makes the statement fail instead of waiting if a table is already
+// locked by another transaction. MySQL doesn't support it.
+func (b *LockTableBuilder) Nowait() *LockTableBuilder {
+	b.nowait = true
+	return b
+}
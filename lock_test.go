@@ -0,0 +1,54 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockTableBuilderToSql(t *testing.T) {
+	sql, args, err := LockTable("users", "orders").In(LockAccessExclusive).Nowait().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "LOCK TABLE users, orders IN ACCESS EXCLUSIVE MODE NOWAIT", sql)
+	assert.Nil(t, args)
+
+	sql, _, err = LockTable("users").Dialect(Postgres).QuoteIdentifiers().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `LOCK TABLE "users"`, sql)
+
+	sql, _, err = LockTable("users").In(LockExclusive).Dialect(Oracle).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "LOCK TABLE users IN EXCLUSIVE MODE", sql)
+
+	sql, _, err = LockTable("users", "orders").In(LockWrite).Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "LOCK TABLES `users` WRITE, `orders` WRITE", sql)
+}
+
+func TestLockTableBuilderToSqlErr(t *testing.T) {
+	invalid := []*LockTableBuilder{
+		LockTable(),
+		LockTable("users").In("SHARE MODE; DROP TABLE users; --"),
+		LockTable("users").In(LockRead),
+		LockTable("users").Dialect(MySQL),
+		LockTable("users").In(LockShare).Dialect(MySQL),
+		LockTable("users").In(LockWrite).Nowait().Dialect(MySQL),
+		LockTable("users").Dialect(Oracle),
+		LockTable("users").In(LockShare).Dialect(SQLite),
+		LockTable("users").In(LockShare).Dialect(SQLServer),
+	}
+	for _, b := range invalid {
+		_, _, err := b.ToSql()
+		assert.Error(t, err)
+	}
+}
+
+func TestLockTableBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	_, err := LockTable("users").In(LockShare).RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, "LOCK TABLE users IN SHARE MODE", db.LastExecSql)
+
+	_, err = LockTable("users").Exec()
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
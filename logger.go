@@ -0,0 +1,280 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+)
+
+// RedactedArg replaces args removed from query logs by a Redactor.
+const RedactedArg = "[REDACTED]"
+
+// QueryLog describes a query run by a LoggingRunner.
+type QueryLog struct {
+	SQL      string
+	Args     []interface{}
+	Duration time.Duration
+	Err      error
+}
+
+// QueryLogger receives a QueryLog for every query run by a LoggingRunner.
+type QueryLogger func(ctx context.Context, l QueryLog)
+
+// Redactor reports whether the arg at index i, bound to column, must be
+// redacted in query logs. column is empty if it can't be determined from the
+// query.
+type Redactor func(column string, i int, arg interface{}) bool
+
+// RedactColumns redacts args bound to any of columns. Columns are matched
+// case insensitively and regardless of their table qualifier.
+// Ex:
+//     RedactColumns("password", "token")
+func RedactColumns(columns ...string) Redactor {
+	set := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		set[strings.ToLower(c)] = true
+	}
+
+	return func(column string, i int, arg interface{}) bool {
+		column = strings.ToLower(column)
+		if dot := strings.LastIndexByte(column, '.'); dot >= 0 {
+			column = column[dot+1:]
+		}
+		return set[strings.Trim(column, "\"`")]
+	}
+}
+
+// LogTo returns a QueryLogger printing queries to l.
+func LogTo(l *log.Logger) QueryLogger {
+	return func(ctx context.Context, q QueryLog) {
+		if q.Err != nil {
+			l.Printf("sql=%q args=%v duration=%s err=%q", q.SQL, q.Args, q.Duration, q.Err)
+			return
+		}
+		l.Printf("sql=%q args=%v duration=%s", q.SQL, q.Args, q.Duration)
+	}
+}
+
+// LoggingRunner wraps a runner and logs every query run with it.
+//
+// It can be passed to RunWith like the runner it wraps:
+//     runner := NewLoggingRunner(db, LogTo(logger), RedactColumns("password"))
+//     Update("users").Set("password", hash).Where(Eq{"id": id}).RunWith(runner).Exec()
+type LoggingRunner struct {
+	runner    BaseRunnerContext
+	logger    QueryLogger
+	redactors []Redactor
+}
+
+// NewLoggingRunner creates a LoggingRunner logging the queries run with
+// runner to logger, with args matched by any of redactors redacted.
+func NewLoggingRunner(runner BaseRunnerContext, logger QueryLogger, redactors ...Redactor) *LoggingRunner {
+	return &LoggingRunner{runner: wrapRunner(runner), logger: logger, redactors: redactors}
+}
+
+// ExecContext executes the query with the wrapped runner.
+func (r *LoggingRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	res, err := r.runner.ExecContext(ctx, query, args...)
+	r.log(ctx, query, args, start, err)
+	return res, err
+}
+
+// QueryContext queries the query with the wrapped runner.
+func (r *LoggingRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := r.runner.QueryContext(ctx, query, args...)
+	r.log(ctx, query, args, start, err)
+	return rows, err
+}
+
+// QueryRowContext queries a single row with the wrapped runner.
+func (r *LoggingRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	queryRower, ok := r.runner.(QueryRowerContext)
+	if !ok {
+		return &Row{err: ErrRunnerNotQueryRunnerContext}
+	}
+
+	start := time.Now()
+	row := queryRower.QueryRowContext(ctx, query, args...)
+	r.log(ctx, query, args, start, nil)
+	return row
+}
+
+func (r *LoggingRunner) log(ctx context.Context, query string, args []interface{}, start time.Time, err error) {
+	r.logger(ctx, QueryLog{
+		SQL:      query,
+		Args:     r.redact(query, args),
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
+// redact returns a copy of args with redacted args replaced by RedactedArg.
+func (r *LoggingRunner) redact(query string, args []interface{}) []interface{} {
+	if len(r.redactors) == 0 || len(args) == 0 {
+		return args
+	}
+
+	columns := placeholderColumns(query, len(args))
+	logged := make([]interface{}, len(args))
+	for i, arg := range args {
+		logged[i] = arg
+		for _, redact := range r.redactors {
+			if redact(columns[i], i, arg) {
+				logged[i] = RedactedArg
+				break
+			}
+		}
+	}
+	return logged
+}
+
+// sqlKeywords are the keywords placeholderColumns needs to tell apart from
+// column names.
+var sqlKeywords = map[string]bool{
+	"ALL": true, "AND": true, "AS": true, "ASC": true, "BETWEEN": true,
+	"BY": true, "CASE": true, "DELETE": true, "DESC": true, "DISTINCT": true,
+	"ELSE": true, "END": true, "ESCAPE": true, "EXISTS": true, "FROM": true,
+	"GROUP": true, "HAVING": true, "ILIKE": true, "IN": true, "INSERT": true,
+	"INTO": true, "IS": true, "JOIN": true, "LIKE": true, "LIMIT": true,
+	"NOT": true, "NULL": true, "OFFSET": true, "ON": true, "OR": true,
+	"ORDER": true, "RETURNING": true, "SELECT": true, "SET": true,
+	"THEN": true, "UNION": true, "UPDATE": true, "USING": true,
+	"VALUES": true, "WHEN": true, "WHERE": true,
+}
+
+// placeholderColumns returns the column each of the count placeholders of
+// query is bound to, as far as it can be told from the query: the column
+// compared to it, e.g. "a = ?", "a IN (?,?)" or "a BETWEEN ? AND ?", or the
+// column of its position in the VALUES of an INSERT. Both ? and numbered
+// placeholders like $1 are recognized.
+func placeholderColumns(query string, count int) []string {
+	columns := make([]string, count)
+
+	var (
+		candidate, current string
+		between            bool
+		depth, arg         int
+
+		insert, inColumns, inValues bool
+		insertColumns               []string
+		position                    int
+	)
+
+	bind := func(i int, column string) {
+		if i >= 0 && i < count {
+			columns[i] = column
+		}
+	}
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			n := quotedLen(query, i, "")
+			if c != '\'' {
+				candidate = query[i : i+n]
+				if inColumns {
+					insertColumns = append(insertColumns, candidate)
+				}
+			}
+			i += n - 1
+
+		case c == '?':
+			column := current
+			if inValues && depth > 0 && position < len(insertColumns) {
+				column = insertColumns[position]
+			}
+			bind(arg, column)
+			arg++
+
+		case (c == '$' || c == ':') && i+1 < len(query) && isDigit(query[i+1]):
+			j := i + 1
+			n := 0
+			for j < len(query) && isDigit(query[j]) {
+				n = n*10 + int(query[j]-'0')
+				j++
+			}
+			column := current
+			if inValues && depth > 0 && position < len(insertColumns) {
+				column = insertColumns[position]
+			}
+			bind(n-1, column)
+			i = j - 1
+
+		case c == '(':
+			if insert && !inValues && depth == 0 && !inColumns && len(insertColumns) == 0 {
+				inColumns = true
+			}
+			if inValues && depth == 0 {
+				position = 0
+			}
+			depth++
+
+		case c == ')':
+			depth--
+			if inColumns && depth == 0 {
+				inColumns = false
+			}
+
+		case c == ',':
+			if inValues && depth == 1 {
+				position++
+			}
+
+		case c == '=' || c == '<' || c == '>' || c == '!':
+			current = candidate
+
+		case isNameChar(c, true):
+			j := i + 1
+			for j < len(query) && (isNameChar(query[j], false) || query[j] == '.') {
+				j++
+			}
+			word := query[i:j]
+			i = j - 1
+
+			if inColumns {
+				insertColumns = append(insertColumns, word)
+				continue
+			}
+
+			upper := strings.ToUpper(word)
+			if !sqlKeywords[upper] {
+				if j < len(query) && query[j] == '(' {
+					// function call
+					continue
+				}
+				candidate = word
+				continue
+			}
+
+			switch upper {
+			case "INSERT":
+				insert = true
+			case "VALUES":
+				inValues = insert
+			case "IN", "LIKE", "ILIKE", "NOT", "IS":
+				current = candidate
+			case "BETWEEN":
+				current = candidate
+				between = true
+			case "AND":
+				if between {
+					between = false
+				} else {
+					current = ""
+				}
+			default:
+				if depth == 0 {
+					inValues = false
+				}
+				current = ""
+			}
+		}
+	}
+
+	return columns
+}
@@ -0,0 +1,63 @@
+package sqrl
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceholderColumns(t *testing.T) {
+	cases := []struct {
+		sql      string
+		count    int
+		expected []string
+	}{
+		{"UPDATE users SET name = ?, password = ? WHERE id = ?", 3, []string{"name", "password", "id"}},
+		{"SELECT * FROM users WHERE u.token IN (?,?) AND age BETWEEN ? AND ? LIMIT ?", 5, []string{"u.token", "u.token", "age", "age", ""}},
+		{"INSERT INTO users (name,\"password\") VALUES (?,?),(?,LOWER(?)) RETURNING id", 4, []string{"name", "\"password\"", "name", "\"password\""}},
+		{"SELECT * FROM users WHERE LOWER(email) = LOWER($2) AND name <> $1", 2, []string{"name", "email"}},
+		{"SELECT * FROM users WHERE name = 'a = ?' AND token = ?", 1, []string{"token"}},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, placeholderColumns(c.sql, c.count), c.sql)
+	}
+}
+
+func TestLoggingRunner(t *testing.T) {
+	db := &DBStub{}
+	var logs []QueryLog
+	runner := NewLoggingRunner(db, func(ctx context.Context, l QueryLog) {
+		logs = append(logs, l)
+	}, RedactColumns("password", "token"))
+
+	_, err := Update("users").Set("name", "Joe").Set("password", "secret").Where("id = ?", 1).RunWith(runner).Exec()
+	assert.NoError(t, err)
+
+	assert.Len(t, logs, 1)
+	assert.Equal(t, "UPDATE users SET name = ?, password = ? WHERE id = ?", logs[0].SQL)
+	assert.Equal(t, []interface{}{"Joe", RedactedArg, 1}, logs[0].Args)
+	assert.Equal(t, []interface{}{"Joe", "secret", 1}, db.LastExecArgs)
+}
+
+func TestLoggingRunnerRedactByIndex(t *testing.T) {
+	db := &DBStub{}
+	var logs []QueryLog
+	runner := NewLoggingRunner(db, func(ctx context.Context, l QueryLog) {
+		logs = append(logs, l)
+	}, func(column string, i int, arg interface{}) bool {
+		return i == 0
+	})
+
+	Select("*").From("users").Where("a = ? OR b = ?", 1, 2).RunWith(runner).QueryRow().Scan()
+	assert.Equal(t, []interface{}{RedactedArg, 2}, logs[0].Args)
+}
+
+func TestLogTo(t *testing.T) {
+	buf := &bytes.Buffer{}
+	LogTo(log.New(buf, "", 0))(context.Background(), QueryLog{SQL: "SELECT ?", Args: []interface{}{1}})
+	assert.Equal(t, "sql=\"SELECT ?\" args=[1] duration=0s\n", buf.String())
+}
@@ -0,0 +1,122 @@
+package sqrl
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// Memoize makes child builders cache the SQL and args built by ToSql, and
+// return them without rebuilding as long as none of their methods changed
+// the query, e.g. for template builders run repeatedly with RunWith.
+//
+// Changes are detected by a shallow comparison of the builder, so
+// Sqlizers passed to it, like subqueries given to Where, must not be changed
+// after the first ToSql. Memoizing pays off for large queries, small ones
+// build about as fast as they are compared.
+func (b StatementBuilderType) Memoize() StatementBuilderType {
+	b.memoize = true
+	return b
+}
+
+// memo caches the SQL built by a builder. It is safe for concurrent use, so
+// a memoized builder can be run by several goroutines.
+type memo struct {
+	entry atomic.Value // *memoEntry
+}
+
+// memoEntry is an immutable snapshot of a builder and the SQL built from it.
+type memoEntry struct {
+	state reflect.Value
+	sql   string
+	args  []interface{}
+}
+
+// newMemo returns a memo for a builder, or nil if it isn't memoized. The
+// memo is allocated with the builder rather than on the first ToSql, which
+// may run concurrently.
+func newMemo(memoize bool) *memo {
+	if !memoize {
+		return nil
+	}
+	return &memo{}
+}
+
+// get returns the cached SQL and a copy of its args, if builder, a pointer
+// to a builder struct, is unchanged since they were cached.
+func (m *memo) get(builder interface{}) (string, []interface{}, bool) {
+	e, _ := m.entry.Load().(*memoEntry)
+	if e == nil || !sameValue(e.state, reflect.ValueOf(builder).Elem()) {
+		return "", nil, false
+	}
+	var args []interface{}
+	if e.args != nil {
+		args = make([]interface{}, len(e.args))
+		copy(args, e.args)
+	}
+	return e.sql, args, true
+}
+
+// set caches sql and args for the current state of builder.
+func (m *memo) set(builder interface{}, sql string, args []interface{}) {
+	v := reflect.ValueOf(builder).Elem()
+	e := &memoEntry{state: reflect.New(v.Type()).Elem(), sql: sql}
+	e.state.Set(v)
+	if args != nil {
+		e.args = make([]interface{}, len(args))
+		copy(e.args, args)
+	}
+	m.entry.Store(e)
+}
+
+// sameValue reports whether a and b are shallowly equal: slices and maps
+// must share their elements, pointers their target.
+func sameValue(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !sameValue(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Elem().Type() != b.Elem().Type() {
+			return false
+		}
+		return sameValue(a.Elem(), b.Elem())
+	case reflect.Slice, reflect.Map:
+		return a.Pointer() == b.Pointer() && a.Len() == b.Len()
+	case reflect.Ptr, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	}
+	return false
+}
+
+// memoized returns the SQL of builder cached in m, or builds and caches it
+// with build if builder changed. Without a memo it just builds.
+func memoized(m *memo, builder interface{}, build func() (string, []interface{}, error)) (string, []interface{}, error) {
+	if m == nil {
+		return build()
+	}
+	if sql, args, ok := m.get(builder); ok {
+		return sql, args, nil
+	}
+	sql, args, err := build()
+	if err == nil {
+		m.set(builder, sql, args)
+	}
+	return sql, args, err
+}
@@ -0,0 +1,99 @@
+package sqrl
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoize(t *testing.T) {
+	b := StatementBuilder.Memoize().Select("id").From("users").Where("id = ?", 1)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+	cached := b.memo.entry.Load().(*memoEntry).sql
+
+	args[0] = 2
+	sql, args, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+	assert.Equal(t, cached, b.memo.entry.Load().(*memoEntry).sql)
+
+	b.Where("active = ?", true).PlaceholderFormat(Dollar)
+	sql, args, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id = $1 AND active = $2", sql)
+	assert.Equal(t, []interface{}{1, true}, args)
+
+	b.Limit(10)
+	sql, _, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id = $1 AND active = $2 LIMIT 10", sql)
+}
+
+func TestMemoizeBuilders(t *testing.T) {
+	i := Insert("users").Columns("id").Values(1).Memoize()
+	i.ToSql()
+	sql, args, err := i.Values(2).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id) VALUES (?),(?)", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	u := Update("users").Set("name", "Joe").Memoize()
+	u.ToSql()
+	sql, _, err = u.Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ? WHERE id = ?", sql)
+
+	d := Delete("users").Memoize()
+	d.ToSql()
+	sql, _, err = d.Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM users WHERE id = ?", sql)
+
+	_, _, err = Delete("").Memoize().ToSql()
+	assert.Error(t, err)
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	b := StatementBuilder.Memoize().Select("id").From("users").Where("id = ?", 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				sql, args, err := b.ToSql()
+				assert.NoError(t, err)
+				assert.Equal(t, "SELECT id FROM users WHERE id = ?", sql)
+				assert.Equal(t, []interface{}{1}, args)
+			}
+		}()
+	}
+	wg.Wait()
+
+	c := b.Copy().Where("active = ?", true)
+	sql, _, err := c.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id = ? AND active = ?", sql)
+	assert.NotNil(t, c.memo)
+	assert.True(t, b.memo != c.memo)
+}
+
+func BenchmarkInsertBuilderToSqlMemoized(b *testing.B) {
+	qb := Insert("users").Columns("id", "name", "email").PlaceholderFormat(Dollar).Memoize()
+	for i := 0; i < 100; i++ {
+		qb.Values(i, "name", "email")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		qb.ToSql()
+	}
+}
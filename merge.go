@@ -0,0 +1,614 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MergeBuilder builds SQL MERGE statements, which insert, update or delete
+// rows of a target table depending on whether they match the rows of a
+// source. It is the way to upsert on SQL Server and Oracle, which have no
+// ON CONFLICT, and is supported by PostgreSQL 15 and later. MySQL and
+// SQLite have no MERGE.
+type MergeBuilder struct {
+	StatementBuilderType
+
+	prefixes  exprs
+	into      string
+	using     Sqlizer
+	onParts   []Sqlizer
+	clauses   []*mergeClause
+	suffixes  exprs
+	clauseErr error
+}
+
+// Kinds of WHEN clauses of a MERGE statement.
+const (
+	whenMatched = iota
+	whenNotMatched
+	whenNotMatchedBySource
+)
+
+type mergeClause struct {
+	when      int
+	condParts []Sqlizer
+	sets      []setClause
+	delete    bool
+	doNothing bool
+	columns   []string
+	values    []interface{}
+	valuesSet bool
+}
+
+// NewMergeBuilder creates new instance of MergeBuilder
+func NewMergeBuilder(b StatementBuilderType) *MergeBuilder {
+	return &MergeBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *MergeBuilder) RunWith(runner BaseRunnerContext) *MergeBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *MergeBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *MergeBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// ExecAffected builds and Execs the query with the Runner set by RunWith
+// using given context and returns the number of rows affected.
+//
+// See ExecAffectedWithContext.
+func (b *MergeBuilder) ExecAffected(ctx context.Context) (int64, error) {
+	if b.runWith == nil {
+		return 0, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecAffectedWithContext(ctx, b.runWith, b)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext.
+func (b *MergeBuilder) Timeout(d time.Duration) *MergeBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on. With
+// SQL Server the statement is terminated with a semicolon, which it
+// requires for MERGE. Dialects without MERGE, like MySQL, make ToSql
+// return an error.
+//
+// See StatementBuilderType.Dialect.
+func (b *MergeBuilder) Dialect(d Dialect) *MergeBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its table and column names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *MergeBuilder) QuoteIdentifiers() *MergeBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
+// query.
+func (b *MergeBuilder) PlaceholderFormat(f PlaceholderFormat) *MergeBuilder {
+	b.placeholderFormat = f
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *MergeBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.into) == 0 {
+		err = fmt.Errorf("merge statements must specify a target table")
+		return
+	}
+	if !supports(b.dialect, FeatureMerge) {
+		err = fmt.Errorf("%s does not support MERGE", b.dialect.Name())
+		return
+	}
+	if b.using == nil {
+		err = fmt.Errorf("merge statements must specify a source")
+		return
+	}
+	if len(b.onParts) == 0 {
+		err = fmt.Errorf("merge statements must have an ON condition")
+		return
+	}
+	if len(b.clauses) == 0 {
+		err = fmt.Errorf("merge statements must have at least one WHEN clause")
+		return
+	}
+	if b.clauseErr != nil {
+		err = b.clauseErr
+		return
+	}
+	if err = b.checkClauses(); err != nil {
+		return
+	}
+	oracle := dialectName(b.dialect) == OracleName
+
+	sql := getQueryBuffer(b.placeholderFormat)
+	defer putQueryBuffer(sql)
+	sql.d = b.dialect
+
+	if len(b.prefixes) > 0 {
+		args, err = b.prefixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
+		sql.WriteString(" ")
+	}
+
+	sql.WriteString("MERGE INTO ")
+	sql.WriteString(b.quoteName(b.into, identAliased))
+
+	using := b.using
+	if oracle {
+		using = oracleSource(using)
+	}
+	sql.WriteString(" USING ")
+	args, err = appendToSql(b.quoteParts([]Sqlizer{using}, identAliased), sql, "", args)
+	if err != nil {
+		return
+	}
+
+	if oracle {
+		// Oracle requires the ON condition in parentheses
+		sql.WriteString(" ON (")
+	} else {
+		sql.WriteString(" ON ")
+	}
+	args, err = appendToSql(b.onParts, sql, " AND ", args)
+	if err != nil {
+		return
+	}
+	if oracle {
+		sql.WriteString(")")
+	}
+
+	for _, c := range b.clauses {
+		args, err = b.appendClauseToSql(c, oracle, sql, args)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(b.suffixes) > 0 {
+		sql.WriteString(" ")
+		args, err = b.suffixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
+	}
+
+	if dialectName(b.dialect) == SQLServerName {
+		sql.WriteString(";")
+	}
+
+	if err = checkOperators(b.dialect, sql.String()); err != nil {
+		return
+	}
+
+	sqlStr, args, err = sql.finish(args)
+	return
+}
+
+func (b *MergeBuilder) appendClauseToSql(c *mergeClause, oracle bool, sql sqlWriter, args []interface{}) ([]interface{}, error) {
+	switch c.when {
+	case whenMatched:
+		sql.WriteString(" WHEN MATCHED")
+	case whenNotMatched:
+		sql.WriteString(" WHEN NOT MATCHED")
+	case whenNotMatchedBySource:
+		sql.WriteString(" WHEN NOT MATCHED BY SOURCE")
+	}
+
+	var err error
+	if len(c.condParts) > 0 && !oracle {
+		sql.WriteString(" AND ")
+		args, err = appendToSql(c.condParts, sql, " AND ", args)
+		if err != nil {
+			return args, err
+		}
+	}
+	sql.WriteString(" THEN ")
+
+	switch {
+	case c.doNothing:
+		sql.WriteString("DO NOTHING")
+
+	case c.delete:
+		sql.WriteString("DELETE")
+
+	case len(c.sets) > 0:
+		sql.WriteString("UPDATE SET ")
+		for i, s := range c.sets {
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			sql.WriteString(b.quoteName(s.column, identName))
+			sql.WriteString(" = ")
+			if v, ok := s.value.(Sqlizer); ok {
+				var vSql string
+				var vArgs []interface{}
+				vSql, vArgs, err = withDialect(v, b.dialect).ToSql()
+				if err != nil {
+					return args, err
+				}
+				sql.WriteString(vSql)
+				args = append(args, vArgs...)
+			} else {
+				sql.WriteString("?")
+				args = append(args, s.value)
+			}
+		}
+
+	default:
+		sql.WriteString("INSERT ")
+		if len(c.columns) > 0 {
+			sql.WriteString("(")
+			sql.WriteString(strings.Join(b.quoteNames(c.columns, identName), ","))
+			sql.WriteString(") ")
+		}
+		sql.WriteString("VALUES (")
+		args, err = appendValuesToSql(c.values, b.dialect, sql, args)
+		if err != nil {
+			return args, err
+		}
+		sql.WriteString(")")
+	}
+
+	if len(c.condParts) > 0 && oracle {
+		// Oracle has the conditions of WHEN clauses after their action
+		sql.WriteString(" WHERE ")
+		args, err = appendToSql(c.condParts, sql, " AND ", args)
+		if err != nil {
+			return args, err
+		}
+	}
+	return args, nil
+}
+
+// oracleSource converts the source of a MERGE to Oracle syntax, which has
+// no VALUES lists nor AS before table aliases.
+func oracleSource(using Sqlizer) Sqlizer {
+	switch u := using.(type) {
+	case mergeValues:
+		u.oracle = true
+		return u
+	case aliasExpr:
+		return Expr("(?) "+u.alias, u.expr)
+	}
+	return using
+}
+
+// appendValuesToSql writes a placeholder for each of values, or their SQL
+// for dialect d if they are Sqlizers, separated by commas.
+func appendValuesToSql(values []interface{}, d Dialect, sql sqlWriter, args []interface{}) ([]interface{}, error) {
+	for i, v := range values {
+		if i > 0 {
+			sql.WriteString(",")
+		}
+		if s, ok := v.(Sqlizer); ok {
+			vSql, vArgs, err := withDialect(s, d).ToSql()
+			if err != nil {
+				return args, err
+			}
+			sql.WriteString(vSql)
+			args = append(args, vArgs...)
+		} else {
+			sql.WriteString("?")
+			args = append(args, v)
+		}
+	}
+	return args, nil
+}
+
+// Prefix adds an expression to the beginning of the query
+func (b *MergeBuilder) Prefix(sql string, args ...interface{}) *MergeBuilder {
+	b.prefixes = append(b.prefixes, Expr(sql, args...))
+	return b
+}
+
+// Into sets the target table of the query, optionally with an alias.
+func (b *MergeBuilder) Into(table string) *MergeBuilder {
+	b.into = table
+	return b
+}
+
+// Using sets the source of the query to table, optionally with an alias.
+func (b *MergeBuilder) Using(table string) *MergeBuilder {
+	b.using = newPart(table)
+	return b
+}
+
+// UsingSelect sets the source of the query to a subquery.
+func (b *MergeBuilder) UsingSelect(from *SelectBuilder, alias string) *MergeBuilder {
+	b.using = Alias(from, alias)
+	return b
+}
+
+// UsingValues sets the source of the query to a VALUES list of rows, with
+// alias and column names.
+// Ex:
+//     Merge("users t").UsingValues("s", []string{"id", "name"}, []interface{}{1, "Joe"})
+//     == "MERGE INTO users t USING (VALUES (?,?)) AS s (id,name) ..."
+func (b *MergeBuilder) UsingValues(alias string, columns []string, rows ...[]interface{}) *MergeBuilder {
+	b.using = mergeValues{alias: alias, columns: columns, rows: rows}
+	return b
+}
+
+type mergeValues struct {
+	alias   string
+	columns []string
+	rows    [][]interface{}
+	oracle  bool
+	dialect Dialect
+}
+
+func (v mergeValues) defaultDialect(d Dialect) Sqlizer {
+	if v.dialect == nil {
+		v.dialect = d
+	}
+	return v
+}
+
+func (v mergeValues) ToSql() (string, []interface{}, error) {
+	if len(v.rows) == 0 {
+		return "", nil, errors.New("merge source values must have at least one row")
+	}
+
+	sql := getBuffer()
+	defer putBuffer(sql)
+	var args []interface{}
+	var err error
+	if v.oracle {
+		sql.WriteString("(")
+	} else {
+		sql.WriteString("(VALUES ")
+	}
+	for i, row := range v.rows {
+		if len(row) != len(v.columns) {
+			return "", nil, fmt.Errorf("merge source row %d has %d values, expected %d", i, len(row), len(v.columns))
+		}
+		if v.oracle {
+			args, err = v.appendOracleRow(i, row, sql, args)
+			if err != nil {
+				return "", nil, err
+			}
+			continue
+		}
+		if i > 0 {
+			sql.WriteString(",")
+		}
+		sql.WriteString("(")
+		args, err = appendValuesToSql(row, v.dialect, sql, args)
+		if err != nil {
+			return "", nil, err
+		}
+		sql.WriteString(")")
+	}
+	if v.oracle {
+		sql.WriteString(") ")
+		sql.WriteString(v.alias)
+		return sql.String(), args, nil
+	}
+	sql.WriteString(") AS ")
+	sql.WriteString(v.alias)
+	sql.WriteString(" (")
+	sql.WriteString(strings.Join(v.columns, ","))
+	sql.WriteString(")")
+	return sql.String(), args, nil
+}
+
+// appendOracleRow writes row i as SELECT from DUAL, united with the
+// previous rows.
+func (v mergeValues) appendOracleRow(i int, row []interface{}, sql sqlWriter, args []interface{}) ([]interface{}, error) {
+	if i > 0 {
+		sql.WriteString(" UNION ALL ")
+	}
+	sql.WriteString("SELECT ")
+	for j, value := range row {
+		if j > 0 {
+			sql.WriteString(", ")
+		}
+		var err error
+		args, err = appendValuesToSql([]interface{}{value}, v.dialect, sql, args)
+		if err != nil {
+			return args, err
+		}
+		sql.WriteString(" ")
+		sql.WriteString(v.columns[j])
+	}
+	sql.WriteString(" FROM DUAL")
+	return args, nil
+}
+
+// On adds a condition matching target and source rows, like Where of
+// SelectBuilder. Conditions are ANDed together.
+func (b *MergeBuilder) On(pred interface{}, args ...interface{}) *MergeBuilder {
+	b.onParts = append(b.onParts, newWherePart(pred, args...))
+	return b
+}
+
+// WhenMatched starts a WHEN MATCHED clause, for target rows matching a
+// source row. It must be followed by Set or Delete.
+func (b *MergeBuilder) WhenMatched() *MergeBuilder {
+	b.clauses = append(b.clauses, &mergeClause{when: whenMatched})
+	return b
+}
+
+// WhenNotMatched starts a WHEN NOT MATCHED clause, for source rows matching
+// no target row. It must be followed by Insert and Values.
+func (b *MergeBuilder) WhenNotMatched() *MergeBuilder {
+	b.clauses = append(b.clauses, &mergeClause{when: whenNotMatched})
+	return b
+}
+
+// WhenNotMatchedBySource starts a WHEN NOT MATCHED BY SOURCE clause, for
+// target rows matching no source row. It must be followed by Set or
+// Delete. It is supported by SQL Server and PostgreSQL 17 and later.
+func (b *MergeBuilder) WhenNotMatchedBySource() *MergeBuilder {
+	b.clauses = append(b.clauses, &mergeClause{when: whenNotMatchedBySource})
+	return b
+}
+
+// And adds a condition to the current WHEN clause, like Where of
+// SelectBuilder.
+func (b *MergeBuilder) And(pred interface{}, args ...interface{}) *MergeBuilder {
+	if c := b.clause("And"); c != nil {
+		c.condParts = append(c.condParts, newWherePart(pred, args...))
+	}
+	return b
+}
+
+// Set adds a SET clause to the UPDATE of the current WHEN clause. value may
+// be a Sqlizer, e.g. Expr("s.name").
+func (b *MergeBuilder) Set(column string, value interface{}) *MergeBuilder {
+	if c := b.clause("Set"); c != nil {
+		if c.when == whenNotMatched {
+			b.setClauseErr(errors.New("merge WHEN NOT MATCHED clauses can't update"))
+		}
+		c.sets = append(c.sets, setClause{column: column, value: value})
+	}
+	return b
+}
+
+// Delete makes the current WHEN clause delete the target row.
+func (b *MergeBuilder) Delete() *MergeBuilder {
+	if c := b.clause("Delete"); c != nil {
+		if c.when == whenNotMatched {
+			b.setClauseErr(errors.New("merge WHEN NOT MATCHED clauses can't delete"))
+		}
+		c.delete = true
+	}
+	return b
+}
+
+// DoNothing makes the current WHEN clause leave the row alone, e.g. to skip
+// the rows matching its condition before a later WHEN clause. It is
+// specific to PostgreSQL.
+// Ex:
+//     Merge("users t").Using("staging s").On("t.id = s.id").
+//         WhenMatched().And("t.locked").DoNothing().
+//         WhenMatched().Set("name", Expr("s.name"))
+//     == "MERGE INTO users t USING staging s ON t.id = s.id " +
+//         "WHEN MATCHED AND t.locked THEN DO NOTHING " +
+//         "WHEN MATCHED THEN UPDATE SET name = s.name"
+func (b *MergeBuilder) DoNothing() *MergeBuilder {
+	if c := b.clause("DoNothing"); c != nil {
+		c.doNothing = true
+	}
+	return b
+}
+
+// Insert sets the columns inserted by the current WHEN NOT MATCHED clause.
+func (b *MergeBuilder) Insert(columns ...string) *MergeBuilder {
+	if c := b.clause("Insert"); c != nil {
+		if c.when != whenNotMatched {
+			b.setClauseErr(errors.New("only merge WHEN NOT MATCHED clauses can insert"))
+		}
+		c.columns = append(c.columns, columns...)
+	}
+	return b
+}
+
+// Values sets the values inserted by the current WHEN NOT MATCHED clause.
+// They may be Sqlizers, e.g. Expr("s.name").
+func (b *MergeBuilder) Values(values ...interface{}) *MergeBuilder {
+	if c := b.clause("Values"); c != nil {
+		if c.when != whenNotMatched {
+			b.setClauseErr(errors.New("only merge WHEN NOT MATCHED clauses can insert"))
+		}
+		c.values = values
+		c.valuesSet = true
+	}
+	return b
+}
+
+// Suffix adds an expression to the end of the query
+func (b *MergeBuilder) Suffix(sql string, args ...interface{}) *MergeBuilder {
+	b.suffixes = append(b.suffixes, Expr(sql, args...))
+	return b
+}
+
+// clause returns the current WHEN clause, recording an error if method was
+// called before one was started.
+func (b *MergeBuilder) clause(method string) *mergeClause {
+	if len(b.clauses) == 0 {
+		b.setClauseErr(fmt.Errorf("merge %s must follow WhenMatched, WhenNotMatched or WhenNotMatchedBySource", method))
+		return nil
+	}
+	return b.clauses[len(b.clauses)-1]
+}
+
+func (b *MergeBuilder) setClauseErr(err error) {
+	if b.clauseErr == nil {
+		b.clauseErr = err
+	}
+}
+
+// checkClauses returns an error if a WHEN clause has no or several actions,
+// or isn't supported by Oracle with its dialect.
+func (b *MergeBuilder) checkClauses() error {
+	d := dialectName(b.dialect)
+	oracle := d == OracleName
+	seen := map[int]bool{}
+	for _, c := range b.clauses {
+		if c.doNothing && d != "" && d != PostgresName {
+			return fmt.Errorf("%s does not support merge DO NOTHING", d)
+		}
+		if oracle {
+			switch {
+			case seen[c.when]:
+				return errors.New("oracle supports one merge WHEN MATCHED and one WHEN NOT MATCHED clause")
+			case c.when == whenNotMatchedBySource:
+				return errors.New("oracle does not support merge WHEN NOT MATCHED BY SOURCE clauses")
+			case c.delete:
+				return errors.New("oracle does not support merge DELETE without UPDATE")
+			}
+			seen[c.when] = true
+		}
+
+		actions := 0
+		if c.doNothing {
+			actions++
+		}
+		if c.delete {
+			actions++
+		}
+		if len(c.sets) > 0 {
+			actions++
+		}
+		if c.valuesSet {
+			actions++
+		}
+		if actions != 1 {
+			return errors.New("merge WHEN clauses must have exactly one of Set, Delete, Values or DoNothing")
+		}
+		if c.valuesSet && len(c.columns) > 0 && len(c.columns) != len(c.values) {
+			return fmt.Errorf("merge insert has %d columns, but %d values", len(c.columns), len(c.values))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeBuilderToSql(t *testing.T) {
+	sql, args, err := Merge("users AS t").
+		Prefix("WITH x AS ?", Expr("(SELECT 1)")).
+		UsingValues("s", []string{"id", "name"}, []interface{}{1, "Joe"}, []interface{}{2, "Ann"}).
+		On("t.id = s.id").
+		WhenMatched().And("t.locked = ?", false).Set("name", Expr("s.name")).Set("visits", Expr("t.visits + ?", 1)).
+		WhenMatched().Delete().
+		WhenNotMatched().Insert("id", "name").Values(Expr("s.id"), Expr("s.name")).
+		WhenNotMatchedBySource().And(Eq{"t.active": true}).Set("active", false).
+		Suffix("OPTION (MAXDOP 1)").
+		Dialect(SQLServer).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "WITH x AS (SELECT 1) MERGE INTO users AS t USING (VALUES (?,?),(?,?)) AS s (id,name) ON t.id = s.id "+
+		"WHEN MATCHED AND t.locked = ? THEN UPDATE SET name = s.name, visits = t.visits + ? "+
+		"WHEN MATCHED THEN DELETE "+
+		"WHEN NOT MATCHED THEN INSERT (id,name) VALUES (s.id,s.name) "+
+		"WHEN NOT MATCHED BY SOURCE AND t.active = ? THEN UPDATE SET active = ? "+
+		"OPTION (MAXDOP 1);", sql)
+	assert.Equal(t, []interface{}{1, "Joe", 2, "Ann", false, 1, true, false}, args)
+}
+
+func TestMergeBuilderUsing(t *testing.T) {
+	sql, args, err := StatementBuilder.Merge("users t").
+		Using("staging s").
+		On("t.id = s.id").
+		WhenNotMatched().Values(Expr("s.id"), "new").
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO users t USING staging s ON t.id = s.id WHEN NOT MATCHED THEN INSERT VALUES (s.id,?)", sql)
+	assert.Equal(t, []interface{}{"new"}, args)
+
+	sql, args, err = Merge("users t").
+		UsingSelect(Select("id", "name").From("staging").Where("batch = ?", 7), "s").
+		On(Expr("t.id = s.id")).
+		WhenMatched().Set("name", Expr("s.name")).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO users t USING (SELECT id, name FROM staging WHERE batch = $1) AS s ON t.id = s.id "+
+		"WHEN MATCHED THEN UPDATE SET name = s.name", sql)
+	assert.Equal(t, []interface{}{7}, args)
+}
+
+func TestMergeBuilderInvalid(t *testing.T) {
+	invalid := []*MergeBuilder{
+		Merge("").Using("s").On("a = b").WhenMatched().Delete(),
+		Merge("t").On("a = b").WhenMatched().Delete(),
+		Merge("t").Using("s").WhenMatched().Delete(),
+		Merge("t").Using("s").On("a = b"),
+		Merge("t").Using("s").On("a = b").Set("a", 1),
+		Merge("t").Using("s").On("a = b").WhenMatched(),
+		Merge("t").Using("s").On("a = b").WhenMatched().Delete().Set("a", 1),
+		Merge("t").Using("s").On("a = b").WhenMatched().Insert("a").Values(1),
+		Merge("t").Using("s").On("a = b").WhenNotMatched().Set("a", 1),
+		Merge("t").Using("s").On("a = b").WhenNotMatched().Insert("a", "b").Values(1),
+		Merge("t").UsingValues("s", []string{"a"}, []interface{}{1, 2}).On("a = b").WhenMatched().Delete(),
+		Merge("t").UsingValues("s", []string{"a"}).On("a = b").WhenMatched().Delete(),
+	}
+
+	for _, b := range invalid {
+		_, _, err := b.ToSql()
+		assert.Error(t, err)
+	}
+}
+
+func TestMergeBuilderPostgres(t *testing.T) {
+	sql, args, err := StatementBuilder.Dialect(Postgres).Merge("users t").
+		UsingValues("s", []string{"id", "name"}, []interface{}{1, "Joe"}).
+		On("t.id = s.id").
+		WhenMatched().And("t.locked = ?", true).DoNothing().
+		WhenMatched().Set("name", Expr("s.name")).
+		WhenNotMatched().Insert("id", "name").Values(Expr("s.id"), Expr("s.name")).
+		WhenNotMatchedBySource().Delete().
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO users t USING (VALUES ($1,$2)) AS s (id,name) ON t.id = s.id "+
+		"WHEN MATCHED AND t.locked = $3 THEN DO NOTHING "+
+		"WHEN MATCHED THEN UPDATE SET name = s.name "+
+		"WHEN NOT MATCHED THEN INSERT (id,name) VALUES (s.id,s.name) "+
+		"WHEN NOT MATCHED BY SOURCE THEN DELETE", sql)
+	assert.Equal(t, []interface{}{1, "Joe", true}, args)
+
+	invalid := []*MergeBuilder{
+		Merge("t").Dialect(MySQL).Using("s").On("a = b").WhenMatched().Delete(),
+		Merge("t").Dialect(SQLite).Using("s").On("a = b").WhenMatched().Delete(),
+		Merge("t").Dialect(SQLServer).Using("s").On("a = b").WhenMatched().DoNothing(),
+		Merge("t").Dialect(Postgres).Using("s").On("a = b").WhenMatched().DoNothing().Delete(),
+	}
+	for _, b := range invalid {
+		_, _, err := b.ToSql()
+		assert.Error(t, err)
+	}
+}
+
+func TestMergeBuilderQuote(t *testing.T) {
+	sql, _, err := StatementBuilder.Dialect(SQLServer).Merge("order t").
+		Using("staging s").
+		On("t.id = s.id").
+		WhenMatched().Set("key", Expr("s.[key]")).
+		WhenNotMatched().Insert("id", "key").Values(Expr("s.id"), Expr("s.[key]")).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO order t USING staging s ON t.id = s.id "+
+		"WHEN MATCHED THEN UPDATE SET key = s.[key] "+
+		"WHEN NOT MATCHED THEN INSERT (id,key) VALUES (s.id,s.[key]);", sql)
+}
+
+func TestMergeBuilderOracle(t *testing.T) {
+	sql, args, err := StatementBuilder.Dialect(Oracle).Merge("users t").
+		UsingValues("s", []string{"id", "name"}, []interface{}{1, "Joe"}, []interface{}{2, "Jane"}).
+		On("t.id = s.id").
+		WhenMatched().And("t.locked = ?", false).Set("name", Expr("s.name")).
+		WhenNotMatched().Insert("id", "name").Values(Expr("s.id"), Expr("s.name")).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO users t "+
+		"USING (SELECT :1 id, :2 name FROM DUAL UNION ALL SELECT :3 id, :4 name FROM DUAL) s ON (t.id = s.id) "+
+		"WHEN MATCHED THEN UPDATE SET name = s.name WHERE t.locked = :5 "+
+		"WHEN NOT MATCHED THEN INSERT (id,name) VALUES (s.id,s.name)", sql)
+	assert.Equal(t, []interface{}{1, "Joe", 2, "Jane", false}, args)
+
+	sql, _, err = StatementBuilder.Dialect(Oracle).Merge("users t").
+		UsingSelect(Select("id", "name").From("staging"), "s").
+		On("t.id = s.id").
+		WhenMatched().Set("name", Expr("s.name")).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO users t USING (SELECT id, name FROM staging) s ON (t.id = s.id) "+
+		"WHEN MATCHED THEN UPDATE SET name = s.name", sql)
+
+	invalid := []*MergeBuilder{
+		Merge("t").Dialect(Oracle).Using("s").On("a = b").WhenMatched().Delete(),
+		Merge("t").Dialect(Oracle).Using("s").On("a = b").WhenNotMatchedBySource().Set("a", 1),
+		Merge("t").Dialect(Oracle).Using("s").On("a = b").WhenMatched().Set("a", 1).WhenMatched().Set("a", 2),
+	}
+	for _, b := range invalid {
+		_, _, err := b.ToSql()
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,88 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// QueryMetrics describes a query run by a MetricsRunner.
+type QueryMetrics struct {
+	// Query is the query normalized by NormalizeSQL.
+	Query string
+	// Fingerprint identifies the query regardless of its args, see
+	// FingerprintSQL.
+	Fingerprint string
+	Duration    time.Duration
+	// Rows is the number of rows affected by Exec, or -1 if it is unknown.
+	Rows int64
+	Err  error
+}
+
+// MetricsHook receives QueryMetrics for every query run by a MetricsRunner.
+type MetricsHook func(ctx context.Context, m QueryMetrics)
+
+// MetricsRunner wraps a runner and reports metrics of every query run with
+// it to a MetricsHook, e.g. to update Prometheus counters and histograms
+// labeled by fingerprint.
+// Ex:
+//     runner := NewMetricsRunner(db, func(ctx context.Context, m QueryMetrics) {
+//         queryDuration.WithLabelValues(m.Fingerprint).Observe(m.Duration.Seconds())
+//     })
+type MetricsRunner struct {
+	runner BaseRunnerContext
+	hook   MetricsHook
+}
+
+// NewMetricsRunner creates a MetricsRunner reporting the queries run with
+// runner to hook.
+func NewMetricsRunner(runner BaseRunnerContext, hook MetricsHook) *MetricsRunner {
+	return &MetricsRunner{runner: wrapRunner(runner), hook: hook}
+}
+
+// ExecContext executes the query with the wrapped runner.
+func (r *MetricsRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	res, err := r.runner.ExecContext(ctx, query, args...)
+
+	rows := int64(-1)
+	if err == nil {
+		if n, err := res.RowsAffected(); err == nil {
+			rows = n
+		}
+	}
+	r.report(ctx, query, start, rows, err)
+	return res, err
+}
+
+// QueryContext queries the query with the wrapped runner.
+func (r *MetricsRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := r.runner.QueryContext(ctx, query, args...)
+	r.report(ctx, query, start, -1, err)
+	return rows, err
+}
+
+// QueryRowContext queries a single row with the wrapped runner.
+func (r *MetricsRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	queryRower, ok := r.runner.(QueryRowerContext)
+	if !ok {
+		return &Row{err: ErrRunnerNotQueryRunnerContext}
+	}
+
+	start := time.Now()
+	row := queryRower.QueryRowContext(ctx, query, args...)
+	r.report(ctx, query, start, -1, nil)
+	return row
+}
+
+func (r *MetricsRunner) report(ctx context.Context, query string, start time.Time, rows int64, err error) {
+	normalized := NormalizeSQL(query)
+	r.hook(ctx, QueryMetrics{
+		Query:       normalized,
+		Fingerprint: fingerprint(normalized),
+		Duration:    time.Since(start),
+		Rows:        rows,
+		Err:         err,
+	})
+}
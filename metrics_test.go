@@ -0,0 +1,31 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsRunner(t *testing.T) {
+	var metrics []QueryMetrics
+	db := &CaptureRunner{Result: driver.RowsAffected(2)}
+	runner := NewMetricsRunner(db, func(ctx context.Context, m QueryMetrics) {
+		metrics = append(metrics, m)
+	})
+
+	Update("t").Set("a", 1).Where(Eq{"id": []int{1, 2}}).RunWith(runner).Exec()
+	Select("a").From("t").RunWith(runner).Scan()
+	db.Err = errors.New("fail")
+	Select("a").From("t").RunWith(runner).Query()
+
+	if assert.Len(t, metrics, 3) {
+		assert.Equal(t, "UPDATE t SET a = ? WHERE id IN (?)", metrics[0].Query)
+		assert.Equal(t, FingerprintSQL("UPDATE t SET a = ? WHERE id IN (?,?)"), metrics[0].Fingerprint)
+		assert.Equal(t, int64(2), metrics[0].Rows)
+		assert.Equal(t, int64(-1), metrics[1].Rows)
+		assert.Equal(t, db.Err, metrics[2].Err)
+	}
+}
@@ -0,0 +1,39 @@
+package mysql
+
+import "strings"
+
+// UseIndex adds a USE INDEX hint to table, to be passed to From or the join
+// methods of SelectBuilder.
+// Ex:
+//     sqrl.Select("*").From("users u").
+//         Join(mysql.UseIndex("orders o", "idx_user") + " ON o.user_id = u.id")
+//     == "SELECT * FROM users u JOIN orders o USE INDEX (idx_user) ON o.user_id = u.id"
+func UseIndex(table string, indexes ...string) string {
+	return indexHint(table, "USE INDEX", indexes)
+}
+
+// ForceIndex adds a FORCE INDEX hint to table, like UseIndex.
+func ForceIndex(table string, indexes ...string) string {
+	return indexHint(table, "FORCE INDEX", indexes)
+}
+
+// IgnoreIndex adds an IGNORE INDEX hint to table, like UseIndex.
+func IgnoreIndex(table string, indexes ...string) string {
+	return indexHint(table, "IGNORE INDEX", indexes)
+}
+
+// UseIndexForJoin adds a USE INDEX FOR JOIN hint to table, which only
+// applies to finding rows of the join, like UseIndex.
+func UseIndexForJoin(table string, indexes ...string) string {
+	return indexHint(table, "USE INDEX FOR JOIN", indexes)
+}
+
+// ForceIndexForJoin adds a FORCE INDEX FOR JOIN hint to table, like
+// UseIndexForJoin.
+func ForceIndexForJoin(table string, indexes ...string) string {
+	return indexHint(table, "FORCE INDEX FOR JOIN", indexes)
+}
+
+func indexHint(table, hint string, indexes []string) string {
+	return table + " " + hint + " (" + strings.Join(indexes, ",") + ")"
+}
@@ -0,0 +1,28 @@
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexHints(t *testing.T) {
+	sql, args, err := sqrl.Select("*").
+		From(mysql.ForceIndex("users u", "PRIMARY")).
+		ForceJoinOrder().
+		Join(mysql.UseIndex("orders o", "idx_user", "idx_date")+" ON o.user_id = u.id").
+		StraightJoin(mysql.IgnoreIndex("items i", "idx_name")+" ON i.order_id = o.id").
+		LeftJoin(mysql.UseIndexForJoin("tags t", "idx_item")+" ON t.item_id = i.id").
+		LeftJoin(mysql.ForceIndexForJoin("notes n", "idx_item")+" ON n.item_id = i.id AND n.kind = ?", 1).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT STRAIGHT_JOIN * FROM users u FORCE INDEX (PRIMARY) "+
+		"JOIN orders o USE INDEX (idx_user,idx_date) ON o.user_id = u.id "+
+		"STRAIGHT_JOIN items i IGNORE INDEX (idx_name) ON i.order_id = o.id "+
+		"LEFT JOIN tags t USE INDEX FOR JOIN (idx_item) ON t.item_id = i.id "+
+		"LEFT JOIN notes n FORCE INDEX FOR JOIN (idx_item) ON n.item_id = i.id AND n.kind = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
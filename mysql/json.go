@@ -0,0 +1,75 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// JSON converts value into MySQL JSON
+func JSON(value interface{}) sqrl.Sqlizer {
+	return jsonOp{value}
+}
+
+type jsonOp struct {
+	value interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (jo jsonOp) ToSql() (string, []interface{}, error) {
+	v, err := json.Marshal(jo.value)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to serialize json value: %v", err)
+	}
+
+	return "CAST(? AS JSON)", []interface{}{string(v)}, nil
+}
+
+// JSONContains checks whether the JSON column contains value, marshalled
+// like by JSON, using JSON_CONTAINS.
+// Ex:
+//     .Where(mysql.JSONContains("tags", []string{"a"})) == "JSON_CONTAINS(tags, CAST(? AS JSON))"
+func JSONContains(column string, value interface{}) sqrl.Sqlizer {
+	return sqrl.Expr("JSON_CONTAINS("+column+", ?)", JSON(value))
+}
+
+// JSONExtract extracts the value at the JSON path, e.g. "$.a[0]", of the
+// JSON column, using JSON_EXTRACT. The path is bound as arg.
+// Ex:
+//     .Column(mysql.JSONExtract("data", "$.a")) == "JSON_EXTRACT(data, ?)"
+func JSONExtract(column, path string) sqrl.Sqlizer {
+	return sqrl.Expr("JSON_EXTRACT("+column+", ?)", path)
+}
+
+// JSONExtractText extracts the value at the JSON path of the JSON column as
+// text, using JSON_UNQUOTE of JSON_EXTRACT.
+// Ex:
+//     .Where(sqrl.Expr("? = ?", mysql.JSONExtractText("data", "$.name"), "Joe"))
+//     == "JSON_UNQUOTE(JSON_EXTRACT(data, ?)) = ?"
+func JSONExtractText(column, path string) sqrl.Sqlizer {
+	return sqrl.Expr("JSON_UNQUOTE(?)", JSONExtract(column, path))
+}
+
+// JSONSet sets the value at the JSON path of the JSON column to value,
+// marshalled like by JSON, using JSON_SET, to be used with Set of
+// UpdateBuilder.
+// Ex:
+//     .Set("data", mysql.JSONSet("data", "$.name", "Joe"))
+//     == "data = JSON_SET(data, ?, CAST(? AS JSON))"
+func JSONSet(column, path string, value interface{}) sqrl.Sqlizer {
+	return sqrl.Expr("JSON_SET("+column+", ?, ?)", path, JSON(value))
+}
+
+// JSONRemove removes the values at the JSON paths of the JSON column, using
+// JSON_REMOVE.
+// Ex:
+//     .Set("data", mysql.JSONRemove("data", "$.a", "$.b")) == "data = JSON_REMOVE(data, ?, ?)"
+func JSONRemove(column string, paths ...string) sqrl.Sqlizer {
+	args := make([]interface{}, len(paths))
+	for i, path := range paths {
+		args[i] = path
+	}
+	return sqrl.Expr("JSON_REMOVE("+column+strings.Repeat(", ?", len(paths))+")", args...)
+}
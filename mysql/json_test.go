@@ -0,0 +1,52 @@
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidJSON(t *testing.T) {
+	valid := []struct {
+		op   sqrl.Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{mysql.JSON(map[string]int{"a": 1}), "CAST(? AS JSON)", []interface{}{`{"a":1}`}},
+		{mysql.JSON(nil), "CAST(? AS JSON)", []interface{}{"null"}},
+		{mysql.JSONContains("tags", []string{"a"}), "JSON_CONTAINS(tags, CAST(? AS JSON))", []interface{}{`["a"]`}},
+		{mysql.JSONExtract("data", "$.a[0]"), "JSON_EXTRACT(data, ?)", []interface{}{"$.a[0]"}},
+		{mysql.JSONExtractText("data", "$.a"), "JSON_UNQUOTE(JSON_EXTRACT(data, ?))", []interface{}{"$.a"}},
+		{mysql.JSONSet("data", "$.a", "b"), "JSON_SET(data, ?, CAST(? AS JSON))", []interface{}{"$.a", `"b"`}},
+		{mysql.JSONRemove("data", "$.a", "$.b"), "JSON_REMOVE(data, ?, ?)", []interface{}{"$.a", "$.b"}},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func TestJSONUpdate(t *testing.T) {
+	sql, args, err := sqrl.Update("users").
+		Set("data", mysql.JSONSet("data", "$.tags", []string{"a"})).
+		Where(sqrl.Expr("? = ?", mysql.JSONExtractText("data", "$.name"), "Joe")).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET data = JSON_SET(data, ?, CAST(? AS JSON)) "+
+		"WHERE JSON_UNQUOTE(JSON_EXTRACT(data, ?)) = ?", sql)
+	assert.Equal(t, []interface{}{"$.tags", `["a"]`, "$.name", "Joe"}, args)
+}
+
+func TestInvalidJSON(t *testing.T) {
+	sql, args, err := mysql.JSON(make(chan int)).ToSql()
+	assert.Error(t, err)
+	assert.Empty(t, sql)
+	assert.Nil(t, args)
+}
@@ -0,0 +1,228 @@
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// LoadDataBuilder builds MySQL LOAD DATA INFILE statements.
+//
+// LOAD DATA can't be prepared, so the file name and the strings of the
+// format are quoted into the statement instead of being bound as args.
+type LoadDataBuilder struct {
+	runWith sqrl.ExecerContext
+
+	file        string
+	local       bool
+	duplicates  string
+	table       string
+	charset     string
+	fieldsTerm  *string
+	enclosed    *string
+	optionally  bool
+	escaped     *string
+	linesStart  *string
+	linesTerm   *string
+	ignoreLines uint64
+	columns     []string
+}
+
+// LoadData returns a LoadDataBuilder loading file.
+// Ex:
+//     mysql.LoadData("/tmp/users.csv").Local().Into("users").
+//         FieldsTerminatedBy(",").FieldsEnclosedBy(`"`, true).LinesTerminatedBy("\n").
+//         IgnoreLines(1).Columns("id", "name")
+//     == `LOAD DATA LOCAL INFILE '/tmp/users.csv' INTO TABLE users FIELDS TERMINATED BY ','
+//         OPTIONALLY ENCLOSED BY '"' LINES TERMINATED BY '\n' IGNORE 1 LINES (id,name)`
+func LoadData(file string) *LoadDataBuilder {
+	return &LoadDataBuilder{file: file}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with Exec.
+func (b *LoadDataBuilder) RunWith(runner sqrl.ExecerContext) *LoadDataBuilder {
+	b.runWith = runner
+	return b
+}
+
+// Exec builds and Execs the statement with the Runner set by RunWith.
+func (b *LoadDataBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the statement with the Runner set by RunWith
+// using given context.
+func (b *LoadDataBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, sqrl.ErrRunnerNotSet
+	}
+	return sqrl.ExecWithContext(ctx, b.runWith, b)
+}
+
+// Local makes the client read the file, instead of the server.
+func (b *LoadDataBuilder) Local() *LoadDataBuilder {
+	b.local = true
+	return b
+}
+
+// Replace makes rows replace existing rows with the same unique key.
+func (b *LoadDataBuilder) Replace() *LoadDataBuilder {
+	b.duplicates = "REPLACE"
+	return b
+}
+
+// Ignore makes rows with the same unique key as existing rows be skipped.
+func (b *LoadDataBuilder) Ignore() *LoadDataBuilder {
+	b.duplicates = "IGNORE"
+	return b
+}
+
+// Into sets the table the rows are loaded into.
+func (b *LoadDataBuilder) Into(table string) *LoadDataBuilder {
+	b.table = table
+	return b
+}
+
+// CharacterSet sets the character set the file is interpreted with.
+func (b *LoadDataBuilder) CharacterSet(charset string) *LoadDataBuilder {
+	b.charset = charset
+	return b
+}
+
+// FieldsTerminatedBy sets the string separating fields.
+func (b *LoadDataBuilder) FieldsTerminatedBy(s string) *LoadDataBuilder {
+	b.fieldsTerm = &s
+	return b
+}
+
+// FieldsEnclosedBy sets the character fields are quoted with. If optionally
+// is set, only string fields are quoted.
+func (b *LoadDataBuilder) FieldsEnclosedBy(s string, optionally bool) *LoadDataBuilder {
+	b.enclosed = &s
+	b.optionally = optionally
+	return b
+}
+
+// FieldsEscapedBy sets the escape character of fields.
+func (b *LoadDataBuilder) FieldsEscapedBy(s string) *LoadDataBuilder {
+	b.escaped = &s
+	return b
+}
+
+// LinesStartingBy sets the prefix of lines, which is skipped with anything
+// before it.
+func (b *LoadDataBuilder) LinesStartingBy(s string) *LoadDataBuilder {
+	b.linesStart = &s
+	return b
+}
+
+// LinesTerminatedBy sets the string separating lines.
+func (b *LoadDataBuilder) LinesTerminatedBy(s string) *LoadDataBuilder {
+	b.linesTerm = &s
+	return b
+}
+
+// IgnoreLines skips the first n lines of the file, e.g. a CSV header.
+func (b *LoadDataBuilder) IgnoreLines(n uint64) *LoadDataBuilder {
+	b.ignoreLines = n
+	return b
+}
+
+// Columns sets the columns, or user variables, the fields are loaded into.
+func (b *LoadDataBuilder) Columns(columns ...string) *LoadDataBuilder {
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+//
+// The file name and the terminators are escaped into the statement with
+// sqrl.EscapeLiteral, so they can't have NUL bytes or invalid UTF-8.
+func (b *LoadDataBuilder) ToSql() (string, []interface{}, error) {
+	if b.file == "" {
+		return "", nil, fmt.Errorf("load data statements must specify a file")
+	}
+	if b.table == "" {
+		return "", nil, fmt.Errorf("load data statements must specify a table")
+	}
+
+	var err error
+	literal := func(s string) string {
+		lit, lerr := sqrl.EscapeLiteral(s, sqrl.MySQL)
+		if err == nil {
+			err = lerr
+		}
+		return lit
+	}
+
+	sql := &bytes.Buffer{}
+	sql.WriteString("LOAD DATA ")
+	if b.local {
+		sql.WriteString("LOCAL ")
+	}
+	sql.WriteString("INFILE ")
+	sql.WriteString(literal(b.file))
+	if b.duplicates != "" {
+		sql.WriteString(" ")
+		sql.WriteString(b.duplicates)
+	}
+	sql.WriteString(" INTO TABLE ")
+	sql.WriteString(b.table)
+
+	if b.charset != "" {
+		sql.WriteString(" CHARACTER SET ")
+		sql.WriteString(b.charset)
+	}
+
+	if b.fieldsTerm != nil || b.enclosed != nil || b.escaped != nil {
+		sql.WriteString(" FIELDS")
+		if b.fieldsTerm != nil {
+			sql.WriteString(" TERMINATED BY ")
+			sql.WriteString(literal(*b.fieldsTerm))
+		}
+		if b.enclosed != nil {
+			if b.optionally {
+				sql.WriteString(" OPTIONALLY")
+			}
+			sql.WriteString(" ENCLOSED BY ")
+			sql.WriteString(literal(*b.enclosed))
+		}
+		if b.escaped != nil {
+			sql.WriteString(" ESCAPED BY ")
+			sql.WriteString(literal(*b.escaped))
+		}
+	}
+
+	if b.linesStart != nil || b.linesTerm != nil {
+		sql.WriteString(" LINES")
+		if b.linesStart != nil {
+			sql.WriteString(" STARTING BY ")
+			sql.WriteString(literal(*b.linesStart))
+		}
+		if b.linesTerm != nil {
+			sql.WriteString(" TERMINATED BY ")
+			sql.WriteString(literal(*b.linesTerm))
+		}
+	}
+
+	if b.ignoreLines > 0 {
+		sql.WriteString(" IGNORE ")
+		sql.WriteString(strconv.FormatUint(b.ignoreLines, 10))
+		sql.WriteString(" LINES")
+	}
+
+	if len(b.columns) > 0 {
+		sql.WriteString(" (")
+		sql.WriteString(strings.Join(b.columns, ","))
+		sql.WriteString(")")
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return sql.String(), nil, nil
+}
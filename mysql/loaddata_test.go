@@ -0,0 +1,91 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadData(t *testing.T) {
+	valid := []struct {
+		b   *mysql.LoadDataBuilder
+		sql string
+	}{
+		{
+			mysql.LoadData("/tmp/users.csv").Into("users"),
+			"LOAD DATA INFILE '/tmp/users.csv' INTO TABLE users",
+		},
+		{
+			mysql.LoadData("/tmp/users.csv").Local().Into("users").
+				FieldsTerminatedBy(",").FieldsEnclosedBy(`"`, true).LinesTerminatedBy("\n").
+				IgnoreLines(1).Columns("id", "name"),
+			`LOAD DATA LOCAL INFILE '/tmp/users.csv' INTO TABLE users FIELDS TERMINATED BY ',' ` +
+				`OPTIONALLY ENCLOSED BY '"' LINES TERMINATED BY '` + "\n" + `' IGNORE 1 LINES (id,name)`,
+		},
+		{
+			mysql.LoadData(`C:\data\o'brien.tsv`).Replace().Into("db.users").CharacterSet("utf8mb4").
+				FieldsTerminatedBy("\t").FieldsEnclosedBy("", false).FieldsEscapedBy(`\`).
+				LinesStartingBy("xxx").LinesTerminatedBy("\r\n").Columns("id", "@skip"),
+			`LOAD DATA INFILE 'C:\\data\\o''brien.tsv' REPLACE INTO TABLE db.users CHARACTER SET utf8mb4 ` +
+				"FIELDS TERMINATED BY '\t' ENCLOSED BY '' ESCAPED BY '\\\\' " +
+				"LINES STARTING BY 'xxx' TERMINATED BY '\r\n' (id,@skip)",
+		},
+		{
+			mysql.LoadData("/tmp/a'b").Into("t").FieldsTerminatedBy(`\'`),
+			`LOAD DATA INFILE '/tmp/a''b' INTO TABLE t FIELDS TERMINATED BY '\\'''`,
+		},
+		{
+			mysql.LoadData("f").Ignore().Into("t").IgnoreLines(2),
+			"LOAD DATA INFILE 'f' IGNORE INTO TABLE t IGNORE 2 LINES",
+		},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.b.ToSql()
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Nil(t, args)
+	}
+}
+
+func TestInvalidLoadData(t *testing.T) {
+	_, _, err := mysql.LoadData("").Into("users").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = mysql.LoadData("/tmp/users.csv").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = mysql.LoadData("/tmp/a\x00b").Into("users").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = mysql.LoadData("/tmp/users.csv").Into("users").LinesTerminatedBy("\x00").ToSql()
+	assert.Error(t, err)
+}
+
+type execerStub struct {
+	query string
+}
+
+func (e *execerStub) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	e.query = query
+	return driver.RowsAffected(3), nil
+}
+
+func TestLoadDataExec(t *testing.T) {
+	_, err := mysql.LoadData("f").Into("t").Exec()
+	assert.Equal(t, sqrl.ErrRunnerNotSet, err)
+
+	db := &execerStub{}
+	res, err := mysql.LoadData("f").Into("t").RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, "LOAD DATA INFILE 'f' INTO TABLE t", db.query)
+
+	n, _ := res.RowsAffected()
+	assert.Equal(t, int64(3), n)
+}
@@ -0,0 +1,105 @@
+package mysql
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// OnDuplicateKeyUpdateBuilder builds MySQL ON DUPLICATE KEY UPDATE clauses,
+// to be used as suffix of an InsertBuilder.
+type OnDuplicateKeyUpdateBuilder struct {
+	alias string
+	sets  []updateSet
+}
+
+type updateSet struct {
+	column   string
+	value    interface{}
+	proposed bool
+}
+
+// OnDuplicateKeyUpdate returns an OnDuplicateKeyUpdateBuilder setting
+// columns to the values which were proposed for insertion, e.g.
+// name = VALUES(name).
+// Ex:
+//     sqrl.Insert("users").Columns("email", "name").Values("joe@example.com", "Joe").
+//         Suffix("?", mysql.OnDuplicateKeyUpdate("name"))
+//     == "INSERT INTO users (email,name) VALUES (?,?) ON DUPLICATE KEY UPDATE name = VALUES(name)"
+func OnDuplicateKeyUpdate(columns ...string) *OnDuplicateKeyUpdateBuilder {
+	return (&OnDuplicateKeyUpdateBuilder{}).SetValues(columns...)
+}
+
+// As renders the clause with the row alias syntax of MySQL 8.0.19+,
+// INSERT ... AS alias ON DUPLICATE KEY UPDATE, which replaces the deprecated
+// VALUES(). Columns of SetValues then refer to the alias.
+// Ex:
+//     mysql.OnDuplicateKeyUpdate("name").As("new")
+//     == "AS new ON DUPLICATE KEY UPDATE name = new.name"
+func (b *OnDuplicateKeyUpdateBuilder) As(alias string) *OnDuplicateKeyUpdateBuilder {
+	b.alias = alias
+	return b
+}
+
+// Set adds an assignment of value, which may be a Sqlizer, to column.
+func (b *OnDuplicateKeyUpdateBuilder) Set(column string, value interface{}) *OnDuplicateKeyUpdateBuilder {
+	b.sets = append(b.sets, updateSet{column: column, value: value})
+	return b
+}
+
+// SetValues adds assignments of the values which were proposed for
+// insertion to columns, using VALUES() or the row alias set by As.
+func (b *OnDuplicateKeyUpdateBuilder) SetValues(columns ...string) *OnDuplicateKeyUpdateBuilder {
+	for _, column := range columns {
+		b.sets = append(b.sets, updateSet{column: column, proposed: true})
+	}
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *OnDuplicateKeyUpdateBuilder) ToSql() (string, []interface{}, error) {
+	if len(b.sets) == 0 {
+		return "", nil, fmt.Errorf("ON DUPLICATE KEY UPDATE requires at least one column")
+	}
+
+	sql := &bytes.Buffer{}
+	args := make([]interface{}, 0, len(b.sets))
+	if b.alias != "" {
+		sql.WriteString("AS ")
+		sql.WriteString(b.alias)
+		sql.WriteString(" ")
+	}
+	sql.WriteString("ON DUPLICATE KEY UPDATE ")
+	for i, s := range b.sets {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString(s.column)
+		sql.WriteString(" = ")
+		switch {
+		case !s.proposed:
+			sql.WriteString("?")
+			args = append(args, s.value)
+		case b.alias != "":
+			sql.WriteString(b.alias + "." + s.column)
+		default:
+			sql.WriteString("VALUES(" + s.column + ")")
+		}
+	}
+
+	s, args, err := sqrl.Expr(sql.String(), args...).ToSql()
+	if len(args) == 0 {
+		args = nil
+	}
+	return s, args, err
+}
+
+// Values refers to the value which was proposed for insertion into column,
+// in an ON DUPLICATE KEY UPDATE clause.
+// Ex:
+//     mysql.OnDuplicateKeyUpdate().Set("visits", sqrl.Expr("visits + ?", mysql.Values("visits")))
+//     == "ON DUPLICATE KEY UPDATE visits = visits + VALUES(visits)"
+func Values(column string) sqrl.Sqlizer {
+	return sqrl.Expr("VALUES(" + column + ")")
+}
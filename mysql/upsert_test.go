@@ -0,0 +1,71 @@
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidOnDuplicateKeyUpdate(t *testing.T) {
+	valid := []struct {
+		op   sqrl.Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{
+			mysql.OnDuplicateKeyUpdate("name", "age"),
+			"ON DUPLICATE KEY UPDATE name = VALUES(name), age = VALUES(age)",
+			nil,
+		},
+		{
+			mysql.OnDuplicateKeyUpdate().
+				Set("visits", sqrl.Expr("visits + ?", mysql.Values("visits"))).
+				Set("name", "Joe"),
+			"ON DUPLICATE KEY UPDATE visits = visits + VALUES(visits), name = ?",
+			[]interface{}{"Joe"},
+		},
+		{
+			mysql.OnDuplicateKeyUpdate("name", "age").As("new"),
+			"AS new ON DUPLICATE KEY UPDATE name = new.name, age = new.age",
+			nil,
+		},
+		{
+			mysql.OnDuplicateKeyUpdate().As("new").
+				Set("visits", sqrl.Expr("visits + new.visits")).
+				SetValues("name"),
+			"AS new ON DUPLICATE KEY UPDATE visits = visits + new.visits, name = new.name",
+			nil,
+		},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func TestInvalidOnDuplicateKeyUpdate(t *testing.T) {
+	_, _, err := mysql.OnDuplicateKeyUpdate().ToSql()
+	assert.Error(t, err)
+
+	_, _, err = mysql.OnDuplicateKeyUpdate().As("new").ToSql()
+	assert.Error(t, err)
+}
+
+func TestInsertOnDuplicateKeyUpdate(t *testing.T) {
+	sql, args, err := sqrl.Insert("users").
+		Columns("email", "name").
+		Values("joe@example.com", "Joe").
+		Suffix("?", mysql.OnDuplicateKeyUpdate("name").Set("updated_at", sqrl.Expr("NOW()"))).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (email,name) VALUES (?,?) "+
+		"ON DUPLICATE KEY UPDATE name = VALUES(name), updated_at = NOW()", sql)
+	assert.Equal(t, []interface{}{"joe@example.com", "Joe"}, args)
+}
@@ -0,0 +1,105 @@
+package sqrl
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// NamedFormat is the prefix character of named placeholders.
+//
+// It can also be set as the PlaceholderFormat of a builder, so Exec and
+// Query pass sql.NamedArg args to the driver:
+//     Select("*").From("t").Where(Eq{"id": 1}).PlaceholderFormat(AtNamed).RunWith(db).Query()
+// runs "SELECT * FROM t WHERE id = @p1" with sql.Named("p1", 1).
+type NamedFormat byte
+
+const (
+	// AtNamed renders named placeholders like @name, as used by SQL Server.
+	AtNamed NamedFormat = '@'
+
+	// ColonNamed renders named placeholders like :name, as used by Oracle.
+	ColonNamed NamedFormat = ':'
+)
+
+// ToSqlNamed builds s into a SQL string with named placeholders and
+// returns the args as sql.NamedArg.
+//
+// Args passed as sql.Named keep their name, so the same value can be reused
+// in several places of the query. All other args are named p1, p2, ... by
+// their position. s must use the Question placeholder format.
+//
+// Ex:
+//     ToSqlNamed(Select("*").From("t").Where(Or{Eq{"a": sql.Named("v", 1)}, Eq{"b": sql.Named("v", 1)}}), AtNamed)
+//     == "SELECT * FROM t WHERE (a = @v OR b = @v)", [{v 1}]
+func ToSqlNamed(s Sqlizer, f NamedFormat) (string, []sql.NamedArg, error) {
+	query, args, err := s.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return f.replaceNamed(query, args)
+}
+
+// ReplacePlaceholders replaces the ? placeholders of sql with placeholders
+// named p1, p2, ... by their position.
+func (f NamedFormat) ReplacePlaceholders(sql string) (string, error) {
+	return replacePlaceholders(sql, func(buf *bytes.Buffer, i int) error {
+		buf.WriteByte(byte(f))
+		buf.WriteString("p")
+		buf.WriteString(strconv.Itoa(i))
+		return nil
+	})
+}
+
+// replacePlaceholdersArgs makes NamedFormat usable as the PlaceholderFormat of
+// builders: Exec and Query pass the args as sql.NamedArg to the driver.
+func (f NamedFormat) replacePlaceholdersArgs(sql string, args []interface{}) (string, []interface{}, error) {
+	query, namedArgs, err := f.replaceNamed(sql, args)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var values []interface{}
+	if len(namedArgs) > 0 {
+		values = make([]interface{}, len(namedArgs))
+		for i, arg := range namedArgs {
+			values[i] = arg
+		}
+	}
+	return query, values, nil
+}
+
+func (f NamedFormat) replaceNamed(query string, args []interface{}) (string, []sql.NamedArg, error) {
+	var namedArgs []sql.NamedArg
+	seen := make(map[string]interface{})
+	query, err := replacePlaceholders(query, func(buf *bytes.Buffer, i int) error {
+		if i > len(args) {
+			return fmt.Errorf("placeholder %d has no arg, only %d given", i, len(args))
+		}
+
+		named, ok := args[i-1].(sql.NamedArg)
+		if !ok {
+			named = sql.Named("p"+strconv.Itoa(i), args[i-1])
+		}
+
+		if value, ok := seen[named.Name]; ok {
+			if !reflect.DeepEqual(value, named.Value) {
+				return fmt.Errorf("named arg %s is bound to different values", named.Name)
+			}
+		} else {
+			seen[named.Name] = named.Value
+			namedArgs = append(namedArgs, named)
+		}
+
+		buf.WriteByte(byte(f))
+		buf.WriteString(named.Name)
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, namedArgs, nil
+}
@@ -0,0 +1,76 @@
+package sqrl
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSqlNamed(t *testing.T) {
+	b := Select("*").
+		From("users").
+		Where(Or{Eq{"owner_id": sql.Named("user", 5)}, Eq{"author_id": sql.Named("user", 5)}}).
+		Where("state = ?", "active")
+
+	query, args, err := ToSqlNamed(b, AtNamed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SELECT * FROM users WHERE (owner_id = @user OR author_id = @user) AND state = @p3", query)
+	assert.Equal(t, []sql.NamedArg{sql.Named("user", 5), sql.Named("p3", "active")}, args)
+
+	query, _, err = ToSqlNamed(Expr("data ??| ? AND id = ?", "a", 1), ColonNamed)
+	assert.NoError(t, err)
+	assert.Equal(t, "data ?| :p1 AND id = :p2", query)
+}
+
+func TestToSqlNamedConflict(t *testing.T) {
+	b := Expr("a = ? AND b = ?", sql.Named("v", 1), sql.Named("v", 2))
+	_, _, err := ToSqlNamed(b, AtNamed)
+	assert.Error(t, err)
+}
+
+func TestBuilderToSqlNamed(t *testing.T) {
+	b := StatementBuilder.PlaceholderFormat(Dollar).
+		Update("users").
+		Set("name", "Joe").
+		Where(Eq{"id": 1})
+
+	query, args, err := b.ToSqlNamed(AtNamed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "UPDATE users SET name = @p1 WHERE id = @p2", query)
+	assert.Equal(t, []sql.NamedArg{sql.Named("p1", "Joe"), sql.Named("p2", 1)}, args)
+
+	query, _, _ = b.ToSql()
+	assert.Equal(t, "UPDATE users SET name = $1 WHERE id = $2", query)
+}
+
+func TestNamedPlaceholderFormat(t *testing.T) {
+	query, err := AtNamed.ReplacePlaceholders("a = ? AND b = ?")
+	assert.NoError(t, err)
+	assert.Equal(t, "a = @p1 AND b = @p2", query)
+
+	b := Select("*").From("users").Where(Eq{"id": 1}).PlaceholderFormat(ColonNamed)
+	query, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = :p1", query)
+	assert.Equal(t, []interface{}{sql.Named("p1", 1)}, args)
+}
+
+func TestNamedPlaceholderFormatExec(t *testing.T) {
+	db := &DBStub{}
+	StatementBuilder.PlaceholderFormat(AtNamed).RunWith(db).
+		Update("users").Set("name", sql.Named("name", "Joe")).Where(Eq{"id": 1}).Exec()
+	assert.Equal(t, "UPDATE users SET name = @name WHERE id = @p2", db.LastExecSql)
+	assert.Equal(t, []interface{}{sql.Named("name", "Joe"), sql.Named("p2", 1)}, db.LastExecArgs)
+
+	d := &stubDriver{}
+	sqlDB := openStubDB(d)
+	defer sqlDB.Close()
+
+	_, err := Delete("users").Where(Eq{"id": 1}).PlaceholderFormat(AtNamed).RunWith(sqlDB).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.NamedValue{{Name: "p1", Ordinal: 1, Value: int64(1)}}, d.Args())
+}
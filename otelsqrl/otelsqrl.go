@@ -0,0 +1,207 @@
+// Package otelsqrl instruments sqrl queries with OpenTelemetry tracing.
+//
+// Wrap a runner and pass it to RunWith to create a span for every query:
+//
+//     runner := otelsqrl.New(db)
+//     rows, err := sqrl.Select("*").From("users").RunWith(runner).QueryContext(ctx)
+//
+// The statement is recorded with its literals replaced by "?", bound args are
+// never recorded.
+package otelsqrl
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/rubenhazelaar/sqrl"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/rubenhazelaar/sqrl/otelsqrl"
+
+// Attribute keys set on the spans.
+const (
+	StatementKey    = attribute.Key("db.statement")
+	OperationKey    = attribute.Key("db.operation")
+	RowsAffectedKey = attribute.Key("db.rows_affected")
+)
+
+// Option configures a Runner.
+type Option func(*Runner)
+
+// WithTracerProvider sets the TracerProvider spans are created with. The
+// global TracerProvider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(r *Runner) {
+		r.tracer = tp.Tracer(instrumentationName)
+	}
+}
+
+// WithAttributes adds attributes to every span, e.g. db.system.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return func(r *Runner) {
+		r.attrs = append(r.attrs, attrs...)
+	}
+}
+
+// Runner wraps a sqrl.BaseRunnerContext and creates a span for every query.
+// It implements sqrl.QueryRowerContext if the wrapped runner does, or
+// returns *sql.Row from QueryRowContext like *sql.DB, *sql.Tx and *sql.Conn.
+type Runner struct {
+	runner sqrl.BaseRunnerContext
+	tracer trace.Tracer
+	attrs  []attribute.KeyValue
+}
+
+// New returns a Runner tracing the queries run with runner.
+func New(runner sqrl.BaseRunnerContext, opts ...Option) *Runner {
+	r := &Runner{runner: runner}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.tracer == nil {
+		r.tracer = otel.Tracer(instrumentationName)
+	}
+	return r
+}
+
+// ExecContext executes query with the wrapped runner. The number of affected
+// rows is recorded if the driver reports it.
+func (r *Runner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := r.start(ctx, query)
+	defer span.End()
+
+	res, err := r.runner.ExecContext(ctx, query, args...)
+	if err != nil {
+		recordError(span, err)
+		return res, err
+	}
+	if n, err := res.RowsAffected(); err == nil {
+		span.SetAttributes(RowsAffectedKey.Int64(n))
+	}
+	return res, nil
+}
+
+// QueryContext queries query with the wrapped runner.
+func (r *Runner) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := r.start(ctx, query)
+	defer span.End()
+
+	rows, err := r.runner.QueryContext(ctx, query, args...)
+	if err != nil {
+		recordError(span, err)
+	}
+	return rows, err
+}
+
+// QueryRowContext queries a single row with the wrapped runner.
+func (r *Runner) QueryRowContext(ctx context.Context, query string, args ...interface{}) sqrl.RowScanner {
+	ctx, span := r.start(ctx, query)
+	defer span.End()
+
+	switch q := r.runner.(type) {
+	case sqrl.QueryRowerContext:
+		return q.QueryRowContext(ctx, query, args...)
+	case sqlQueryRowerContext:
+		return q.QueryRowContext(ctx, query, args...)
+	}
+	recordError(span, sqrl.ErrRunnerNotQueryRunnerContext)
+	return &errRow{sqrl.ErrRunnerNotQueryRunnerContext}
+}
+
+type sqlQueryRowerContext interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (r *Runner) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	op := Operation(query)
+	name := op
+	if name == "" {
+		name = "query"
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(r.attrs)+2)
+	attrs = append(attrs, r.attrs...)
+	attrs = append(attrs, StatementKey.String(Sanitize(query)))
+	if op != "" {
+		attrs = append(attrs, OperationKey.String(op))
+	}
+
+	return r.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+func recordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+type errRow struct {
+	err error
+}
+
+func (r *errRow) Scan(...interface{}) error {
+	return r.err
+}
+
+// Operation returns the leading keyword of query in upper case, e.g.
+// "SELECT" or "INSERT". Queries starting with WITH return the keyword of the
+// main statement when it can be found.
+func Operation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	op := strings.ToUpper(fields[0])
+	if op != "WITH" {
+		return op
+	}
+
+	// Find the first statement keyword outside of the CTE parentheses.
+	depth := 0
+	start := -1
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && isWordByte(c) && (i == 0 || !isWordByte(query[i-1])):
+			start = i
+		}
+		if start >= 0 {
+			end := start
+			for end < len(query) && isWordByte(query[end]) {
+				end++
+			}
+			switch w := strings.ToUpper(query[start:end]); w {
+			case "SELECT", "INSERT", "UPDATE", "DELETE":
+				return w
+			}
+			i = end - 1
+			start = -1
+		}
+	}
+	return op
+}
+
+// Sanitize replaces string and numeric literals in query with "?", so
+// values inlined into the statement aren't recorded. Placeholders are
+// replaced as well, and lists of them collapsed, see sqrl.NormalizeSQL.
+func Sanitize(query string) string {
+	return sqrl.NormalizeSQL(query)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,83 @@
+package otelsqrl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type runnerStub struct {
+	err error
+}
+
+type resultStub int64
+
+func (r resultStub) LastInsertId() (int64, error) { return 0, nil }
+func (r resultStub) RowsAffected() (int64, error) { return int64(r), nil }
+
+func (s *runnerStub) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return resultStub(3), s.err
+}
+
+func (s *runnerStub) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, s.err
+}
+
+func newRecorder() (*tracetest.SpanRecorder, Option) {
+	sr := tracetest.NewSpanRecorder()
+	return sr, WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+}
+
+func TestRunnerExec(t *testing.T) {
+	sr, opt := newRecorder()
+	r := New(&runnerStub{}, opt)
+
+	_, err := sqrl.Update("users").Set("name", "Joe").Where("id = 1").RunWith(r).Exec()
+	assert.NoError(t, err)
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "UPDATE", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), StatementKey.String("UPDATE users SET name = ? WHERE id = ?"))
+	assert.Contains(t, spans[0].Attributes(), OperationKey.String("UPDATE"))
+	assert.Contains(t, spans[0].Attributes(), RowsAffectedKey.Int64(3))
+}
+
+func TestRunnerQueryErr(t *testing.T) {
+	sr, opt := newRecorder()
+	r := New(&runnerStub{err: errors.New("boom")}, opt)
+
+	_, err := sqrl.Select("id").From("users").RunWith(r).Query()
+	assert.Error(t, err)
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "SELECT", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestRunnerQueryRowNotSupported(t *testing.T) {
+	_, opt := newRecorder()
+	r := New(&runnerStub{}, opt)
+
+	err := sqrl.Select("id").From("users").RunWith(r).Scan()
+	assert.Equal(t, sqrl.ErrRunnerNotQueryRunnerContext, err)
+}
+
+func TestSanitize(t *testing.T) {
+	sql := Sanitize("SELECT * FROM t1 WHERE a = 'it''s' AND b = 12.5 AND c IN ($1, $2) AND d = ? AND e = :3 LIMIT 10")
+	assert.Equal(t, "SELECT * FROM t1 WHERE a = ? AND b = ? AND c IN (?) AND d = ? AND e = ? LIMIT ?", sql)
+}
+
+func TestOperation(t *testing.T) {
+	assert.Equal(t, "SELECT", Operation("  select 1"))
+	assert.Equal(t, "UPDATE", Operation("WITH x AS (SELECT 1) UPDATE t SET a = 1"))
+	assert.Equal(t, "", Operation(""))
+}
@@ -1,9 +1,6 @@
 package sqrl
 
-import (
-	"fmt"
-	"io"
-)
+import "fmt"
 
 type part struct {
 	pred interface{}
@@ -29,29 +26,119 @@ func (p part) ToSql() (sql string, args []interface{}, err error) {
 	return
 }
 
-func appendToSql(parts []Sqlizer, w io.Writer, sep string, args []interface{}) ([]interface{}, error) {
-	count := 0
-	for _, p := range parts {
-		partSql, partArgs, err := p.ToSql()
+func (p part) appendPart(w sqlWriter, sep string, args []interface{}) ([]interface{}, bool, error) {
+	switch pred := p.pred.(type) {
+	case nil:
+		return args, false, nil
+	case Sqlizer:
+		return writePart(w, sep, pred, args)
+	case string:
+		if pred == "" {
+			return args, false, nil
+		}
+		w.WriteString(sep)
+		w.WriteString(pred)
+		return append(args, p.args...), true, nil
+	}
+	return nil, false, fmt.Errorf("expected string or Sqlizer, not %T", p.pred)
+}
+
+// partAppender is implemented by parts which write their SQL straight into
+// the buffer of the enclosing query rather than returning it from ToSql.
+// appendPart writes sep before the SQL, unless it is empty, and reports
+// whether anything was written.
+type partAppender interface {
+	appendPart(w sqlWriter, sep string, args []interface{}) ([]interface{}, bool, error)
+}
+
+// writePart writes the SQL of s, preceded by sep, to w unless it is empty.
+func writePart(w sqlWriter, sep string, s Sqlizer, args []interface{}) ([]interface{}, bool, error) {
+	if q, ok := w.(*queryBuffer); ok {
+		s = withDialect(s, q.d)
+	}
+	switch s := s.(type) {
+	case partAppender:
+		return s.appendPart(w, sep, args)
+	case SqlAppender:
+		w.WriteString(sep)
+		args, err := s.AppendToSql(w, args)
 		if err != nil {
-			return nil, err
-		} else if len(partSql) == 0 {
-			continue
+			return nil, false, err
 		}
+		return args, true, nil
+	}
 
-		if count > 0 {
-			_, err := io.WriteString(w, sep)
-			if err != nil {
-				return nil, err
-			}
+	sql, sargs, err := s.ToSql()
+	if err != nil {
+		return nil, false, err
+	} else if sql == "" {
+		return args, false, nil
+	}
+	w.WriteString(sep)
+	w.WriteString(sql)
+	return append(args, sargs...), true, nil
+}
+
+func appendToSql(parts []Sqlizer, w sqlWriter, sep string, args []interface{}) ([]interface{}, error) {
+	written := false
+	for _, p := range parts {
+		partSep := sep
+		if !written {
+			partSep = ""
 		}
 
-		_, err = io.WriteString(w, partSql)
+		var ok bool
+		var err error
+		args, ok, err = writePart(w, partSep, p, args)
 		if err != nil {
 			return nil, err
 		}
-		args = append(args, partArgs...)
-		count++
+		written = written || ok
 	}
 	return args, nil
 }
+
+// countArgs estimates the number of args of parts, to preallocate the args
+// of a query. Parts with a Sqlizer predicate count as one arg.
+func countArgs(parts ...[]Sqlizer) int {
+	n := 0
+	for _, ps := range parts {
+		for _, p := range ps {
+			switch p := p.(type) {
+			case *part:
+				n += partArgs(p.pred, p.args)
+			case *wherePart:
+				n += partArgs(p.pred, p.args)
+			case expr:
+				n += len(p.args)
+			default:
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func partArgs(pred interface{}, args []interface{}) int {
+	if _, ok := pred.(string); ok {
+		return len(args)
+	}
+	return 1
+}
+
+// countExprArgs returns the number of args of es.
+func countExprArgs(es exprs) int {
+	n := 0
+	for _, e := range es {
+		n += len(e.args)
+	}
+	return n
+}
+
+// makeArgs returns an empty args slice with capacity n, or nil if n is 0.
+func makeArgs(n int) []interface{} {
+	if n == 0 {
+		return nil
+	}
+	return make([]interface{}, 0, n)
+}
@@ -0,0 +1,80 @@
+package pg
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// arrayTypes are the Postgres types of array elements by kind.
+var arrayTypes = map[reflect.Kind]string{
+	reflect.Uint8:   "smallint",
+	reflect.Uint16:  "integer",
+	reflect.Uint32:  "bigint",
+	reflect.Uint:    "bigint",
+	reflect.Uint64:  "bigint",
+	reflect.Int8:    "smallint",
+	reflect.Int16:   "smallint",
+	reflect.Int32:   "integer",
+	reflect.Int:     "bigint",
+	reflect.Int64:   "bigint",
+	reflect.Float32: "real",
+	reflect.Float64: "double precision",
+	reflect.String:  "text",
+	reflect.Bool:    "boolean",
+}
+
+// Any checks whether column equals any element of slice, binding slice as
+// a single array instead of expanding it into an IN list like Eq does. The
+// query has the same placeholders whatever the length of slice, so prepared
+// statements can be reused.
+//
+// Valid slices are those Array accepts. The array is cast to the Postgres
+// type of its elements, e.g. bigint[] for []int.
+// Ex:
+//     .Where(pg.Any("id", []int{1, 2, 3})) == "id = ANY(?::bigint[])"
+func Any(column string, slice interface{}) sqrl.Sqlizer {
+	return anyOp{column: column, slice: slice}
+}
+
+type anyOp struct {
+	column string
+	slice  interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (a anyOp) ToSql() (string, []interface{}, error) {
+	_, args, err := Array(a.slice).ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+
+	tpe, err := arrayType(reflect.TypeOf(a.slice))
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("%s = ANY(?::%s[])", a.column, tpe), args, nil
+}
+
+// arrayType returns the Postgres type of the elements of arrays of type t.
+func arrayType(t reflect.Type) (string, error) {
+	t = t.Elem()
+	for elemMarshaler(t) == nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return "timestamptz", nil
+	case isUUID(t):
+		return "uuid", nil
+	}
+	if tpe, ok := arrayTypes[t.Kind()]; ok {
+		return tpe, nil
+	}
+	return "", fmt.Errorf("Expected element of type %s, got: %s", validElems, t.Kind())
+}
@@ -0,0 +1,63 @@
+package pg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidAny(t *testing.T) {
+	valid := []struct {
+		op    sqrl.Sqlizer
+		sql   string
+		value string
+	}{
+		{pg.Any("id", []int{}), "id = ANY(?::bigint[])", "{}"},
+		{pg.Any("id", []int{1, 2, 3}), "id = ANY(?::bigint[])", "{1,2,3}"},
+		{pg.Any("id", []int32{1}), "id = ANY(?::integer[])", "{1}"},
+		{pg.Any("score", []float64{1.5}), "score = ANY(?::double precision[])", "{1.5}"},
+		{pg.Any("name", []string{"foo", "bar"}), `name = ANY(?::text[])`, `{"foo","bar"}`},
+		{pg.Any("name", []*string{nil}), `name = ANY(?::text[])`, `{NULL}`},
+		{pg.Any("active", []bool{true}), "active = ANY(?::boolean[])", "{true}"},
+		{pg.Any("at", []time.Time{}), "at = ANY(?::timestamptz[])", "{}"},
+		{pg.Any("id", []uuid{}), "id = ANY(?::uuid[])", "{}"},
+		{pg.Any("id", [][]int{{1}, {2}}), "id = ANY(?::bigint[])", "{{1},{2}}"},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, []interface{}{test.value}, args)
+	}
+}
+
+func TestInvalidAny(t *testing.T) {
+	invalid := []sqrl.Sqlizer{
+		pg.Any("id", 42),
+		pg.Any("id", []interface{}{1}),
+		pg.Any("id", []struct{}{}),
+	}
+
+	for _, test := range invalid {
+		_, _, err := test.ToSql()
+		assert.NotNil(t, err, "Expected error at case %+v", test)
+	}
+}
+
+func TestAnyPlaceholders(t *testing.T) {
+	for _, ids := range [][]int{{1}, {1, 2, 3}} {
+		sql, args, err := sqrl.Select("name").From("users").
+			Where(pg.Any("id", ids)).
+			PlaceholderFormat(sqrl.Dollar).
+			ToSql()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT name FROM users WHERE id = ANY($1::bigint[])", sql)
+		assert.Len(t, args, 1)
+	}
+}
@@ -2,10 +2,13 @@ package pg
 
 import (
 	"bytes"
+	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rubenhazelaar/sqrl"
 )
@@ -13,8 +16,10 @@ import (
 // Array converts value into Postgres Array
 //
 // Valid values are slices or arrays of arbitrary depth
-// with elements of type string, int, uint and float elements of any bit size
-// Example: []int, [][]uint16, [2][2]int, []string
+// with elements of type string, bool, int, uint and float elements of any bit size,
+// time.Time, rendered as timestamptz, and UUIDs, i.e. [16]byte like uuid.UUID,
+// or pointers to them, with nil pointers rendered as NULL
+// Example: []int, [][]uint16, [2][2]int, []string, []*string, []time.Time, []uuid.UUID
 func Array(arr interface{}) sqrl.Sqlizer {
 	return array{arr}
 }
@@ -46,9 +51,29 @@ var marshalers = map[reflect.Kind]marshaler{
 	reflect.Float32: marshalFloat,
 	reflect.Float64: marshalFloat,
 	reflect.String:  marshalString,
+	reflect.Bool:    marshalBool,
 }
 
-var validElems = makeValidElems(marshalers)
+var validElems = makeValidElems(marshalers) + ", time.Time, [16]byte"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// isUUID returns whether elements of type t are UUIDs, e.g. uuid.UUID.
+func isUUID(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
+}
+
+// elemMarshaler returns the marshaler of array elements of type t, or nil if
+// they are arrays themselves.
+func elemMarshaler(t reflect.Type) marshaler {
+	switch {
+	case t == timeType:
+		return marshalTime
+	case isUUID(t):
+		return marshalUUID
+	}
+	return marshalers[t.Kind()]
+}
 
 type array struct {
 	value interface{}
@@ -61,13 +86,16 @@ func checkArrayType(src interface{}) error {
 		return fmt.Errorf("Expected value of type slice or array, got %s", k)
 	}
 
-	for k == reflect.Slice || k == reflect.Array {
+	t = t.Elem()
+	for elemMarshaler(t) == nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
-		k = t.Kind()
 	}
 
-	if _, ok := marshalers[k]; !ok {
-		return fmt.Errorf("Expected element of type %s, got: %s", validElems, k)
+	if elemMarshaler(t) == nil {
+		return fmt.Errorf("Expected element of type %s, got: %s", validElems, t.Kind())
 	}
 	return nil
 }
@@ -79,9 +107,11 @@ func marshalArray(v reflect.Value, buf *bytes.Buffer) {
 		return
 	}
 
-	k := v.Type().Elem().Kind()
-	marshalElem, ok := marshalers[k]
-	if !ok {
+	marshalElem := elemMarshaler(v.Type().Elem())
+	switch {
+	case v.Type().Elem().Kind() == reflect.Ptr:
+		marshalElem = marshalPtr(elemMarshaler(v.Type().Elem().Elem()))
+	case marshalElem == nil:
 		marshalElem = marshalArray
 	}
 
@@ -106,8 +136,45 @@ func marshalFloat(v reflect.Value, buf *bytes.Buffer) {
 	buf.WriteString(strconv.FormatFloat(v.Float(), 'f', -1, 64))
 }
 
+// marshalString quotes the string, escaping only quotes and backslashes as
+// Postgres arrays expect.
 func marshalString(v reflect.Value, buf *bytes.Buffer) {
-	buf.WriteString(strconv.Quote(v.String()))
+	s := v.String()
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(s[i])
+	}
+	buf.WriteByte('"')
+}
+
+// marshalPtr returns a marshaler of pointers to elements marshalled by
+// marshalElem, rendering nil pointers as NULL.
+func marshalPtr(marshalElem marshaler) marshaler {
+	return func(v reflect.Value, buf *bytes.Buffer) {
+		if v.IsNil() {
+			buf.WriteString("NULL")
+			return
+		}
+		marshalElem(v.Elem(), buf)
+	}
+}
+
+func marshalBool(v reflect.Value, buf *bytes.Buffer) {
+	buf.WriteString(strconv.FormatBool(v.Bool()))
+}
+
+func marshalTime(v reflect.Value, buf *bytes.Buffer) {
+	t := v.Interface().(time.Time)
+	buf.WriteString(`"` + t.Format(time.RFC3339Nano) + `"`)
+}
+
+func marshalUUID(v reflect.Value, buf *bytes.Buffer) {
+	var u [16]byte
+	reflect.Copy(reflect.ValueOf(u[:]), v)
+	fmt.Fprintf(buf, "%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
 }
 
 func makeValidElems(m map[reflect.Kind]marshaler) string {
@@ -118,3 +185,359 @@ func makeValidElems(m map[reflect.Kind]marshaler) string {
 
 	return strings.Join(s, ", ")
 }
+
+// ArrayScanner returns a sql.Scanner which parses a Postgres array into the
+// slice or array dest points to.
+//
+// Valid destinations are pointers to slices or arrays of arbitrary depth
+// with elements of the types Array accepts, or pointers to them for arrays
+// with NULL elements. A NULL array sets a slice to nil.
+// Example:
+//     var tags []string
+//     err := db.QueryRow("SELECT tags FROM posts WHERE id = $1", id).Scan(pg.ArrayScanner(&tags))
+func ArrayScanner(dest interface{}) sql.Scanner {
+	return arrayScanner{dest}
+}
+
+type arrayScanner struct {
+	dest interface{}
+}
+
+// Scan implements sql.Scanner.
+func (a arrayScanner) Scan(src interface{}) error {
+	v := reflect.ValueOf(a.dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("Expected pointer to slice or array, got %T", a.dest)
+	}
+	v = v.Elem()
+	if err := checkScanType(v.Type()); err != nil {
+		return err
+	}
+
+	var text string
+	switch src := src.(type) {
+	case nil:
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	case []byte:
+		text = string(src)
+	case string:
+		text = src
+	default:
+		return fmt.Errorf("Cannot scan %T into Postgres array", src)
+	}
+
+	elem, err := parseArray(text)
+	if err != nil {
+		return err
+	}
+	return unmarshalArray(elem, v)
+}
+
+func checkScanType(t reflect.Type) error {
+	k := t.Kind()
+	if k != reflect.Slice && k != reflect.Array {
+		return fmt.Errorf("Expected value of type slice or array, got %s", k)
+	}
+
+	t = t.Elem()
+	for elemUnmarshaler(t) == nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if elemUnmarshaler(t) == nil {
+		return fmt.Errorf("Expected element of type %s, got: %s", validElems, t.Kind())
+	}
+	return nil
+}
+
+// arrayElem is an element of a parsed Postgres array, which is an array
+// itself if elems isn't nil.
+type arrayElem struct {
+	text  string
+	null  bool
+	elems []arrayElem
+}
+
+// parseArray parses the text format of Postgres arrays, e.g.
+// {{1,2},{NULL,"quoted \"4\""}}.
+func parseArray(s string) (arrayElem, error) {
+	// skip dimension decorations, e.g. [0:1]={1,2}
+	if strings.HasPrefix(s, "[") {
+		if i := strings.Index(s, "="); i >= 0 {
+			s = s[i+1:]
+		}
+	}
+
+	p := &arrayParser{s: s, kind: "array"}
+	elem, err := p.parseArray()
+	if err != nil {
+		return elem, err
+	}
+	if p.pos != len(p.s) {
+		return elem, fmt.Errorf("Invalid Postgres array %q: unexpected %q", s, p.s[p.pos:])
+	}
+	return elem, nil
+}
+
+type arrayParser struct {
+	s    string
+	pos  int
+	kind string
+}
+
+func (p *arrayParser) errorf(msg string) error {
+	return fmt.Errorf("Invalid Postgres %s %q at %d: %s", p.kind, p.s, p.pos, msg)
+}
+
+func (p *arrayParser) parseArray() (arrayElem, error) {
+	if p.pos >= len(p.s) || p.s[p.pos] != '{' {
+		return arrayElem{}, p.errorf("expected {")
+	}
+	p.pos++
+
+	elem := arrayElem{elems: []arrayElem{}}
+	if p.pos < len(p.s) && p.s[p.pos] == '}' {
+		p.pos++
+		return elem, nil
+	}
+
+	for {
+		var (
+			e   arrayElem
+			err error
+		)
+		p.skipSpace()
+		switch {
+		case p.pos >= len(p.s):
+			return elem, p.errorf("unexpected end")
+		case p.s[p.pos] == '{':
+			e, err = p.parseArray()
+		case p.s[p.pos] == '"':
+			e, err = p.parseQuoted()
+		default:
+			e, err = p.parseUnquoted()
+		}
+		if err != nil {
+			return elem, err
+		}
+		elem.elems = append(elem.elems, e)
+
+		p.skipSpace()
+		if p.pos >= len(p.s) {
+			return elem, p.errorf("unexpected end")
+		}
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return elem, nil
+		default:
+			return elem, p.errorf("expected , or }")
+		}
+	}
+}
+
+func (p *arrayParser) parseQuoted() (arrayElem, error) {
+	var buf strings.Builder
+	for p.pos++; p.pos < len(p.s); p.pos++ {
+		switch c := p.s[p.pos]; c {
+		case '\\':
+			p.pos++
+			if p.pos < len(p.s) {
+				buf.WriteByte(p.s[p.pos])
+			}
+		case '"':
+			p.pos++
+			return arrayElem{text: buf.String()}, nil
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return arrayElem{}, p.errorf("unterminated quoted element")
+}
+
+func (p *arrayParser) parseUnquoted() (arrayElem, error) {
+	var buf strings.Builder
+	for ; p.pos < len(p.s); p.pos++ {
+		c := p.s[p.pos]
+		if c == ',' || c == '}' {
+			break
+		}
+		if c == '\\' {
+			p.pos++
+			if p.pos >= len(p.s) {
+				break
+			}
+			c = p.s[p.pos]
+		}
+		buf.WriteByte(c)
+	}
+
+	text := strings.TrimSpace(buf.String())
+	if text == "" {
+		return arrayElem{}, p.errorf("empty element")
+	}
+	return arrayElem{text: text, null: strings.EqualFold(text, "NULL")}, nil
+}
+
+func (p *arrayParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+type unmarshaler func(string, reflect.Value) error
+
+var unmarshalers = map[reflect.Kind]unmarshaler{
+	reflect.Uint:    unmarshalUint,
+	reflect.Uint8:   unmarshalUint,
+	reflect.Uint16:  unmarshalUint,
+	reflect.Uint32:  unmarshalUint,
+	reflect.Uint64:  unmarshalUint,
+	reflect.Int:     unmarshalInt,
+	reflect.Int8:    unmarshalInt,
+	reflect.Int16:   unmarshalInt,
+	reflect.Int32:   unmarshalInt,
+	reflect.Int64:   unmarshalInt,
+	reflect.Float32: unmarshalFloat,
+	reflect.Float64: unmarshalFloat,
+	reflect.String:  unmarshalString,
+	reflect.Bool:    unmarshalBool,
+}
+
+// elemUnmarshaler returns the unmarshaler of array elements of type t, or
+// nil if they are arrays themselves.
+func elemUnmarshaler(t reflect.Type) unmarshaler {
+	switch {
+	case t == timeType:
+		return unmarshalTime
+	case isUUID(t):
+		return unmarshalUUID
+	}
+	return unmarshalers[t.Kind()]
+}
+
+func unmarshalArray(elem arrayElem, v reflect.Value) error {
+	if elem.elems == nil {
+		return fmt.Errorf("Expected array, got %q", elem.text)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), len(elem.elems), len(elem.elems)))
+	case reflect.Array:
+		if v.Len() != len(elem.elems) {
+			return fmt.Errorf("Cannot scan array of length %d into %s", len(elem.elems), v.Type())
+		}
+	}
+
+	for i, e := range elem.elems {
+		if err := unmarshalElem(e, v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unmarshalElem(elem arrayElem, v reflect.Value) error {
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	unmarshal := elemUnmarshaler(t)
+	if unmarshal == nil {
+		return unmarshalArray(elem, v)
+	}
+	if elem.elems != nil {
+		return fmt.Errorf("Cannot scan nested array into %s", v.Type())
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if elem.null {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	} else if elem.null {
+		return fmt.Errorf("Cannot scan NULL into %s", v.Type())
+	}
+
+	return unmarshal(elem.text, v)
+}
+
+func unmarshalInt(s string, v reflect.Value) error {
+	i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+	if err != nil {
+		return err
+	}
+	v.SetInt(i)
+	return nil
+}
+
+func unmarshalUint(s string, v reflect.Value) error {
+	i, err := strconv.ParseUint(s, 10, v.Type().Bits())
+	if err != nil {
+		return err
+	}
+	v.SetUint(i)
+	return nil
+}
+
+func unmarshalFloat(s string, v reflect.Value) error {
+	f, err := strconv.ParseFloat(s, v.Type().Bits())
+	if err != nil {
+		return err
+	}
+	v.SetFloat(f)
+	return nil
+}
+
+func unmarshalString(s string, v reflect.Value) error {
+	v.SetString(s)
+	return nil
+}
+
+func unmarshalBool(s string, v reflect.Value) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	v.SetBool(b)
+	return nil
+}
+
+// timeLayouts are the layouts of timestamptz, timestamp and date elements
+// in Postgres output and of marshalled time.Time elements.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	time.RFC3339Nano,
+}
+
+func unmarshalTime(s string, v reflect.Value) error {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			v.Set(reflect.ValueOf(t))
+			return nil
+		}
+	}
+	return fmt.Errorf("Cannot parse %q as time", s)
+}
+
+func unmarshalUUID(s string, v reflect.Value) error {
+	b, err := hex.DecodeString(strings.Replace(strings.Trim(s, "{}"), "-", "", -1))
+	if err != nil || len(b) != 16 {
+		return fmt.Errorf("Cannot parse %q as UUID", s)
+	}
+	reflect.Copy(v, reflect.ValueOf(b))
+	return nil
+}
@@ -3,6 +3,7 @@ package pg_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/rubenhazelaar/sqrl"
 	"github.com/rubenhazelaar/sqrl/pg"
@@ -27,6 +28,18 @@ func TestValidArray(t *testing.T) {
 		{pg.Array([2][2]int{{1, 2}, {3, 4}}), "?", `{{1,2},{3,4}}`},
 		{pg.Array([]float32{1.5, 2, 3}), "?", `{1.5,2,3}`},
 		{pg.Array([]float64{1.5, 2, 3}), "?", `{1.5,2,3}`},
+		{pg.Array([]int64{1, -2}), "?", `{1,-2}`},
+		{pg.Array([]int32{1, -2}), "?", `{1,-2}`},
+		{pg.Array([]bool{true, false}), "?", `{true,false}`},
+		{pg.Array([][]bool{{true}, {false}}), "?", `{{true},{false}}`},
+		{pg.Array([]time.Time{time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)}), "?", `{"2020-01-02T03:04:05.000006Z"}`},
+		{pg.Array([][16]byte{testUUID}), "?", `{0102e4f5-0000-4000-8000-00000000002a}`},
+		{pg.Array([]uuid{uuid(testUUID)}), "?", `{0102e4f5-0000-4000-8000-00000000002a}`},
+		{pg.Array([16]byte{1, 2}), "?", `{1,2,0,0,0,0,0,0,0,0,0,0,0,0,0,0}`},
+		{pg.Array([]string{`he said "hi"`, `C:\dir`, "new\nline", "é"}), "?", `{"he said \"hi\"","C:\\dir","new` + "\n" + `line","é"}`},
+		{pg.Array([]*string{&foo, nil}), "?", `{"foo",NULL}`},
+		{pg.Array([][]*int{{&answer}, {nil}}), "?", `{{42},{NULL}}`},
+		{pg.Array([]*[16]byte{nil}), "?", `{NULL}`},
 	}
 
 	for _, test := range valid {
@@ -38,6 +51,15 @@ func TestValidArray(t *testing.T) {
 	}
 }
 
+type uuid [16]byte
+
+var (
+	foo    = "foo"
+	answer = 42
+)
+
+var testUUID = [16]byte{0x01, 0x02, 0xe4, 0xf5, 0, 0, 0x40, 0, 0x80, 0, 0, 0, 0, 0, 0, 0x2a}
+
 func TestInvalidArray(t *testing.T) {
 	invalid := []sqrl.Sqlizer{
 		pg.Array([]struct{}{{}}),
@@ -46,6 +68,9 @@ func TestInvalidArray(t *testing.T) {
 		pg.Array([]interface{}{6, 7, "foo"}),
 		pg.Array([][]interface{}{}),
 		pg.Array([][]interface{}{{1}}),
+		pg.Array([]struct{ time.Time }{}),
+		pg.Array([]*[]int{}),
+		pg.Array([]**int{}),
 	}
 
 	for _, test := range invalid {
@@ -72,3 +97,110 @@ func ExampleArray() {
 	// INSERT INTO posts (content,tags) VALUES ($1,$2)
 	// [Lorem Ipsum {"foo","bar"}]
 }
+
+func TestArrayScanner(t *testing.T) {
+	var strs []string
+	assert.NoError(t, pg.ArrayScanner(&strs).Scan([]byte(`{foo,"bar baz","\"quoted\"","NULL",""}`)))
+	assert.Equal(t, []string{"foo", "bar baz", `"quoted"`, "NULL", ""}, strs)
+
+	var ints [][]int
+	assert.NoError(t, pg.ArrayScanner(&ints).Scan(`{{1,2},{3,-4}}`))
+	assert.Equal(t, [][]int{{1, 2}, {3, -4}}, ints)
+
+	var fixed [2]uint8
+	assert.NoError(t, pg.ArrayScanner(&fixed).Scan(`[1:2]={6,42}`))
+	assert.Equal(t, [2]uint8{6, 42}, fixed)
+
+	var floats []float64
+	assert.NoError(t, pg.ArrayScanner(&floats).Scan(`{1.5, 2 ,3}`))
+	assert.Equal(t, []float64{1.5, 2, 3}, floats)
+
+	var nullable []*string
+	assert.NoError(t, pg.ArrayScanner(&nullable).Scan(`{a,NULL}`))
+	if assert.Len(t, nullable, 2) {
+		assert.Equal(t, "a", *nullable[0])
+		assert.Nil(t, nullable[1])
+	}
+
+	empty := []int{1}
+	assert.NoError(t, pg.ArrayScanner(&empty).Scan(`{}`))
+	assert.Equal(t, []int{}, empty)
+
+	assert.NoError(t, pg.ArrayScanner(&empty).Scan(nil))
+	assert.Nil(t, empty)
+}
+
+func TestArrayScannerRoundTrip(t *testing.T) {
+	in := []string{"foo", "bar, baz", `"quoted"`, `back\slash`, "{braces}", "new\nline", "NULL", " spaced "}
+	_, args, err := pg.Array(in).ToSql()
+	assert.NoError(t, err)
+
+	var out []string
+	assert.NoError(t, pg.ArrayScanner(&out).Scan(args[0]))
+	assert.Equal(t, in, out)
+
+	_, args, err = pg.Array([]*string{&foo, nil}).ToSql()
+	assert.NoError(t, err)
+
+	var nullable []*string
+	assert.NoError(t, pg.ArrayScanner(&nullable).Scan(args[0]))
+	assert.Equal(t, []*string{&foo, nil}, nullable)
+}
+
+func TestTypedArrayScanner(t *testing.T) {
+	var bools []bool
+	assert.NoError(t, pg.ArrayScanner(&bools).Scan(`{t,f,true}`))
+	assert.Equal(t, []bool{true, false, true}, bools)
+
+	var times []time.Time
+	assert.NoError(t, pg.ArrayScanner(&times).Scan(`{"2020-01-02 03:04:05.000006+00","2020-01-02 05:04:05+02:00","2020-01-02T03:04:05Z"}`))
+	if assert.Len(t, times, 3) {
+		expected := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		assert.True(t, expected.Add(6*time.Microsecond).Equal(times[0]))
+		assert.True(t, expected.Equal(times[1]))
+		assert.True(t, expected.Equal(times[2]))
+	}
+
+	var uuids []uuid
+	assert.NoError(t, pg.ArrayScanner(&uuids).Scan(`{0102E4F5-0000-4000-8000-00000000002A}`))
+	assert.Equal(t, []uuid{testUUID}, uuids)
+
+	var nullable []*[16]byte
+	assert.NoError(t, pg.ArrayScanner(&nullable).Scan(`{0102e4f5-0000-4000-8000-00000000002a,NULL}`))
+	if assert.Len(t, nullable, 2) {
+		assert.Equal(t, testUUID, *nullable[0])
+		assert.Nil(t, nullable[1])
+	}
+
+	var invalid []uuid
+	assert.Error(t, pg.ArrayScanner(&invalid).Scan(`{0102e4f5}`))
+}
+
+func TestInvalidArrayScanner(t *testing.T) {
+	var strs []string
+	var ints []int
+	var fixed [2]int
+	invalid := []struct {
+		dest interface{}
+		src  interface{}
+	}{
+		{strs, `{a}`},
+		{&struct{}{}, `{a}`},
+		{&[]struct{}{}, `{a}`},
+		{&strs, 42},
+		{&strs, `a`},
+		{&strs, `{a`},
+		{&strs, `{"a}`},
+		{&strs, `{a,}`},
+		{&strs, `{a,NULL}`},
+		{&strs, `{{a}}`},
+		{&ints, `{a}`},
+		{&ints, `{1}x`},
+		{&fixed, `{1}`},
+	}
+
+	for _, test := range invalid {
+		err := pg.ArrayScanner(test.dest).Scan(test.src)
+		assert.Error(t, err, "Expected error at case %+v", test)
+	}
+}
@@ -0,0 +1,164 @@
+package pg
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// OnConflictBuilder builds Postgres ON CONFLICT clauses, to be used as
+// suffix of an InsertBuilder.
+type OnConflictBuilder struct {
+	columns     []string
+	constraint  string
+	targetWhere []sqrl.Sqlizer
+	sets        []conflictSet
+	where       []sqrl.Sqlizer
+}
+
+type conflictSet struct {
+	column string
+	value  interface{}
+}
+
+// OnConflict returns an OnConflictBuilder for conflicts on the unique index
+// of columns. Without columns any conflict is handled, which is only
+// allowed with DO NOTHING.
+// Ex:
+//     sqrl.Insert("users").Columns("email", "name").Values("joe@example.com", "Joe").
+//         Suffix("?", pg.OnConflict("email").SetExcluded("name"))
+//     == "INSERT INTO users (email,name) VALUES (?,?) ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name"
+func OnConflict(columns ...string) *OnConflictBuilder {
+	return &OnConflictBuilder{columns: columns}
+}
+
+// OnConflictOnConstraint returns an OnConflictBuilder for conflicts on the
+// constraint name.
+func OnConflictOnConstraint(name string) *OnConflictBuilder {
+	return &OnConflictBuilder{constraint: name}
+}
+
+// TargetWhere adds a predicate of the partial unique index to the conflict
+// target, like Where of SelectBuilder.
+func (b *OnConflictBuilder) TargetWhere(pred interface{}, args ...interface{}) *OnConflictBuilder {
+	b.targetWhere = append(b.targetWhere, conflictPred(pred, args))
+	return b
+}
+
+// Set adds a SET clause to DO UPDATE. Without SET clauses the conflict is
+// handled with DO NOTHING.
+func (b *OnConflictBuilder) Set(column string, value interface{}) *OnConflictBuilder {
+	b.sets = append(b.sets, conflictSet{column, value})
+	return b
+}
+
+// SetExcluded adds SET clauses to DO UPDATE, setting columns to the values
+// which were proposed for insertion, e.g. name = EXCLUDED.name.
+func (b *OnConflictBuilder) SetExcluded(columns ...string) *OnConflictBuilder {
+	for _, column := range columns {
+		b.Set(column, sqrl.Expr("EXCLUDED."+column))
+	}
+	return b
+}
+
+// Where adds a condition to DO UPDATE, like Where of SelectBuilder. Rows
+// not matching it are not updated.
+func (b *OnConflictBuilder) Where(pred interface{}, args ...interface{}) *OnConflictBuilder {
+	b.where = append(b.where, conflictPred(pred, args))
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *OnConflictBuilder) ToSql() (string, []interface{}, error) {
+	if len(b.sets) == 0 && len(b.where) > 0 {
+		return "", nil, fmt.Errorf("ON CONFLICT DO NOTHING can't have a WHERE clause")
+	}
+	if len(b.sets) > 0 && len(b.columns) == 0 && b.constraint == "" {
+		return "", nil, fmt.Errorf("ON CONFLICT DO UPDATE requires columns or a constraint")
+	}
+
+	sql := &bytes.Buffer{}
+	var args []interface{}
+	sql.WriteString("ON CONFLICT")
+
+	switch {
+	case b.constraint != "":
+		sql.WriteString(" ON CONSTRAINT ")
+		sql.WriteString(b.constraint)
+	case len(b.columns) > 0:
+		sql.WriteString(" (")
+		sql.WriteString(strings.Join(b.columns, ","))
+		sql.WriteString(")")
+		if len(b.targetWhere) > 0 {
+			sql.WriteString(" WHERE ")
+			args = appendConflictPreds(sql, args, b.targetWhere)
+		}
+	}
+
+	if len(b.sets) == 0 {
+		sql.WriteString(" DO NOTHING")
+		return finishConflict(sql, args)
+	}
+
+	sql.WriteString(" DO UPDATE SET ")
+	for i, s := range b.sets {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString(s.column)
+		sql.WriteString(" = ?")
+		args = append(args, s.value)
+	}
+
+	if len(b.where) > 0 {
+		sql.WriteString(" WHERE ")
+		args = appendConflictPreds(sql, args, b.where)
+	}
+	return finishConflict(sql, args)
+}
+
+// conflictPred converts pred to a Sqlizer like Where of SelectBuilder.
+func conflictPred(pred interface{}, args []interface{}) sqrl.Sqlizer {
+	switch p := pred.(type) {
+	case string:
+		return sqrl.Expr(p, args...)
+	case map[string]interface{}:
+		return sqrl.Eq(p)
+	case sqrl.Sqlizer:
+		return p
+	}
+	return invalidPred{pred}
+}
+
+type invalidPred struct {
+	pred interface{}
+}
+
+func (p invalidPred) ToSql() (string, []interface{}, error) {
+	return "", nil, fmt.Errorf("expected string-keyed map, string or Sqlizer, not %T", p.pred)
+}
+
+// appendConflictPreds writes a placeholder for each of preds, joined by AND,
+// and appends them as args to be expanded by finishConflict.
+func appendConflictPreds(sql *bytes.Buffer, args []interface{}, preds []sqrl.Sqlizer) []interface{} {
+	for i, pred := range preds {
+		if i > 0 {
+			sql.WriteString(" AND ")
+		}
+		sql.WriteString("?")
+		args = append(args, pred)
+	}
+	return args
+}
+
+// finishConflict expands the Sqlizer args of the clause, so their args are
+// merged in order.
+func finishConflict(sql *bytes.Buffer, args []interface{}) (string, []interface{}, error) {
+	s, args, err := sqrl.Expr(sql.String(), args...).ToSql()
+	if len(args) == 0 {
+		args = nil
+	}
+	return s, args, err
+}
@@ -0,0 +1,110 @@
+package pg_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidOnConflict(t *testing.T) {
+	valid := []struct {
+		op   sqrl.Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{pg.OnConflict(), "ON CONFLICT DO NOTHING", nil},
+		{pg.OnConflict("a", "b"), "ON CONFLICT (a,b) DO NOTHING", nil},
+		{pg.OnConflictOnConstraint("users_pkey"), "ON CONFLICT ON CONSTRAINT users_pkey DO NOTHING", nil},
+		{
+			pg.OnConflict("email").SetExcluded("name", "age"),
+			"ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name, age = EXCLUDED.age",
+			nil,
+		},
+		{
+			pg.OnConflict("email").TargetWhere("deleted_at IS NULL").
+				Set("visits", sqrl.Expr("users.visits + ?", 1)).
+				Set("name", "Joe").
+				Where(sqrl.Lt{"users.updated_at": 10}).
+				Where("users.locked = ?", false),
+			"ON CONFLICT (email) WHERE deleted_at IS NULL DO UPDATE SET visits = users.visits + ?, name = ? " +
+				"WHERE users.updated_at < ? AND users.locked = ?",
+			[]interface{}{1, "Joe", 10, false},
+		},
+		{
+			pg.OnConflictOnConstraint("users_email_key").Set("name", "Joe").Where(map[string]interface{}{"id": []int{1, 2}}),
+			"ON CONFLICT ON CONSTRAINT users_email_key DO UPDATE SET name = ? WHERE id IN (?,?)",
+			[]interface{}{"Joe", 1, 2},
+		},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func TestInvalidOnConflict(t *testing.T) {
+	invalid := []sqrl.Sqlizer{
+		pg.OnConflict("a").Where("b = 1"),
+		pg.OnConflict().Set("a", 1),
+		pg.OnConflict("a").Set("a", 1).Where(42),
+	}
+
+	for _, test := range invalid {
+		_, _, err := test.ToSql()
+		assert.NotNil(t, err, "Expected error at case %+v", test)
+	}
+}
+
+func TestOnConflictSuffix(t *testing.T) {
+	sql, args, err := sqrl.Insert("users").
+		Columns("email", "name").
+		Values("joe@example.com", "Joe").
+		Suffix("?", pg.OnConflict("email").Set("name", "Joe").Where("users.name LIKE ?", "%J%")).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"INSERT INTO users (email,name) VALUES ($1,$2) "+
+			"ON CONFLICT (email) DO UPDATE SET name = $3 WHERE users.name LIKE $4 RETURNING id",
+		sql)
+	assert.Equal(t, []interface{}{"joe@example.com", "Joe", "Joe", "%J%"}, args)
+
+	_, _, err = sqrl.Insert("users").Columns("email").Values("joe@example.com").
+		Suffix("?", pg.OnConflict().Set("name", "Joe")).
+		ToSql()
+	assert.Error(t, err)
+}
+
+func TestInsertOnConflict(t *testing.T) {
+	b := sqrl.Insert("users").
+		Columns("email", "name").
+		Values("joe@example.com", "Joe").
+		OnConflict(pg.OnConflict("email").SetExcluded("name")).
+		Returning("id")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"INSERT INTO users (email,name) VALUES (?,?) "+
+			"ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name RETURNING id",
+		sql)
+	assert.Equal(t, []interface{}{"joe@example.com", "Joe"}, args)
+
+	sql, _, err = b.Dialect(sqrl.SQLite).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"INSERT INTO users (email,name) VALUES (?,?) "+
+			"ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name RETURNING id",
+		sql)
+
+	_, _, err = b.OnConflict(pg.OnConflictOnConstraint("users_email_key")).ToSql()
+	assert.Error(t, err)
+}
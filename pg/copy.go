@@ -0,0 +1,158 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// Copier is the interface that wraps the CopyFrom method, bulk loading rows
+// with the COPY protocol. It is implemented by the Runner of pgxrunner.
+type Copier interface {
+	CopyFrom(ctx context.Context, table string, columns []string, rows [][]interface{}) (int64, error)
+}
+
+// maxInsertRows is the most rows inserted with a single INSERT by CopyFrom.
+const maxInsertRows = 1000
+
+// maxParams is the most args Postgres accepts for a statement.
+const maxParams = 65535
+
+// CopyFrom bulk loads rows into columns of table and returns the number of
+// rows inserted.
+//
+// rows is either a [][]interface{} or a slice of structs, or pointers to
+// them, whose fields are matched to columns by their db tag, or by their
+// name case insensitively.
+//
+// If db is a Copier the rows are loaded with COPY, otherwise db must be a
+// sqrl.ExecerContext, e.g. *sql.DB, and the rows are inserted in chunks with
+// multi-row INSERTs.
+// Ex:
+//     n, err := pg.CopyFrom(ctx, pgxrunner.New(pool), "users", []string{"id", "name"}, users)
+func CopyFrom(ctx context.Context, db interface{}, table string, columns []string, rows interface{}) (int64, error) {
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("CopyFrom requires at least one column")
+	}
+	values, err := copyRows(columns, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	switch db := db.(type) {
+	case Copier:
+		return db.CopyFrom(ctx, table, columns, values)
+	case sqrl.ExecerContext:
+		return insertChunks(ctx, db, table, columns, values)
+	}
+	return 0, fmt.Errorf("Expected Copier or sqrl.ExecerContext, got %T", db)
+}
+
+func insertChunks(ctx context.Context, db sqrl.ExecerContext, table string, columns []string, rows [][]interface{}) (int64, error) {
+	chunk := maxParams / len(columns)
+	if chunk > maxInsertRows {
+		chunk = maxInsertRows
+	}
+
+	var count int64
+	for start := 0; start < len(rows); start += chunk {
+		end := start + chunk
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		b := sqrl.Insert(table).Columns(columns...).PlaceholderFormat(sqrl.Dollar)
+		for _, row := range rows[start:end] {
+			b = b.Values(row...)
+		}
+		query, args, err := b.ToSql()
+		if err != nil {
+			return count, err
+		}
+
+		res, err := db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return count, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return count, err
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// copyRows converts rows into a [][]interface{} of columns.
+func copyRows(columns []string, rows interface{}) ([][]interface{}, error) {
+	if values, ok := rows.([][]interface{}); ok {
+		for i, row := range values {
+			if len(row) != len(columns) {
+				return nil, fmt.Errorf("row %d has %d values, expected %d", i, len(row), len(columns))
+			}
+		}
+		return values, nil
+	}
+
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("Expected rows of type [][]interface{} or slice of structs, got %T", rows)
+	}
+	t := v.Type().Elem()
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Expected rows of type [][]interface{} or slice of structs, got %T", rows)
+	}
+
+	fields := sqrl.StructFields(t)
+	indexes := make([][]int, len(columns))
+	for i, column := range columns {
+		index, ok := fields[column]
+		if !ok {
+			index, ok = fields[strings.ToLower(column)]
+		}
+		if !ok {
+			return nil, fmt.Errorf("no field of %s for column %q", t, column)
+		}
+		indexes[i] = index
+	}
+
+	values := make([][]interface{}, v.Len())
+	for i := range values {
+		elem := v.Index(i)
+		if isPtr {
+			if elem.IsNil() {
+				return nil, fmt.Errorf("row %d is nil", i)
+			}
+			elem = elem.Elem()
+		}
+
+		row := make([]interface{}, len(indexes))
+		for j, index := range indexes {
+			row[j] = copyField(elem, index)
+		}
+		values[i] = row
+	}
+	return values, nil
+}
+
+// copyField returns the value of the field of v at index, or nil if an
+// embedded struct pointer on the way is nil.
+func copyField(v reflect.Value, index []int) interface{} {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v.Interface()
+}
@@ -0,0 +1,117 @@
+package pg_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+type copierStub struct {
+	table   string
+	columns []string
+	rows    [][]interface{}
+}
+
+func (c *copierStub) CopyFrom(ctx context.Context, table string, columns []string, rows [][]interface{}) (int64, error) {
+	c.table, c.columns, c.rows = table, columns, rows
+	return int64(len(rows)), nil
+}
+
+type execerStub struct {
+	queries []string
+	args    [][]interface{}
+}
+
+func (e *execerStub) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	e.queries = append(e.queries, query)
+	e.args = append(e.args, args)
+	return driver.RowsAffected(len(args) / 2), nil
+}
+
+type copyBase struct {
+	ID int64
+}
+
+type copyUser struct {
+	*copyBase
+	Name  string `db:"full_name"`
+	Email string
+	age   int
+}
+
+func TestCopyFromCopier(t *testing.T) {
+	users := []*copyUser{
+		{&copyBase{1}, "Joe", "joe@example.com", 30},
+		{nil, "Ann", "ann@example.com", 40},
+	}
+
+	c := &copierStub{}
+	n, err := pg.CopyFrom(context.Background(), c, "users", []string{"id", "full_name", "Email"}, users)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, "users", c.table)
+	assert.Equal(t, []string{"id", "full_name", "Email"}, c.columns)
+	assert.Equal(t, [][]interface{}{
+		{int64(1), "Joe", "joe@example.com"},
+		{nil, "Ann", "ann@example.com"},
+	}, c.rows)
+}
+
+func TestCopyFromInsert(t *testing.T) {
+	rows := [][]interface{}{{1, "a"}, {2, "b"}}
+
+	e := &execerStub{}
+	n, err := pg.CopyFrom(context.Background(), e, "t", []string{"id", "name"}, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, []string{"INSERT INTO t (id,name) VALUES ($1,$2),($3,$4)"}, e.queries)
+	assert.Equal(t, [][]interface{}{{1, "a", 2, "b"}}, e.args)
+}
+
+func TestCopyFromInsertChunks(t *testing.T) {
+	rows := make([][]interface{}, 2500)
+	for i := range rows {
+		rows[i] = []interface{}{i, "x"}
+	}
+
+	e := &execerStub{}
+	n, err := pg.CopyFrom(context.Background(), e, "t", []string{"id", "name"}, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2500), n)
+	if assert.Len(t, e.args, 3) {
+		assert.Len(t, e.args[0], 2000)
+		assert.Len(t, e.args[1], 2000)
+		assert.Len(t, e.args[2], 1000)
+		assert.Equal(t, 1000, e.args[1][0])
+		assert.True(t, strings.HasSuffix(e.queries[2], "($999,$1000)"))
+	}
+}
+
+func TestCopyFromInvalid(t *testing.T) {
+	invalid := []struct {
+		db      interface{}
+		columns []string
+		rows    interface{}
+	}{
+		{&copierStub{}, nil, [][]interface{}{}},
+		{&copierStub{}, []string{"a", "b"}, [][]interface{}{{1}}},
+		{&copierStub{}, []string{"id", "age"}, []copyUser{}},
+		{&copierStub{}, []string{"a"}, []int{1}},
+		{&copierStub{}, []string{"a"}, 1},
+		{&copierStub{}, []string{"id"}, []*copyUser{nil}},
+		{"db", []string{"a"}, [][]interface{}{{1}}},
+	}
+
+	for _, test := range invalid {
+		_, err := pg.CopyFrom(context.Background(), test.db, "t", test.columns, test.rows)
+		assert.NotNil(t, err, "Expected error at case %+v", test)
+	}
+}
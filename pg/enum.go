@@ -0,0 +1,108 @@
+package pg
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Enum binds value as Postgres enum of type pgType. A nil value is bound as
+// NULL.
+// Ex:
+//     pg.Enum(StatusActive, "user_status") == "?::user_status"
+//     pg.Enum(status, "user_status").Allowed("active", "blocked")
+func Enum(value fmt.Stringer, pgType string) enumOp {
+	return enumOp{value: value, tpe: pgType}
+}
+
+type enumOp struct {
+	value   fmt.Stringer
+	tpe     string
+	allowed []string
+}
+
+// Allowed makes ToSql return an error if the value isn't one of values,
+// instead of failing when the query is run.
+func (e enumOp) Allowed(values ...string) enumOp {
+	e.allowed = values
+	return e
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (e enumOp) ToSql() (string, []interface{}, error) {
+	if e.tpe == "" {
+		return "", nil, fmt.Errorf("Enum requires a type")
+	}
+	if v := reflect.ValueOf(e.value); !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
+		return "?::" + e.tpe, []interface{}{nil}, nil
+	}
+
+	s := e.value.String()
+	if err := checkEnum(s, e.tpe, e.allowed); err != nil {
+		return "", nil, err
+	}
+	return "?::" + e.tpe, []interface{}{s}, nil
+}
+
+// EnumArray binds values as Postgres array of enum type pgType.
+//
+// Valid values are slices or arrays of fmt.Stringer types or strings
+// Ex:
+//     pg.EnumArray([]Status{StatusActive, StatusBlocked}, "user_status") == "?::user_status[]"
+func EnumArray(values interface{}, pgType string) enumArray {
+	return enumArray{values: values, tpe: pgType}
+}
+
+type enumArray struct {
+	values  interface{}
+	tpe     string
+	allowed []string
+}
+
+// Allowed makes ToSql return an error if any value isn't one of values,
+// instead of failing when the query is run.
+func (e enumArray) Allowed(values ...string) enumArray {
+	e.allowed = values
+	return e
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (e enumArray) ToSql() (string, []interface{}, error) {
+	if e.tpe == "" {
+		return "", nil, fmt.Errorf("EnumArray requires a type")
+	}
+	v := reflect.ValueOf(e.values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", nil, fmt.Errorf("Expected value of type slice or array, got %s", v.Kind())
+	}
+
+	strs := make([]string, v.Len())
+	for i := range strs {
+		switch value := v.Index(i).Interface().(type) {
+		case fmt.Stringer:
+			strs[i] = value.String()
+		case string:
+			strs[i] = value
+		default:
+			return "", nil, fmt.Errorf("Expected enum of type fmt.Stringer or string, got %T", value)
+		}
+		if err := checkEnum(strs[i], e.tpe, e.allowed); err != nil {
+			return "", nil, err
+		}
+	}
+
+	sql, args, err := Array(strs).ToSql()
+	return sql + "::" + e.tpe + "[]", args, err
+}
+
+// checkEnum returns an error if allowed is given and s isn't one of them.
+func checkEnum(s, tpe string, allowed []string) error {
+	if allowed == nil {
+		return nil
+	}
+	for _, a := range allowed {
+		if s == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid value %q for enum %s", s, tpe)
+}
@@ -0,0 +1,79 @@
+package pg_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+type status int
+
+const (
+	statusActive status = iota
+	statusBlocked
+	statusDeleted
+)
+
+func (s status) String() string {
+	return [...]string{"active", "blocked", "deleted"}[s]
+}
+
+func TestValidEnum(t *testing.T) {
+	var nilStatus *statusPtr
+
+	valid := []struct {
+		op   sqrl.Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{pg.Enum(statusActive, "user_status"), "?::user_status", []interface{}{"active"}},
+		{pg.Enum(statusBlocked, "user_status").Allowed("active", "blocked"), "?::user_status", []interface{}{"blocked"}},
+		{pg.Enum(nil, "user_status"), "?::user_status", []interface{}{nil}},
+		{pg.Enum(nilStatus, "user_status"), "?::user_status", []interface{}{nil}},
+		{
+			pg.EnumArray([]status{statusActive, statusDeleted}, "user_status"),
+			"?::user_status[]",
+			[]interface{}{`{"active","deleted"}`},
+		},
+		{pg.EnumArray([]string{"a b"}, "my_enum").Allowed("a b"), "?::my_enum[]", []interface{}{`{"a b"}`}},
+		{pg.EnumArray([]status{}, "user_status"), "?::user_status[]", []interface{}{"{}"}},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+
+	sql, args, err := sqrl.Update("users").Set("status", pg.Enum(statusBlocked, "user_status")).
+		Where("id = ?", 1).PlaceholderFormat(sqrl.Dollar).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET status = $1::user_status WHERE id = $2", sql)
+	assert.Equal(t, []interface{}{"blocked", 1}, args)
+}
+
+type statusPtr struct{}
+
+func (s *statusPtr) String() string {
+	return "ptr"
+}
+
+func TestInvalidEnum(t *testing.T) {
+	invalid := []sqrl.Sqlizer{
+		pg.Enum(statusActive, ""),
+		pg.Enum(statusDeleted, "user_status").Allowed("active", "blocked"),
+		pg.EnumArray([]status{statusActive, statusDeleted}, "user_status").Allowed("active"),
+		pg.EnumArray([]int{1}, "user_status"),
+		pg.EnumArray("active", "user_status"),
+		pg.EnumArray([]string{"a"}, ""),
+	}
+
+	for _, test := range invalid {
+		_, _, err := test.ToSql()
+		assert.NotNil(t, err, "Expected error at case %+v", test)
+	}
+}
@@ -0,0 +1,177 @@
+package pg
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// HStore converts map into Postgres hstore. Nil values are rendered as NULL.
+func HStore(m map[string]*string) sqrl.Sqlizer {
+	return hstore(m)
+}
+
+type hstore map[string]*string
+
+// ToSql builds the query into a SQL string and bound args.
+func (h hstore) ToSql() (string, []interface{}, error) {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf := &bytes.Buffer{}
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		marshalHStoreString(k, buf)
+		buf.WriteString("=>")
+		if v := h[k]; v != nil {
+			marshalHStoreString(*v, buf)
+		} else {
+			buf.WriteString("NULL")
+		}
+	}
+	return "?::hstore", []interface{}{buf.String()}, nil
+}
+
+func marshalHStoreString(s string, buf *bytes.Buffer) {
+	buf.WriteByte('"')
+	buf.WriteString(strings.Replace(strings.Replace(s, `\`, `\\`, -1), `"`, `\"`, -1))
+	buf.WriteByte('"')
+}
+
+// HStoreHasKey checks whether the hstore column has key, using the ?
+// operator. It needs a numbered PlaceholderFormat, e.g. sqrl.Dollar.
+// Ex:
+//     .Where(pg.HStoreHasKey("attrs", "color")) == "attrs ? $1"
+func HStoreHasKey(column, key string) sqrl.Sqlizer {
+	return sqrl.Expr(column+" ?? ?", key)
+}
+
+// HStoreHasAnyKey checks whether the hstore column has any of keys, using
+// the ?| operator. It needs a numbered PlaceholderFormat, e.g. sqrl.Dollar.
+func HStoreHasAnyKey(column string, keys ...string) sqrl.Sqlizer {
+	return sqrl.Expr(column+" ??| ?", Array(keys))
+}
+
+// HStoreHasAllKeys checks whether the hstore column has all of keys, using
+// the ?& operator. It needs a numbered PlaceholderFormat, e.g. sqrl.Dollar.
+func HStoreHasAllKeys(column string, keys ...string) sqrl.Sqlizer {
+	return sqrl.Expr(column+" ??& ?", Array(keys))
+}
+
+// HStoreContains checks whether the hstore column contains all pairs of m.
+// Ex:
+//     .Where(pg.HStoreContains("attrs", map[string]*string{"color": &red})) == "attrs @> $1::hstore"
+func HStoreContains(column string, m map[string]*string) sqrl.Sqlizer {
+	return sqrl.Expr(column+" @> ?", HStore(m))
+}
+
+// HStoreContainedBy checks whether all pairs of the hstore column are in m.
+func HStoreContainedBy(column string, m map[string]*string) sqrl.Sqlizer {
+	return sqrl.Expr(column+" <@ ?", HStore(m))
+}
+
+// HStoreScanner returns a sql.Scanner which parses a Postgres hstore into
+// the map dest points to. A NULL hstore sets the map to nil.
+// Example:
+//     var attrs map[string]*string
+//     err := db.QueryRow("SELECT attrs FROM products WHERE id = $1", id).Scan(pg.HStoreScanner(&attrs))
+func HStoreScanner(dest *map[string]*string) sql.Scanner {
+	return hstoreScanner{dest}
+}
+
+type hstoreScanner struct {
+	dest *map[string]*string
+}
+
+// Scan implements sql.Scanner.
+func (h hstoreScanner) Scan(src interface{}) error {
+	var text string
+	switch src := src.(type) {
+	case nil:
+		*h.dest = nil
+		return nil
+	case []byte:
+		text = string(src)
+	case string:
+		text = src
+	default:
+		return fmt.Errorf("Cannot scan %T into Postgres hstore", src)
+	}
+
+	m, err := parseHStore(text)
+	if err != nil {
+		return err
+	}
+	*h.dest = m
+	return nil
+}
+
+// parseHStore parses the text format of Postgres hstore, e.g.
+// "a"=>"1", "b"=>NULL.
+func parseHStore(s string) (map[string]*string, error) {
+	p := &arrayParser{s: s, kind: "hstore"}
+	m := map[string]*string{}
+
+	p.skipSpace()
+	for p.pos < len(p.s) {
+		key, err := p.parseHStoreString()
+		if err != nil {
+			return nil, err
+		}
+		if key.null {
+			return nil, p.errorf("NULL key")
+		}
+
+		p.skipSpace()
+		if !strings.HasPrefix(p.s[p.pos:], "=>") {
+			return nil, p.errorf("expected =>")
+		}
+		p.pos += 2
+		p.skipSpace()
+
+		value, err := p.parseHStoreString()
+		if err != nil {
+			return nil, err
+		}
+		if value.null {
+			m[key.text] = nil
+		} else {
+			m[key.text] = &value.text
+		}
+
+		p.skipSpace()
+		if p.pos < len(p.s) {
+			if p.s[p.pos] != ',' {
+				return nil, p.errorf("expected ,")
+			}
+			p.pos++
+			p.skipSpace()
+		}
+	}
+	return m, nil
+}
+
+func (p *arrayParser) parseHStoreString() (arrayElem, error) {
+	if p.pos < len(p.s) && p.s[p.pos] == '"' {
+		return p.parseQuoted()
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(" \t\n\r,=", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	text := p.s[start:p.pos]
+	if text == "" {
+		return arrayElem{}, p.errorf("empty element")
+	}
+	return arrayElem{text: text, null: strings.EqualFold(text, "NULL")}, nil
+}
@@ -0,0 +1,69 @@
+package pg_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestHStore(t *testing.T) {
+	sql, args, err := pg.HStore(map[string]*string{
+		"b":          nil,
+		"a":          strPtr("1"),
+		`"quoted" k`: strPtr(`back\slash`),
+	}).ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "?::hstore", sql)
+	assert.Equal(t, []interface{}{`"\"quoted\" k"=>"back\\slash", "a"=>"1", "b"=>NULL`}, args)
+
+	_, args, err = pg.HStore(nil).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{""}, args)
+}
+
+func TestHStorePredicates(t *testing.T) {
+	sql, args, err := sqrl.Select("id").From("products").
+		Where(pg.HStoreHasKey("attrs", "color")).
+		Where(pg.HStoreHasAnyKey("attrs", "a", "b")).
+		Where(pg.HStoreHasAllKeys("attrs", "c")).
+		Where(pg.HStoreContains("attrs", map[string]*string{"color": strPtr("red")})).
+		Where(pg.HStoreContainedBy("attrs", map[string]*string{"size": nil})).
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"SELECT id FROM products WHERE attrs ? $1 AND attrs ?| $2 AND attrs ?& $3 "+
+			"AND attrs @> $4::hstore AND attrs <@ $5::hstore",
+		sql)
+	assert.Equal(t, []interface{}{"color", `{"a","b"}`, `{"c"}`, `"color"=>"red"`, `"size"=>NULL`}, args)
+}
+
+func TestHStoreScanner(t *testing.T) {
+	var m map[string]*string
+	assert.NoError(t, pg.HStoreScanner(&m).Scan([]byte(`"a"=>"1", "b"=>NULL, "\"q\""=>"x\\y", c => "NULL"`)))
+	assert.Equal(t, map[string]*string{"a": strPtr("1"), "b": nil, `"q"`: strPtr(`x\y`), "c": strPtr("NULL")}, m)
+
+	assert.NoError(t, pg.HStoreScanner(&m).Scan(""))
+	assert.Equal(t, map[string]*string{}, m)
+
+	assert.NoError(t, pg.HStoreScanner(&m).Scan(nil))
+	assert.Nil(t, m)
+
+	in := map[string]*string{"k, =>": strPtr(`"v"`), "n": nil}
+	_, args, err := pg.HStore(in).ToSql()
+	assert.NoError(t, err)
+	assert.NoError(t, pg.HStoreScanner(&m).Scan(args[0]))
+	assert.Equal(t, in, m)
+
+	for _, invalid := range []interface{}{42, `"a"`, `"a"=>`, `"a"=>"1" "b"=>"2"`, `NULL=>"1"`, `"a=>"1"`} {
+		assert.Error(t, pg.HStoreScanner(&m).Scan(invalid), "Expected error at case %v", invalid)
+	}
+}
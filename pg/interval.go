@@ -0,0 +1,51 @@
+package pg
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// Interval converts d into Postgres interval
+// Ex:
+//     .Where(sqrl.Expr("created_at > now() - ?", pg.Interval(time.Hour))) == "created_at > now() - ?::interval"
+func Interval(d time.Duration) sqrl.Sqlizer {
+	return IntervalParts{Duration: d}
+}
+
+// IntervalParts is a Postgres interval of months and days, whose length
+// depends on the date they are added to, and a fixed duration. It converts
+// into Postgres interval like Interval.
+// Ex:
+//     pg.IntervalParts{Months: 1, Days: 2, Duration: 3 * time.Hour}
+type IntervalParts struct {
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (i IntervalParts) ToSql() (string, []interface{}, error) {
+	return "?::interval", []interface{}{i.String()}, nil
+}
+
+// String formats the interval in Postgres interval input syntax, e.g.
+// "1 months 2 days 10800 seconds".
+func (i IntervalParts) String() string {
+	var parts []string
+	if i.Months != 0 {
+		parts = append(parts, strconv.Itoa(i.Months)+" months")
+	}
+	if i.Days != 0 {
+		parts = append(parts, strconv.Itoa(i.Days)+" days")
+	}
+	if i.Duration != 0 || len(parts) == 0 {
+		// microseconds are the precision of Postgres intervals
+		us := i.Duration.Round(time.Microsecond) / time.Microsecond
+		seconds := strconv.FormatFloat(float64(us)/1e6, 'f', -1, 64)
+		parts = append(parts, seconds+" seconds")
+	}
+	return strings.Join(parts, " ")
+}
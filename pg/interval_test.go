@@ -0,0 +1,44 @@
+package pg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval(t *testing.T) {
+	valid := []struct {
+		op    sqrl.Sqlizer
+		value string
+	}{
+		{pg.Interval(0), "0 seconds"},
+		{pg.Interval(90 * time.Minute), "5400 seconds"},
+		{pg.Interval(-1500 * time.Millisecond), "-1.5 seconds"},
+		{pg.Interval(time.Nanosecond * 1500), "0.000002 seconds"},
+		{pg.IntervalParts{Months: 1, Days: 2, Duration: 3 * time.Hour}, "1 months 2 days 10800 seconds"},
+		{pg.IntervalParts{Months: -12}, "-12 months"},
+		{pg.IntervalParts{Days: 7}, "7 days"},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, "?::interval", sql)
+		assert.Equal(t, []interface{}{test.value}, args)
+	}
+}
+
+func TestIntervalInQuery(t *testing.T) {
+	sql, args, err := sqrl.Select("id").From("sessions").
+		Where(sqrl.Expr("created_at > now() - ?", pg.Interval(time.Hour))).
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM sessions WHERE created_at > now() - $1::interval", sql)
+	assert.Equal(t, []interface{}{"3600 seconds"}, args)
+}
@@ -37,3 +37,107 @@ func (jo jsonOp) ToSql() (string, []interface{}, error) {
 
 	return fmt.Sprintf("?::%s", jo.tpe), []interface{}{string(v)}, nil
 }
+
+// JSONBContains checks whether the jsonb column contains value, marshalled
+// like by JSONB, using the @> operator.
+// Ex:
+//     .Where(pg.JSONBContains("data", map[string]int{"a": 1})) == "data @> ?::jsonb"
+func JSONBContains(column string, value interface{}) sqrl.Sqlizer {
+	return sqrl.Expr(column+" @> ?", JSONB(value))
+}
+
+// JSONBContainedBy checks whether the jsonb column is contained by value,
+// marshalled like by JSONB, using the <@ operator.
+func JSONBContainedBy(column string, value interface{}) sqrl.Sqlizer {
+	return sqrl.Expr(column+" <@ ?", JSONB(value))
+}
+
+// JSONBHasKey checks whether the jsonb column has the top level key, using
+// the ? operator. It needs a numbered PlaceholderFormat, e.g. sqrl.Dollar.
+// Ex:
+//     .Where(pg.JSONBHasKey("data", "a")) == "data ? $1"
+func JSONBHasKey(column, key string) sqrl.Sqlizer {
+	return sqrl.Expr(column+" ?? ?", key)
+}
+
+// JSONBHasAnyKey checks whether the jsonb column has any of the top level
+// keys, using the ?| operator. It needs a numbered PlaceholderFormat, e.g.
+// sqrl.Dollar.
+func JSONBHasAnyKey(column string, keys ...string) sqrl.Sqlizer {
+	return sqrl.Expr(column+" ??| ?", Array(keys))
+}
+
+// JSONBHasAllKeys checks whether the jsonb column has all of the top level
+// keys, using the ?& operator. It needs a numbered PlaceholderFormat, e.g.
+// sqrl.Dollar.
+func JSONBHasAllKeys(column string, keys ...string) sqrl.Sqlizer {
+	return sqrl.Expr(column+" ??& ?", Array(keys))
+}
+
+// JSONGet extracts the field key, a string, or the element key, an int, of
+// the json or jsonb column, using the -> operator.
+// Ex:
+//     .Column(pg.JSONGet("data", "a")) == "data->?::text"
+func JSONGet(column string, key interface{}) sqrl.Sqlizer {
+	return jsonGet{column: column, op: "->", key: key}
+}
+
+// JSONGetText is like JSONGet, but extracts the value as text, using the ->>
+// operator.
+// Ex:
+//     .Where(sqrl.Expr("? = ?", pg.JSONGetText("data", "a"), "foo")) == "data->>?::text = ?"
+func JSONGetText(column string, key interface{}) sqrl.Sqlizer {
+	return jsonGet{column: column, op: "->>", key: key}
+}
+
+type jsonGet struct {
+	column string
+	op     string
+	key    interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (g jsonGet) ToSql() (string, []interface{}, error) {
+	var tpe string
+	switch g.key.(type) {
+	case string:
+		tpe = "text"
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		tpe = "int"
+	default:
+		return "", nil, fmt.Errorf("Expected json key of type string or int, got %T", g.key)
+	}
+
+	return fmt.Sprintf("%s%s?::%s", g.column, g.op, tpe), []interface{}{g.key}, nil
+}
+
+// JSONGetPath extracts the value at path of the json or jsonb column, using
+// the #> operator. Elements of path are keys or array indexes.
+// Ex:
+//     .Column(pg.JSONGetPath("data", "a", "0")) == "data#>?"
+func JSONGetPath(column string, path ...string) sqrl.Sqlizer {
+	return sqrl.Expr(column+"#>?", Array(path))
+}
+
+// JSONGetPathText is like JSONGetPath, but extracts the value as text, using
+// the #>> operator.
+func JSONGetPathText(column string, path ...string) sqrl.Sqlizer {
+	return sqrl.Expr(column+"#>>?", Array(path))
+}
+
+// JSONBSet replaces the value at path of the jsonb column with value,
+// marshalled like by JSONB, using jsonb_set. With createMissing the value is
+// added if path doesn't exist.
+// Ex:
+//     .Set("data", pg.JSONBSet("data", []string{"a", "b"}, 42, true)) == "data = jsonb_set(data, ?, ?::jsonb, true)"
+func JSONBSet(column string, path []string, value interface{}, createMissing bool) sqrl.Sqlizer {
+	return sqrl.Expr(fmt.Sprintf("jsonb_set(%s, ?, ?, %t)", column, createMissing), Array(path), JSONB(value))
+}
+
+// JSONBMerge merges value, marshalled like by JSONB, into the jsonb column,
+// using the || operator. Top level keys of value replace those of column.
+// Ex:
+//     .Set("data", pg.JSONBMerge("data", map[string]int{"a": 1})) == "data = data || ?::jsonb"
+func JSONBMerge(column string, value interface{}) sqrl.Sqlizer {
+	return sqrl.Expr(column+" || ?", JSONB(value))
+}
@@ -53,6 +53,65 @@ func TestInvalidJSON(t *testing.T) {
 	assert.Nil(t, args)
 }
 
+func TestJSONBOperators(t *testing.T) {
+	sql, args, err := sqrl.Select("id").
+		Column(pg.JSONGet("data", "a")).
+		Column(pg.JSONGetPathText("data", "a", "0")).
+		From("docs").
+		Where(pg.JSONBContains("data", map[string]int{"a": 1})).
+		Where(pg.JSONBContainedBy("data", []int{1, 2})).
+		Where(pg.JSONBHasKey("data", "a")).
+		Where(pg.JSONBHasAnyKey("data", "b", "c")).
+		Where(pg.JSONBHasAllKeys("data", "d")).
+		Where(sqrl.Expr("? = ?", pg.JSONGetText("data", 0), "foo")).
+		Where(sqrl.Expr("? IS NOT NULL", pg.JSONGetPath("data", "x"))).
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"SELECT id, data->$1::text, data#>>$2 FROM docs "+
+			"WHERE data @> $3::jsonb AND data <@ $4::jsonb AND data ? $5 AND data ?| $6 AND data ?& $7 "+
+			"AND data->>$8::int = $9 AND data#>$10 IS NOT NULL",
+		sql)
+	assert.Equal(t,
+		[]interface{}{"a", `{"a","0"}`, `{"a":1}`, "[1,2]", "a", `{"b","c"}`, `{"d"}`, 0, "foo", `{"x"}`},
+		args)
+}
+
+func TestInvalidJSONOperators(t *testing.T) {
+	invalid := []sqrl.Sqlizer{
+		pg.JSONGet("data", 1.5),
+		pg.JSONGetText("data", nil),
+		pg.JSONBContains("data", invalidValue{}),
+	}
+
+	for _, test := range invalid {
+		_, _, err := test.ToSql()
+		assert.Error(t, err, "Expected error at case %+v", test)
+	}
+}
+
+func TestJSONBUpdate(t *testing.T) {
+	sql, args, err := sqrl.Update("docs").
+		Set("data", pg.JSONBSet("data", []string{"a", "b"}, 42, true)).
+		Set("meta", pg.JSONBMerge("meta", map[string]string{"c": "d"})).
+		Set("tags", pg.JSONBSet("tags", []string{"0"}, "x", false)).
+		Where(sqrl.Eq{"id": 1}).
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"UPDATE docs SET data = jsonb_set(data, $1, $2::jsonb, true), meta = meta || $3::jsonb, "+
+			"tags = jsonb_set(tags, $4, $5::jsonb, false) WHERE id = $6",
+		sql)
+	assert.Equal(t, []interface{}{`{"a","b"}`, "42", `{"c":"d"}`, `{"0"}`, `"x"`, 1}, args)
+
+	_, _, err = pg.JSONBMerge("meta", invalidValue{}).ToSql()
+	assert.Error(t, err)
+}
+
 func ExampleJSONB() {
 	sql, args, err := sqrl.Insert("posts").
 		Columns("content", "tags").
@@ -0,0 +1,91 @@
+package pg
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// Inet converts ip into Postgres inet
+func Inet(ip net.IP) sqrl.Sqlizer {
+	return netOp{value: ip, tpe: "inet"}
+}
+
+// CIDR converts network into Postgres cidr
+func CIDR(network *net.IPNet) sqrl.Sqlizer {
+	return netOp{value: network, tpe: "cidr"}
+}
+
+// MACAddr converts addr into Postgres macaddr, or macaddr8 for EUI-64
+// addresses
+func MACAddr(addr net.HardwareAddr) sqrl.Sqlizer {
+	tpe := "macaddr"
+	if len(addr) == 8 {
+		tpe = "macaddr8"
+	}
+	return netOp{value: addr, tpe: tpe}
+}
+
+type netOp struct {
+	value interface{}
+	tpe   string
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (n netOp) ToSql() (string, []interface{}, error) {
+	var s string
+	switch v := n.value.(type) {
+	case net.IP:
+		if v.To16() == nil {
+			return "", nil, fmt.Errorf("Invalid %s value: %v", n.tpe, []byte(v))
+		}
+		s = v.String()
+	case *net.IPNet:
+		if v == nil || v.IP.To16() == nil {
+			return "", nil, fmt.Errorf("Invalid %s value: %v", n.tpe, v)
+		}
+		s = v.String()
+	case net.HardwareAddr:
+		if len(v) != 6 && len(v) != 8 {
+			return "", nil, fmt.Errorf("Invalid %s value: %v", n.tpe, []byte(v))
+		}
+		s = v.String()
+	}
+
+	return fmt.Sprintf("?::%s", n.tpe), []interface{}{s}, nil
+}
+
+// InetContainedBy checks whether the inet or cidr column is strictly
+// contained by addr, using the << operator.
+// Ex:
+//     .Where(pg.InetContainedBy("ip", pg.CIDR(network))) == "ip << ?::cidr"
+func InetContainedBy(column string, addr sqrl.Sqlizer) sqrl.Sqlizer {
+	return sqrl.Expr(column+" << ?", addr)
+}
+
+// InetContainedByOrEq checks whether the inet or cidr column is contained by
+// or equal to addr, using the <<= operator.
+func InetContainedByOrEq(column string, addr sqrl.Sqlizer) sqrl.Sqlizer {
+	return sqrl.Expr(column+" <<= ?", addr)
+}
+
+// InetContains checks whether the inet or cidr column strictly contains
+// addr, using the >> operator.
+// Ex:
+//     .Where(pg.InetContains("network", pg.Inet(ip))) == "network >> ?::inet"
+func InetContains(column string, addr sqrl.Sqlizer) sqrl.Sqlizer {
+	return sqrl.Expr(column+" >> ?", addr)
+}
+
+// InetContainsOrEq checks whether the inet or cidr column contains or equals
+// addr, using the >>= operator.
+func InetContainsOrEq(column string, addr sqrl.Sqlizer) sqrl.Sqlizer {
+	return sqrl.Expr(column+" >>= ?", addr)
+}
+
+// InetOverlaps checks whether the inet or cidr column contains or is
+// contained by addr, using the && operator.
+func InetOverlaps(column string, addr sqrl.Sqlizer) sqrl.Sqlizer {
+	return sqrl.Expr(column+" && ?", addr)
+}
@@ -0,0 +1,74 @@
+package pg_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidNet(t *testing.T) {
+	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+	_, network6, _ := net.ParseCIDR("2001:db8::/32")
+	mac, _ := net.ParseMAC("08:00:2b:01:02:03")
+	mac8, _ := net.ParseMAC("08:00:2b:01:02:03:04:05")
+
+	valid := []struct {
+		op    sqrl.Sqlizer
+		sql   string
+		value string
+	}{
+		{pg.Inet(net.ParseIP("192.168.0.1")), "?::inet", "192.168.0.1"},
+		{pg.Inet(net.ParseIP("::1")), "?::inet", "::1"},
+		{pg.CIDR(network), "?::cidr", "10.0.0.0/8"},
+		{pg.CIDR(network6), "?::cidr", "2001:db8::/32"},
+		{pg.MACAddr(mac), "?::macaddr", "08:00:2b:01:02:03"},
+		{pg.MACAddr(mac8), "?::macaddr8", "08:00:2b:01:02:03:04:05"},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, []interface{}{test.value}, args)
+	}
+}
+
+func TestInvalidNet(t *testing.T) {
+	invalid := []sqrl.Sqlizer{
+		pg.Inet(nil),
+		pg.Inet(net.IP{1, 2}),
+		pg.CIDR(nil),
+		pg.MACAddr(nil),
+		pg.MACAddr(net.HardwareAddr{1, 2, 3}),
+	}
+
+	for _, test := range invalid {
+		_, _, err := test.ToSql()
+		assert.NotNil(t, err, "Expected error at case %+v", test)
+	}
+}
+
+func TestNetPredicates(t *testing.T) {
+	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+	ip := net.ParseIP("10.1.2.3")
+
+	sql, args, err := sqrl.Select("id").From("hosts").
+		Where(pg.InetContainedBy("ip", pg.CIDR(network))).
+		Where(pg.InetContainedByOrEq("ip", pg.CIDR(network))).
+		Where(pg.InetContains("network", pg.Inet(ip))).
+		Where(pg.InetContainsOrEq("network", pg.Inet(ip))).
+		Where(pg.InetOverlaps("network", pg.CIDR(network))).
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"SELECT id FROM hosts WHERE ip << $1::cidr AND ip <<= $2::cidr AND network >> $3::inet "+
+			"AND network >>= $4::inet AND network && $5::cidr",
+		sql)
+	assert.Equal(t, []interface{}{"10.0.0.0/8", "10.0.0.0/8", "10.1.2.3", "10.1.2.3", "10.0.0.0/8"}, args)
+}
@@ -0,0 +1,69 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// Notify sends a notification with payload on channel, using pg_notify so
+// both are bound as args.
+// Ex:
+//     sqrl.ExecWith(db, sqrl.ReplaceFor(sqrl.Dollar, pg.Notify("orders", `{"id": 1}`)))
+//     == "SELECT pg_notify($1, $2)"
+func Notify(channel, payload string) sqrl.Sqlizer {
+	return notifyOp{channel, payload}
+}
+
+type notifyOp struct {
+	channel string
+	payload string
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (n notifyOp) ToSql() (string, []interface{}, error) {
+	if n.channel == "" {
+		return "", nil, fmt.Errorf("NOTIFY requires a channel")
+	}
+	return "SELECT pg_notify(?, ?)", []interface{}{n.channel, n.payload}, nil
+}
+
+// Listen registers the session as listener on channel. The channel is
+// quoted, so it is case sensitive, like with pg_notify.
+// Ex:
+//     pg.Listen("orders") == `LISTEN "orders"`
+func Listen(channel string) sqrl.Sqlizer {
+	return listenOp{"LISTEN", channel}
+}
+
+// Unlisten removes the session as listener on channel, or on all channels
+// if channel is "*".
+// Ex:
+//     pg.Unlisten("orders") == `UNLISTEN "orders"`
+func Unlisten(channel string) sqrl.Sqlizer {
+	return listenOp{"UNLISTEN", channel}
+}
+
+type listenOp struct {
+	command string
+	channel string
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (l listenOp) ToSql() (string, []interface{}, error) {
+	switch l.channel {
+	case "":
+		return "", nil, fmt.Errorf("%s requires a channel", l.command)
+	case "*":
+		if l.command == "UNLISTEN" {
+			return "UNLISTEN *", nil, nil
+		}
+	}
+	return l.command + " " + quoteIdent(l.channel), nil, nil
+}
+
+// quoteIdent quotes s as Postgres identifier.
+func quoteIdent(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
@@ -0,0 +1,46 @@
+package pg_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidNotify(t *testing.T) {
+	valid := []struct {
+		op   sqrl.Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{pg.Notify("orders", `{"id": 1}`), "SELECT pg_notify(?, ?)", []interface{}{"orders", `{"id": 1}`}},
+		{pg.Notify("Orders", ""), "SELECT pg_notify(?, ?)", []interface{}{"Orders", ""}},
+		{pg.Listen("orders"), `LISTEN "orders"`, nil},
+		{pg.Listen(`my "chan"; DROP`), `LISTEN "my ""chan""; DROP"`, nil},
+		{pg.Unlisten("Orders"), `UNLISTEN "Orders"`, nil},
+		{pg.Unlisten("*"), "UNLISTEN *", nil},
+		{pg.Listen("*"), `LISTEN "*"`, nil},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func TestInvalidNotify(t *testing.T) {
+	invalid := []sqrl.Sqlizer{
+		pg.Notify("", "payload"),
+		pg.Listen(""),
+		pg.Unlisten(""),
+	}
+
+	for _, test := range invalid {
+		_, _, err := test.ToSql()
+		assert.NotNil(t, err, "Expected error at case %+v", test)
+	}
+}
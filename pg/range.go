@@ -0,0 +1,88 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// Int4Range converts lower and upper into Postgres int4range
+//
+// Bounds are [) by default, i.e. lower is inclusive and upper exclusive,
+// see Bounds. Nil bounds are unbounded.
+// Ex:
+//     pg.Int4Range(1, 10).Bounds("[]") == "int4range(?, ?, '[]')"
+func Int4Range(lower, upper interface{}) rangeOp {
+	return newRange("int4range", lower, upper)
+}
+
+// Int8Range converts lower and upper into Postgres int8range
+func Int8Range(lower, upper interface{}) rangeOp {
+	return newRange("int8range", lower, upper)
+}
+
+// NumRange converts lower and upper into Postgres numrange
+func NumRange(lower, upper interface{}) rangeOp {
+	return newRange("numrange", lower, upper)
+}
+
+// TsTzRange converts lower and upper, e.g. time.Time, into Postgres tstzrange
+func TsTzRange(lower, upper interface{}) rangeOp {
+	return newRange("tstzrange", lower, upper)
+}
+
+// DateRange converts lower and upper into Postgres daterange
+func DateRange(lower, upper interface{}) rangeOp {
+	return newRange("daterange", lower, upper)
+}
+
+type rangeOp struct {
+	tpe    string
+	lower  interface{}
+	upper  interface{}
+	bounds string
+}
+
+func newRange(tpe string, lower, upper interface{}) rangeOp {
+	return rangeOp{tpe: tpe, lower: lower, upper: upper, bounds: "[)"}
+}
+
+// Bounds sets whether the bounds are inclusive [] or exclusive (), e.g. (]
+// for an exclusive lower and inclusive upper bound.
+func (r rangeOp) Bounds(bounds string) rangeOp {
+	r.bounds = bounds
+	return r
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (r rangeOp) ToSql() (string, []interface{}, error) {
+	switch r.bounds {
+	case "[)", "[]", "(]", "()":
+	default:
+		return "", nil, fmt.Errorf("Invalid %s bounds %q, expected one of [), [], (] or ()", r.tpe, r.bounds)
+	}
+
+	return fmt.Sprintf("%s(?, ?, '%s')", r.tpe, r.bounds), []interface{}{r.lower, r.upper}, nil
+}
+
+// RangeContains checks whether the range column contains value, which is
+// either an element or a range, using the @> operator.
+// Ex:
+//     .Where(pg.RangeContains("during", time.Now()))
+func RangeContains(column string, value interface{}) sqrl.Sqlizer {
+	return sqrl.Expr(column+" @> ?", value)
+}
+
+// RangeContainedBy checks whether the range column is contained by the
+// range r, using the <@ operator.
+func RangeContainedBy(column string, r sqrl.Sqlizer) sqrl.Sqlizer {
+	return sqrl.Expr(column+" <@ ?", r)
+}
+
+// RangeOverlaps checks whether the range column overlaps the range r, using
+// the && operator.
+// Ex:
+//     .Where(pg.RangeOverlaps("during", pg.TsTzRange(from, to)))
+func RangeOverlaps(column string, r sqrl.Sqlizer) sqrl.Sqlizer {
+	return sqrl.Expr(column+" && ?", r)
+}
@@ -0,0 +1,63 @@
+package pg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidRange(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	valid := []struct {
+		op   sqrl.Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{pg.Int4Range(1, 10), "int4range(?, ?, '[)')", []interface{}{1, 10}},
+		{pg.Int8Range(1, nil).Bounds("(]"), "int8range(?, ?, '(]')", []interface{}{1, nil}},
+		{pg.NumRange(nil, "1.5").Bounds("()"), "numrange(?, ?, '()')", []interface{}{nil, "1.5"}},
+		{pg.TsTzRange(from, nil).Bounds("[]"), "tstzrange(?, ?, '[]')", []interface{}{from, nil}},
+		{pg.DateRange("2020-01-01", "2020-02-01"), "daterange(?, ?, '[)')", []interface{}{"2020-01-01", "2020-02-01"}},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func TestInvalidRange(t *testing.T) {
+	invalid := []sqrl.Sqlizer{
+		pg.Int4Range(1, 10).Bounds(""),
+		pg.Int4Range(1, 10).Bounds("[')"),
+	}
+
+	for _, test := range invalid {
+		_, _, err := test.ToSql()
+		assert.NotNil(t, err, "Expected error at case %+v", test)
+	}
+}
+
+func TestRangePredicates(t *testing.T) {
+	sql, args, err := sqrl.Select("id").From("bookings").
+		Where(pg.RangeContains("seats", 4)).
+		Where(pg.RangeContains("seats", pg.Int4Range(1, 3))).
+		Where(pg.RangeContainedBy("seats", pg.Int4Range(1, 100).Bounds("[]"))).
+		Where(pg.RangeOverlaps("during", pg.TsTzRange("2020-01-01", "2020-02-01"))).
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"SELECT id FROM bookings WHERE seats @> $1 AND seats @> int4range($2, $3, '[)') "+
+			"AND seats <@ int4range($4, $5, '[]') AND during && tstzrange($6, $7, '[)')",
+		sql)
+	assert.Equal(t, []interface{}{4, 1, 3, 1, 100, "2020-01-01", "2020-02-01"}, args)
+}
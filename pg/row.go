@@ -0,0 +1,36 @@
+package pg
+
+import (
+	"strings"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// Row builds a Postgres ROW expression of values, which may be Sqlizers
+// themselves
+// Ex:
+//     pg.Row(1, "foo") == "ROW(?,?)"
+//     pg.Row(1, "foo").Cast("my_type") == "ROW(?,?)::my_type"
+func Row(values ...interface{}) rowOp {
+	return rowOp{values: values}
+}
+
+type rowOp struct {
+	values []interface{}
+	tpe    string
+}
+
+// Cast casts the row to the composite type tpe.
+func (r rowOp) Cast(tpe string) rowOp {
+	r.tpe = tpe
+	return r
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (r rowOp) ToSql() (string, []interface{}, error) {
+	sql := "ROW(" + strings.TrimSuffix(strings.Repeat("?,", len(r.values)), ",") + ")"
+	if r.tpe != "" {
+		sql += "::" + r.tpe
+	}
+	return sqrl.Expr(sql, r.values...).ToSql()
+}
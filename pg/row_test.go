@@ -0,0 +1,52 @@
+package pg_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRow(t *testing.T) {
+	valid := []struct {
+		op   sqrl.Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{pg.Row(), "ROW()", nil},
+		{pg.Row(1, "foo"), "ROW(?,?)", []interface{}{1, "foo"}},
+		{pg.Row(1, "foo").Cast("my_type"), "ROW(?,?)::my_type", []interface{}{1, "foo"}},
+		{pg.Row(sqrl.Expr("now()"), pg.Array([]int{1})), "ROW(now(),?)", []interface{}{"{1}"}},
+		{pg.Row(1, pg.Row("a", nil).Cast("inner_type")).Cast("outer_type"), "ROW(?,ROW(?,?)::inner_type)::outer_type", []interface{}{1, "a", nil}},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test.op)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func TestRowInQuery(t *testing.T) {
+	sql, args, err := sqrl.Insert("shipments").
+		Columns("id", "address").
+		Values(1, pg.Row("Main St", "42", "Springfield").Cast("address")).
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO shipments (id,address) VALUES ($1,ROW($2,$3,$4)::address)", sql)
+	assert.Equal(t, []interface{}{1, "Main St", "42", "Springfield"}, args)
+
+	sql, args, err = sqrl.Select("id").From("shipments").
+		Where(sqrl.Expr("address = ?", pg.Row("Main St", "42", "Springfield").Cast("address"))).
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM shipments WHERE address = ROW($1,$2,$3)::address", sql)
+	assert.Equal(t, []interface{}{"Main St", "42", "Springfield"}, args)
+}
@@ -0,0 +1,105 @@
+package pg
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"reflect"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// UUID converts value into Postgres uuid
+//
+// Valid values are [16]byte, like uuid.UUID, strings and
+// encoding.TextMarshaler types rendering a UUID
+func UUID(value interface{}) sqrl.Sqlizer {
+	return uuidOp{value}
+}
+
+type uuidOp struct {
+	value interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (u uuidOp) ToSql() (string, []interface{}, error) {
+	s, err := formatUUID(u.value)
+	if err != nil {
+		return "", nil, err
+	}
+	return "?::uuid", []interface{}{s}, nil
+}
+
+// UUIDArray converts values into Postgres uuid[]
+//
+// Valid values are slices or arrays of the values UUID accepts
+func UUIDArray(values interface{}) sqrl.Sqlizer {
+	return uuidArray{values}
+}
+
+type uuidArray struct {
+	values interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (a uuidArray) ToSql() (string, []interface{}, error) {
+	v := reflect.ValueOf(a.values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", nil, fmt.Errorf("Expected value of type slice or array, got %s", v.Kind())
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteRune('{')
+	for i := 0; i < v.Len(); i++ {
+		s, err := formatUUID(v.Index(i).Interface())
+		if err != nil {
+			return "", nil, err
+		}
+		if i > 0 {
+			buf.WriteRune(',')
+		}
+		buf.WriteString(s)
+	}
+	buf.WriteRune('}')
+	return "?::uuid[]", []interface{}{buf.String()}, nil
+}
+
+// UUIDIn checks whether the uuid column is one of values, using = ANY
+// with a single uuid[] arg instead of a placeholder per value.
+// Ex:
+//     .Where(pg.UUIDIn("id", ids)) == "id = ANY(?::uuid[])"
+func UUIDIn(column string, values interface{}) sqrl.Sqlizer {
+	return sqrl.Expr(column+" = ANY(?)", UUIDArray(values))
+}
+
+// formatUUID formats value as canonical UUID string.
+func formatUUID(value interface{}) (string, error) {
+	v := reflect.ValueOf(value)
+	if v.IsValid() && isUUID(v.Type()) {
+		buf := &bytes.Buffer{}
+		marshalUUID(v, buf)
+		return buf.String(), nil
+	}
+
+	var s string
+	switch value := value.(type) {
+	case string:
+		s = value
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		s = string(text)
+	default:
+		return "", fmt.Errorf("Expected UUID of type [16]byte, string or encoding.TextMarshaler, got %T", value)
+	}
+
+	var u [16]byte
+	if err := unmarshalUUID(s, reflect.ValueOf(&u).Elem()); err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	marshalUUID(reflect.ValueOf(u), buf)
+	return buf.String(), nil
+}
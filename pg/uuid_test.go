@@ -0,0 +1,68 @@
+package pg_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+type textUUID string
+
+func (u textUUID) MarshalText() ([]byte, error) {
+	return []byte(u), nil
+}
+
+const testUUIDString = "0102e4f5-0000-4000-8000-00000000002a"
+
+func TestValidUUID(t *testing.T) {
+	valid := []sqrl.Sqlizer{
+		pg.UUID(testUUID),
+		pg.UUID(uuid(testUUID)),
+		pg.UUID(testUUIDString),
+		pg.UUID("0102E4F5-0000-4000-8000-00000000002A"),
+		pg.UUID("{0102e4f500004000800000000000002a}"),
+		pg.UUID(textUUID(testUUIDString)),
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.ToSql()
+
+		assert.NoError(t, err, "Unexpected error at case %v", test)
+		assert.Equal(t, "?::uuid", sql)
+		assert.Equal(t, []interface{}{testUUIDString}, args)
+	}
+}
+
+func TestInvalidUUID(t *testing.T) {
+	invalid := []sqrl.Sqlizer{
+		pg.UUID(nil),
+		pg.UUID(42),
+		pg.UUID("not-a-uuid"),
+		pg.UUID("0102e4f5-0000-4000-8000"),
+		pg.UUID([15]byte{}),
+		pg.UUIDArray("foo"),
+		pg.UUIDArray([]interface{}{testUUID, 42}),
+	}
+
+	for _, test := range invalid {
+		_, _, err := test.ToSql()
+		assert.NotNil(t, err, "Expected error at case %+v", test)
+	}
+}
+
+func TestUUIDIn(t *testing.T) {
+	sql, args, err := sqrl.Select("name").From("users").
+		Where(pg.UUIDIn("id", []interface{}{testUUID, "00000000-0000-0000-0000-000000000000"})).
+		PlaceholderFormat(sqrl.Dollar).
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT name FROM users WHERE id = ANY($1::uuid[])", sql)
+	assert.Equal(t, []interface{}{"{" + testUUIDString + ",00000000-0000-0000-0000-000000000000}"}, args)
+
+	_, args, err = pg.UUIDArray([]uuid{}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"{}"}, args)
+}
@@ -0,0 +1,38 @@
+package pg
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// XML converts value into Postgres XML
+//
+// Strings and []byte are taken as XML documents or fragments as they are,
+// nil is rendered as NULL and other values are marshalled with encoding/xml
+func XML(value interface{}) sqrl.Sqlizer {
+	return xmlOp{value}
+}
+
+type xmlOp struct {
+	value interface{}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (xo xmlOp) ToSql() (string, []interface{}, error) {
+	switch v := xo.value.(type) {
+	case nil:
+		return "?::xml", []interface{}{nil}, nil
+	case string:
+		return "?::xml", []interface{}{v}, nil
+	case []byte:
+		return "?::xml", []interface{}{string(v)}, nil
+	}
+
+	v, err := xml.Marshal(xo.value)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to serialize xml value: %v", err)
+	}
+	return "?::xml", []interface{}{string(v)}, nil
+}
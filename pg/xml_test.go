@@ -0,0 +1,45 @@
+package pg_test
+
+import (
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidXML(t *testing.T) {
+	sv := struct {
+		XMLName struct{} `xml:"book"`
+		Title   string   `xml:"title"`
+		ID      int      `xml:"id,attr"`
+	}{
+		Title: "Dune & co",
+		ID:    42,
+	}
+
+	valid := []struct {
+		op    sqrl.Sqlizer
+		value interface{}
+	}{
+		{pg.XML("<a>foo</a>"), "<a>foo</a>"},
+		{pg.XML([]byte("<a/>")), "<a/>"},
+		{pg.XML(nil), nil},
+		{pg.XML(sv), `<book id="42"><title>Dune &amp; co</title></book>`},
+	}
+
+	for _, test := range valid {
+		sql, args, err := test.op.ToSql()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "?::xml", sql)
+		assert.Equal(t, []interface{}{test.value}, args)
+	}
+}
+
+func TestInvalidXML(t *testing.T) {
+	sql, args, err := pg.XML(map[string]int{"a": 1}).ToSql()
+	assert.Error(t, err)
+	assert.Empty(t, sql)
+	assert.Nil(t, args)
+}
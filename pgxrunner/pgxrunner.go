@@ -0,0 +1,224 @@
+// Package pgxrunner runs sqrl queries with pgx without going through
+// database/sql.
+//
+// The Runner interfaces of sqrl are bound to database/sql result types, so
+// a pgx connection can not be passed to RunWith. Use the functions of this
+// package, or a Runner, instead:
+//
+//     pool, _ := pgxpool.Connect(ctx, url)
+//     rows, err := pgxrunner.Query(ctx, pool, pgxrunner.StatementBuilder.Select("*").From("users"))
+package pgxrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// Querier is the subset of the pgx API used to run queries.
+// It is implemented by *pgx.Conn, *pgxpool.Pool, *pgxpool.Conn and pgx.Tx.
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// StatementBuilder is a sqrl.StatementBuilderType using the Dollar
+// placeholder format expected by pgx.
+var StatementBuilder = sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar)
+
+// Exec builds s and executes it with q.
+func Exec(ctx context.Context, q Querier, s sqrl.Sqlizer) (pgconn.CommandTag, error) {
+	query, args, err := s.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	return q.Exec(ctx, query, args...)
+}
+
+// Query builds s and queries it with q.
+func Query(ctx context.Context, q Querier, s sqrl.Sqlizer) (pgx.Rows, error) {
+	query, args, err := s.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	return q.Query(ctx, query, args...)
+}
+
+// QueryRow builds s and queries a single row with q. Errors building the
+// query are returned by Scan.
+func QueryRow(ctx context.Context, q Querier, s sqrl.Sqlizer) pgx.Row {
+	query, args, err := s.ToSql()
+	if err != nil {
+		return &errRow{err}
+	}
+	return q.QueryRow(ctx, query, args...)
+}
+
+// BatchSender is the interface that wraps the SendBatch method.
+// It is implemented by *pgx.Conn, *pgxpool.Pool, *pgxpool.Conn and pgx.Tx.
+type BatchSender interface {
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+// SendBatch builds the statements of b and sends them with q as a pgx batch,
+// in a single round trip. It returns the command tags of the statements
+// executed before the first failure, and a *sqrl.BatchError if a statement
+// could not be built or failed.
+func SendBatch(ctx context.Context, q BatchSender, b *sqrl.Batch) ([]pgconn.CommandTag, error) {
+	batch := &pgx.Batch{}
+	for i, s := range b.Statements() {
+		query, args, err := s.ToSql()
+		if err != nil {
+			return nil, &sqrl.BatchError{Index: i, Err: err}
+		}
+		batch.Queue(query, args...)
+	}
+
+	results := q.SendBatch(ctx, batch)
+	defer results.Close()
+
+	tags := make([]pgconn.CommandTag, 0, b.Len())
+	for i := 0; i < b.Len(); i++ {
+		tag, err := results.Exec()
+		if err != nil {
+			return tags, &sqrl.BatchError{Index: i, Err: err}
+		}
+		tags = append(tags, tag)
+	}
+	return tags, results.Close()
+}
+
+// CopyFromer is the interface that wraps the CopyFrom method.
+// It is implemented by *pgx.Conn, *pgxpool.Pool, *pgxpool.Conn and pgx.Tx.
+type CopyFromer interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// CopyFrom loads rows into columns of table with the COPY protocol and
+// returns the number of rows copied. table may be qualified by its schema
+// and its parts may be quoted, e.g. `public."Users"`. Unquoted parts are
+// folded to lower case, like PostgreSQL does.
+func CopyFrom(ctx context.Context, q CopyFromer, table string, columns []string, rows [][]interface{}) (int64, error) {
+	ident, err := parseIdentifier(table)
+	if err != nil {
+		return 0, err
+	}
+	return q.CopyFrom(ctx, ident, columns, pgx.CopyFromRows(rows))
+}
+
+// parseIdentifier splits the qualified name into its parts, unquoting
+// double quoted ones.
+func parseIdentifier(qualified string) (pgx.Identifier, error) {
+	var ident pgx.Identifier
+	name := qualified
+	for {
+		var part string
+		if strings.HasPrefix(name, `"`) {
+			end := 1
+			for {
+				i := strings.IndexByte(name[end:], '"')
+				if i < 0 {
+					return nil, fmt.Errorf("unterminated quoted identifier in %q", qualified)
+				}
+				end += i + 1
+				if !strings.HasPrefix(name[end:], `"`) {
+					break
+				}
+				end++
+			}
+			part = strings.Replace(name[1:end-1], `""`, `"`, -1)
+			name = name[end:]
+		} else {
+			end := strings.IndexByte(name, '.')
+			if end < 0 {
+				end = len(name)
+			}
+			part = strings.ToLower(name[:end])
+			name = name[end:]
+		}
+		if part == "" {
+			return nil, fmt.Errorf("empty identifier in %q", qualified)
+		}
+		ident = append(ident, part)
+
+		if name == "" {
+			return ident, nil
+		}
+		if name[0] != '.' {
+			return nil, fmt.Errorf("invalid identifier %q", qualified)
+		}
+		name = name[1:]
+	}
+}
+
+// Runner binds a Querier, so queries can be run like with sqrl's RunWith.
+type Runner struct {
+	q Querier
+}
+
+// New returns a Runner executing queries with q.
+func New(q Querier) *Runner {
+	return &Runner{q}
+}
+
+// Exec builds s and executes it.
+func (r *Runner) Exec(ctx context.Context, s sqrl.Sqlizer) (pgconn.CommandTag, error) {
+	return Exec(ctx, r.q, s)
+}
+
+// Query builds s and queries it.
+func (r *Runner) Query(ctx context.Context, s sqrl.Sqlizer) (pgx.Rows, error) {
+	return Query(ctx, r.q, s)
+}
+
+// QueryRow builds s and queries a single row.
+func (r *Runner) QueryRow(ctx context.Context, s sqrl.Sqlizer) pgx.Row {
+	return QueryRow(ctx, r.q, s)
+}
+
+// Scan is a shortcut for QueryRow(ctx, s).Scan(dest...).
+func (r *Runner) Scan(ctx context.Context, s sqrl.Sqlizer, dest ...interface{}) error {
+	return QueryRow(ctx, r.q, s).Scan(dest...)
+}
+
+// SendBatch sends the statements of b as a pgx batch. The Querier must
+// implement BatchSender.
+//
+// See SendBatch.
+func (r *Runner) SendBatch(ctx context.Context, b *sqrl.Batch) ([]pgconn.CommandTag, error) {
+	q, ok := r.q.(BatchSender)
+	if !ok {
+		return nil, errNotBatchSender
+	}
+	return SendBatch(ctx, q, b)
+}
+
+// CopyFrom loads rows with the COPY protocol. The Querier must implement
+// CopyFromer. It makes Runner a pg.Copier, to be used with pg.CopyFrom.
+//
+// See CopyFrom.
+func (r *Runner) CopyFrom(ctx context.Context, table string, columns []string, rows [][]interface{}) (int64, error) {
+	q, ok := r.q.(CopyFromer)
+	if !ok {
+		return 0, errNotCopyFromer
+	}
+	return CopyFrom(ctx, q, table, columns, rows)
+}
+
+var errNotBatchSender = errors.New("cannot SendBatch; Querier is not a BatchSender")
+
+var errNotCopyFromer = errors.New("cannot CopyFrom; Querier is not a CopyFromer")
+
+type errRow struct {
+	err error
+}
+
+func (r *errRow) Scan(...interface{}) error {
+	return r.err
+}
@@ -0,0 +1,148 @@
+package pgxrunner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/stretchr/testify/assert"
+)
+
+type querierStub struct {
+	lastSql  string
+	lastArgs []interface{}
+}
+
+func (s *querierStub) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	s.lastSql, s.lastArgs = sql, args
+	return pgconn.CommandTag("UPDATE 1"), nil
+}
+
+func (s *querierStub) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	s.lastSql, s.lastArgs = sql, args
+	return nil, nil
+}
+
+func (s *querierStub) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	s.lastSql, s.lastArgs = sql, args
+	return &errRow{}
+}
+
+func TestRunner(t *testing.T) {
+	q := &querierStub{}
+	r := New(q)
+	ctx := context.Background()
+
+	tag, err := r.Exec(ctx, StatementBuilder.Update("users").Set("name", "Joe").Where(sqrl.Eq{"id": 1}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), tag.RowsAffected())
+	assert.Equal(t, "UPDATE users SET name = $1 WHERE id = $2", q.lastSql)
+	assert.Equal(t, []interface{}{"Joe", 1}, q.lastArgs)
+
+	_, err = r.Query(ctx, StatementBuilder.Select("id").From("users").Where("name = ?", "Joe"))
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE name = $1", q.lastSql)
+
+	assert.NoError(t, r.Scan(ctx, StatementBuilder.Select("1")))
+	assert.Equal(t, "SELECT 1", q.lastSql)
+}
+
+func TestRunnerToSqlErr(t *testing.T) {
+	r := New(&querierStub{})
+	ctx := context.Background()
+
+	_, err := r.Exec(ctx, sqrl.Select())
+	assert.Error(t, err)
+
+	_, err = r.Query(ctx, sqrl.Select())
+	assert.Error(t, err)
+
+	assert.Error(t, r.QueryRow(ctx, sqrl.Select()).Scan())
+}
+
+type batchResultsStub struct {
+	pgx.BatchResults
+	tags []pgconn.CommandTag
+}
+
+func (r *batchResultsStub) Exec() (pgconn.CommandTag, error) {
+	if len(r.tags) == 0 {
+		return nil, errors.New("no more results")
+	}
+	tag := r.tags[0]
+	r.tags = r.tags[1:]
+	return tag, nil
+}
+
+func (r *batchResultsStub) Close() error {
+	return nil
+}
+
+type batchSenderStub struct {
+	querierStub
+	queued int
+}
+
+func (s *batchSenderStub) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	s.queued = b.Len()
+	return &batchResultsStub{tags: []pgconn.CommandTag{pgconn.CommandTag("INSERT 0 1")}}
+}
+
+func TestSendBatch(t *testing.T) {
+	q := &batchSenderStub{}
+	b := sqrl.NewBatch(
+		StatementBuilder.Insert("users").Values(1),
+		StatementBuilder.Delete("sessions").Where(sqrl.Eq{"user_id": 1}),
+	)
+
+	tags, err := New(q).SendBatch(context.Background(), b)
+	assert.Equal(t, 2, q.queued)
+	assert.Len(t, tags, 1)
+
+	var batchErr *sqrl.BatchError
+	if assert.True(t, errors.As(err, &batchErr)) {
+		assert.Equal(t, 1, batchErr.Index)
+	}
+}
+
+type copyFromerStub struct {
+	querierStub
+	table   pgx.Identifier
+	columns []string
+}
+
+func (s *copyFromerStub) CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, src pgx.CopyFromSource) (int64, error) {
+	s.table, s.columns = table, columns
+	var n int64
+	for src.Next() {
+		n++
+	}
+	return n, src.Err()
+}
+
+func TestCopyFrom(t *testing.T) {
+	q := &copyFromerStub{}
+	n, err := New(q).CopyFrom(context.Background(), "public.users", []string{"id", "name"}, [][]interface{}{{1, "Joe"}, {2, "Ann"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, pgx.Identifier{"public", "users"}, q.table)
+	assert.Equal(t, []string{"id", "name"}, q.columns)
+
+	_, err = New(&querierStub{}).CopyFrom(context.Background(), "users", []string{"id"}, nil)
+	assert.Error(t, err)
+}
+
+func TestParseIdentifier(t *testing.T) {
+	ident, err := parseIdentifier(`Public."My ""Users"".v2"`)
+	assert.NoError(t, err)
+	assert.Equal(t, pgx.Identifier{"public", `My "Users".v2`}, ident)
+
+	for _, name := range []string{"", "public.", `"users`, `"a"b`, "a..b"} {
+		_, err = parseIdentifier(name)
+		assert.Error(t, err, name)
+	}
+}
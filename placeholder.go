@@ -3,13 +3,18 @@ package sqrl
 import (
 	"bytes"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // PlaceholderFormat is the interface that wraps the ReplacePlaceholders method.
 //
 // ReplacePlaceholders takes a SQL statement and replaces each question mark
-// placeholder with a (possibly different) SQL placeholder.
+// placeholder with a (possibly different) SQL placeholder. Numbered formats
+// turn the escaped literal ?? into a single ?, e.g. for PostgreSQL JSONB
+// operators like ?| and ?&.
 type PlaceholderFormat interface {
 	ReplacePlaceholders(sql string) (string, error)
 }
@@ -22,8 +27,18 @@ var (
 	// Dollar is a PlaceholderFormat instance that replaces placeholders with
 	// dollar-prefixed positional placeholders (e.g. $1, $2, $3).
 	Dollar = dollarFormat{}
+
+	// Colon is a PlaceholderFormat instance that replaces placeholders with
+	// colon-prefixed positional placeholders (e.g. :1, :2, :3) as used by Oracle.
+	Colon = colonFormat{}
 )
 
+// numberedFormat is implemented by PlaceholderFormats whose placeholders
+// carry their position, e.g. $1
+type numberedFormat interface {
+	writePlaceholder(buf *bytes.Buffer, i int) error
+}
+
 type questionFormat struct{}
 
 func (_ questionFormat) ReplacePlaceholders(sql string) (string, error) {
@@ -33,10 +48,169 @@ func (_ questionFormat) ReplacePlaceholders(sql string) (string, error) {
 type dollarFormat struct{}
 
 func (_ dollarFormat) ReplacePlaceholders(sql string) (string, error) {
-	return replacePlaceholders(sql, func(buf *bytes.Buffer, i int) error {
-		fmt.Fprintf(buf, "$%d", i)
-		return nil
+	return replaceNumbered(sql, '$'), nil
+}
+
+func (_ dollarFormat) writePlaceholder(buf *bytes.Buffer, i int) error {
+	buf.WriteByte('$')
+	buf.WriteString(strconv.Itoa(i))
+	return nil
+}
+
+type colonFormat struct{}
+
+func (_ colonFormat) ReplacePlaceholders(sql string) (string, error) {
+	return replaceNumbered(sql, ':'), nil
+}
+
+func (_ colonFormat) writePlaceholder(buf *bytes.Buffer, i int) error {
+	buf.WriteByte(':')
+	buf.WriteString(strconv.Itoa(i))
+	return nil
+}
+
+// FuncPlaceholderFormat returns a PlaceholderFormat which calls fn to write
+// the placeholder at position idx (starting at 1) into buf. Escaped ??
+// literals are turned into a single ? before fn is involved.
+// Ex:
+//     FuncPlaceholderFormat(func(buf *bytes.Buffer, idx int) error {
+//         fmt.Fprintf(buf, "{{arg%d}}", idx)
+//         return nil
+//     })
+func FuncPlaceholderFormat(fn func(buf *bytes.Buffer, idx int) error) PlaceholderFormat {
+	return funcFormat(fn)
+}
+
+type funcFormat func(buf *bytes.Buffer, idx int) error
+
+func (f funcFormat) ReplacePlaceholders(sql string) (string, error) {
+	return replacePlaceholders(sql, f)
+}
+
+func (f funcFormat) writePlaceholder(buf *bytes.Buffer, i int) error {
+	return f(buf, i)
+}
+
+// argsFormat is implemented by PlaceholderFormats which rewrite the args
+// together with the placeholders
+type argsFormat interface {
+	replacePlaceholdersArgs(sql string, args []interface{}) (string, []interface{}, error)
+}
+
+// replaceFormat applies f to the SQL and args of a whole statement. Empty
+// args, e.g. preallocated ones, are returned as nil.
+func replaceFormat(f PlaceholderFormat, sql string, args []interface{}) (string, []interface{}, error) {
+	if len(args) == 0 {
+		args = nil
+	}
+	if af, ok := f.(argsFormat); ok {
+		return af.replacePlaceholdersArgs(sql, args)
+	}
+
+	sql, err := f.ReplacePlaceholders(sql)
+	return sql, args, err
+}
+
+// Dedup returns a PlaceholderFormat which binds identical args only once and
+// refers to them by the same numbered placeholder, e.g.
+// "a = $1 OR b = $1" instead of "a = $1 OR b = $2". Args which are not
+// bools, numbers, strings or time.Time, like slices or sql.NamedArg, are
+// never collapsed. Note that PostgreSQL infers a single type per parameter,
+// so a value reused in differently typed contexts may need an explicit cast.
+//
+// f is returned unchanged if it is not a numbered format like Dollar or Colon.
+// Ex:
+//     .PlaceholderFormat(Dedup(Dollar))
+func Dedup(f PlaceholderFormat) PlaceholderFormat {
+	nf, ok := f.(numberedFormat)
+	if !ok {
+		return f
+	}
+	return dedupFormat{f, nf}
+}
+
+type dedupFormat struct {
+	PlaceholderFormat
+	nf numberedFormat
+}
+
+func (df dedupFormat) writePlaceholder(buf *bytes.Buffer, i int) error {
+	return df.nf.writePlaceholder(buf, i)
+}
+
+func (df dedupFormat) replacePlaceholdersArgs(sql string, args []interface{}) (string, []interface{}, error) {
+	positions := make(map[interface{}]int)
+	deduped := make([]interface{}, 0, len(args))
+
+	sql, err := replacePlaceholders(sql, func(buf *bytes.Buffer, i int) error {
+		if i > len(args) {
+			return fmt.Errorf("placeholder %d has no arg, only %d given", i, len(args))
+		}
+		arg := args[i-1]
+
+		hashable := isScalarArg(arg)
+		if hashable {
+			if pos, ok := positions[arg]; ok {
+				return df.nf.writePlaceholder(buf, pos)
+			}
+		}
+
+		deduped = append(deduped, arg)
+		if hashable {
+			positions[arg] = len(deduped)
+		}
+		return df.nf.writePlaceholder(buf, len(deduped))
 	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sql, deduped, nil
+}
+
+// isScalarArg reports whether arg is a bool, number, string or time.Time,
+// which can safely be used as a map key. Other args, like slices or structs
+// and interfaces holding them, would panic.
+func isScalarArg(arg interface{}) bool {
+	if _, ok := arg.(time.Time); ok {
+		return true
+	}
+	if arg == nil {
+		return false
+	}
+	switch reflect.TypeOf(arg).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+type formatted struct {
+	f PlaceholderFormat
+	s Sqlizer
+}
+
+// ReplaceFor returns a Sqlizer which renders s with placeholder format f, so
+// standalone expressions like Eq or And can be passed to e.g. pgx directly.
+//
+// The result is meant to be the outermost part of a query. Nesting it into a
+// builder would number its placeholders twice.
+// Ex:
+//     ReplaceFor(Dollar, And{Eq{"a": 1}, Gt{"b": 2}}) == "(a = $1 AND b > $2)"
+func ReplaceFor(f PlaceholderFormat, s Sqlizer) Sqlizer {
+	return formatted{f, s}
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (fs formatted) ToSql() (string, []interface{}, error) {
+	sql, args, err := fs.s.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return replaceFormat(fs.f, sql, args)
 }
 
 // Placeholders returns a string with count ? placeholders joined with commas.
@@ -48,8 +222,78 @@ func Placeholders(count int) string {
 	return strings.Repeat(",?", count)[1:]
 }
 
-func replacePlaceholders(sql string, replace func(buf *bytes.Buffer, i int) error) (string, error) {
+// PlaceholdersFmt returns a string with count placeholders of format f joined
+// with commas. Numbered formats start counting at start, so snippets with
+// their own placeholders can be aligned with the rest of the query.
+// Ex:
+//     PlaceholdersFmt(3, Dollar, 4) == "$4,$5,$6"
+func PlaceholdersFmt(count int, f PlaceholderFormat, start int) string {
+	if count < 1 {
+		return ""
+	}
+
+	nf, ok := f.(numberedFormat)
+	if !ok {
+		sql, _ := f.ReplacePlaceholders(Placeholders(count))
+		return sql
+	}
+
 	buf := &bytes.Buffer{}
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		nf.writePlaceholder(buf, start+i)
+	}
+	return buf.String()
+}
+
+// replaceNumbered replaces placeholders with prefix followed by the position
+// of the placeholder in a single pass over sql.
+func replaceNumbered(sql string, prefix byte) string {
+	if strings.IndexByte(sql, '?') == -1 {
+		return sql
+	}
+
+	var num [20]byte
+	buf := &strings.Builder{}
+	// Placeholders grow by at least one byte each
+	buf.Grow(len(sql) + len(sql)/4)
+
+	n := int64(0)
+	for {
+		p := strings.IndexByte(sql, '?')
+		if p == -1 {
+			break
+		}
+		buf.WriteString(sql[:p])
+
+		if p+1 < len(sql) && sql[p+1] == '?' { // escape ?? => ?
+			buf.WriteByte('?')
+			sql = sql[p+2:]
+			continue
+		}
+
+		n++
+		buf.WriteByte(prefix)
+		buf.Write(strconv.AppendInt(num[:0], n, 10))
+		sql = sql[p+1:]
+	}
+
+	buf.WriteString(sql)
+	return buf.String()
+}
+
+func replacePlaceholders(sql string, replace func(buf *bytes.Buffer, i int) error) (string, error) {
+	return replacePlaceholdersEscaped(sql, "?", replace)
+}
+
+// replacePlaceholdersEscaped calls replace for every ? placeholder in sql and
+// writes escaped for every escaped ?? literal. Nested parts of a query keep
+// the escaped literal "??" so the outer PlaceholderFormat can unescape it.
+func replacePlaceholdersEscaped(sql string, escaped string, replace func(buf *bytes.Buffer, i int) error) (string, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
 	i := 0
 	for {
 		p := strings.Index(sql, "?")
@@ -59,10 +303,7 @@ func replacePlaceholders(sql string, replace func(buf *bytes.Buffer, i int) erro
 
 		if len(sql[p:]) > 1 && sql[p:p+2] == "??" { // escape ?? => ?
 			buf.WriteString(sql[:p])
-			buf.WriteString("?")
-			if len(sql[p:]) == 1 {
-				break
-			}
+			buf.WriteString(escaped)
 			sql = sql[p+2:]
 		} else {
 			i++
@@ -1,8 +1,12 @@
 package sqrl
 
 import (
+	"bytes"
+	"database/sql"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -19,6 +23,12 @@ func TestDollar(t *testing.T) {
 	assert.Equal(t, "x = $1 AND y = $2", s)
 }
 
+func TestColon(t *testing.T) {
+	sql := "x = ? AND y = ?"
+	s, _ := Colon.ReplacePlaceholders(sql)
+	assert.Equal(t, "x = :1 AND y = :2", s)
+}
+
 func TestPlaceholders(t *testing.T) {
 	assert.Equal(t, Placeholders(2), "?,?")
 }
@@ -29,6 +39,13 @@ func TestEscape(t *testing.T) {
 	assert.Equal(t, "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ?| array['$1'] AND enabled = $2", s)
 }
 
+func TestPlaceholdersFmt(t *testing.T) {
+	assert.Equal(t, "$4,$5,$6", PlaceholdersFmt(3, Dollar, 4))
+	assert.Equal(t, ":1,:2", PlaceholdersFmt(2, Colon, 1))
+	assert.Equal(t, "?,?", PlaceholdersFmt(2, Question, 7))
+	assert.Equal(t, "", PlaceholdersFmt(0, Dollar, 1))
+}
+
 func BenchmarkPlaceholdersArray(b *testing.B) {
 	var count = b.N
 	placeholders := make([]string, count)
@@ -41,3 +58,109 @@ func BenchmarkPlaceholdersArray(b *testing.B) {
 func BenchmarkPlaceholdersStrings(b *testing.B) {
 	Placeholders(b.N)
 }
+
+func TestEscapeNestedExpr(t *testing.T) {
+	subQ := Select("tags").From("presets").Where("id = ?", 2)
+	b := Select("id").
+		From("nodes").
+		Where("kind = ?", 1).
+		Where(Expr("data->'tags' ??| ARRAY(?) AND data ?? 'x' AND enabled = ?", subQ, true)).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT id FROM nodes WHERE kind = $1 AND " +
+		"data->'tags' ?| ARRAY(SELECT tags FROM presets WHERE id = $2) AND data ? 'x' AND enabled = $3"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{1, 2, true}, args)
+
+	sql, _, err = b.PlaceholderFormat(Colon).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM nodes WHERE kind = :1 AND "+
+		"data->'tags' ?| ARRAY(SELECT tags FROM presets WHERE id = :2) AND data ? 'x' AND enabled = :3", sql)
+}
+
+func BenchmarkDollarReplacePlaceholders(b *testing.B) {
+	ib := Insert("t").Columns("a", "b", "c", "d", "e")
+	for i := 0; i < 1000; i++ {
+		ib.Values(1, 2, 3, 4, 5)
+	}
+	sql, _, _ := ib.ToSql()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Dollar.ReplacePlaceholders(sql)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	b := Select("*").
+		From("orders").
+		Where(Eq{"tenant_id": 7}).
+		Where(Or{Eq{"owner": 7}, Eq{"tags": []string{"a", "b"}}}).
+		Where(Expr("payload = ? OR payload = ?", []byte("x"), []byte("x"))).
+		Where("state = ?", nil).
+		Where("state <> ?", nil).
+		PlaceholderFormat(Dedup(Dollar))
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT * FROM orders WHERE tenant_id = $1 AND (owner = $1 OR tags IN ($2,$3)) " +
+		"AND payload = $4 OR payload = $5 AND state = $6 AND state <> $7"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{7, "a", "b", []byte("x"), []byte("x"), nil, nil}, args)
+
+	assert.Equal(t, Question, Dedup(Question))
+}
+
+func TestDedupUnhashable(t *testing.T) {
+	now := time.Now()
+	named := sql.Named("x", []int{1})
+	query, args, err := Select("*").
+		Where("a = ? OR b = ?", named, named).
+		Where("c = ? OR d = ?", now, now).
+		PlaceholderFormat(Dedup(Dollar)).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * WHERE a = $1 OR b = $2 AND c = $3 OR d = $3", query)
+	assert.Equal(t, []interface{}{named, named, now}, args)
+}
+
+func TestFuncPlaceholderFormat(t *testing.T) {
+	f := FuncPlaceholderFormat(func(buf *bytes.Buffer, idx int) error {
+		fmt.Fprintf(buf, "{{arg%d}}", idx)
+		return nil
+	})
+
+	sql, err := f.ReplacePlaceholders("a = ? AND b ?? c AND d = ?")
+	assert.NoError(t, err)
+	assert.Equal(t, "a = {{arg1}} AND b ? c AND d = {{arg2}}", sql)
+
+	assert.Equal(t, "{{arg3}},{{arg4}}", PlaceholdersFmt(2, f, 3))
+
+	sql, args, err := Select("*").Where("a = ? OR b = ?", 1, 1).PlaceholderFormat(Dedup(f)).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * WHERE a = {{arg1}} OR b = {{arg1}}", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	failing := FuncPlaceholderFormat(func(buf *bytes.Buffer, idx int) error {
+		return fmt.Errorf("too many placeholders")
+	})
+	_, err = failing.ReplacePlaceholders("a = ?")
+	assert.Error(t, err)
+}
+
+func TestReplaceFor(t *testing.T) {
+	sql, args, err := ReplaceFor(Dollar, And{Eq{"a": 1}, Gt{"b": 2}, Expr("c ?? 'x'")}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(a = $1 AND b > $2 AND c ? 'x')", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, args, err = ReplaceFor(Dedup(Colon), Expr("a = ? OR b = ?", 3, 3)).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "a = :1 OR b = :1", sql)
+	assert.Equal(t, []interface{}{3}, args)
+}
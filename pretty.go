@@ -0,0 +1,154 @@
+package sqrl
+
+import (
+	"strings"
+)
+
+// clauseKeywords start a new line when pretty printing.
+var clauseKeywords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "GROUP": true,
+	"HAVING": true, "ORDER": true, "LIMIT": true, "OFFSET": true,
+	"JOIN": true, "LEFT": true, "RIGHT": true, "INNER": true, "FULL": true,
+	"CROSS": true, "UNION": true, "INTERSECT": true, "EXCEPT": true,
+	"INSERT": true, "UPDATE": true, "DELETE": true, "SET": true,
+	"VALUES": true, "RETURNING": true, "WITH": true,
+}
+
+// continuations are keywords which don't start a new line after prev.
+var continuations = map[string]map[string]bool{
+	"JOIN":   {"LEFT": true, "RIGHT": true, "INNER": true, "FULL": true, "CROSS": true, "OUTER": true, "NATURAL": true},
+	"FROM":   {"DELETE": true},
+	"UPDATE": {"DO": true, "FOR": true},
+}
+
+// PrettySQL formats sql, as returned by ToSql, for humans: every clause
+// starts on its own line, AND and OR conditions are put on their own
+// indented line and subqueries are indented.
+// Ex:
+//     PrettySQL("SELECT a FROM t WHERE b = ? AND c IN (SELECT c FROM u)")
+//     == "SELECT a\nFROM t\nWHERE b = ?\n  AND c IN (\n    SELECT c\n    FROM u\n  )"
+func PrettySQL(sql string) string {
+	tokens := sqlTokens(sql)
+	buf := &strings.Builder{}
+
+	// parens holds whether each open paren is a subquery
+	var parens []bool
+	// indents holds the indentation of the open subqueries
+	indents := []int{0}
+	inClauses := func() bool {
+		return len(parens) == 0 || parens[len(parens)-1]
+	}
+	newline := func(indent int) {
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(strings.Repeat("  ", indent))
+	}
+
+	prev := ""
+	between := false
+	for i, t := range tokens {
+		word := strings.ToUpper(t.text)
+
+		switch {
+		case t.text == "(":
+			subquery := i+1 < len(tokens) && (strings.EqualFold(tokens[i+1].text, "SELECT") || strings.EqualFold(tokens[i+1].text, "WITH"))
+			if t.space {
+				buf.WriteByte(' ')
+			}
+			buf.WriteByte('(')
+			parens = append(parens, subquery)
+			if subquery {
+				indents = append(indents, lineIndent(buf)+1)
+				newline(indents[len(indents)-1])
+				prev = ""
+				continue
+			}
+
+		case t.text == ")":
+			if len(parens) > 0 {
+				if parens[len(parens)-1] {
+					newline(indents[len(indents)-1] - 1)
+					indents = indents[:len(indents)-1]
+				}
+				parens = parens[:len(parens)-1]
+			}
+			buf.WriteByte(')')
+
+		case inClauses() && clauseKeywords[word] && !continuations[word][strings.ToUpper(prev)] && buf.Len() > 0 && !atLineStart(buf):
+			newline(indents[len(indents)-1])
+			buf.WriteString(t.text)
+
+		case inClauses() && (word == "AND" && !between || word == "OR"):
+			newline(indents[len(indents)-1] + 1)
+			buf.WriteString(t.text)
+
+		default:
+			if t.space && !atLineStart(buf) {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(t.text)
+		}
+
+		switch word {
+		case "BETWEEN":
+			between = true
+		case "AND":
+			between = false
+		}
+		prev = t.text
+	}
+
+	return buf.String()
+}
+
+// lineIndent returns the indentation level of the last line of buf.
+func lineIndent(buf *strings.Builder) int {
+	s := buf.String()
+	line := s[strings.LastIndexByte(s, '\n')+1:]
+	return (len(line) - len(strings.TrimLeft(line, " "))) / 2
+}
+
+func atLineStart(buf *strings.Builder) bool {
+	s := buf.String()
+	return len(strings.TrimRight(s[strings.LastIndexByte(s, '\n')+1:], " ")) == 0
+}
+
+type sqlToken struct {
+	text  string
+	space bool // preceded by whitespace
+}
+
+// sqlTokens splits sql into words, quoted strings and identifiers, parens
+// and other characters.
+func sqlTokens(sql string) []sqlToken {
+	var tokens []sqlToken
+	space := false
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			i += quotedLen(sql, i, "") - 1
+		case isNameChar(c, false):
+			for i+1 < len(sql) && (isNameChar(sql[i+1], false) || sql[i+1] == '.') {
+				i++
+			}
+		case c == '(' || c == ')' || c == ',':
+		default:
+			for i+1 < len(sql) && !isNameChar(sql[i+1], false) && !strings.ContainsRune(" \t\n\r'\"`(),", rune(sql[i+1])) {
+				i++
+			}
+		}
+		end := i + 1
+		if end > len(sql) {
+			end = len(sql)
+		}
+		tokens = append(tokens, sqlToken{text: sql[start:end], space: space})
+		space = false
+	}
+	return tokens
+}
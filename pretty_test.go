@@ -0,0 +1,43 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrettySQL(t *testing.T) {
+	b := Select("a", "COUNT(*) OVER (PARTITION BY b ORDER BY c)").
+		From("t").
+		LeftJoin("u ON u.id = t.u_id").
+		Where("t.x BETWEEN ? AND ?", 1, 2).
+		Where(Or{Eq{"y": 1}, Expr("z IN (SELECT z FROM v WHERE w = 'a AND b')")}).
+		OrderBy("a").
+		Limit(10)
+
+	sql, _, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expected := `SELECT a, COUNT(*) OVER (PARTITION BY b ORDER BY c)
+FROM t
+LEFT JOIN u ON u.id = t.u_id
+WHERE t.x BETWEEN ? AND ?
+  AND (y = ? OR z IN (
+    SELECT z
+    FROM v
+    WHERE w = 'a AND b'
+  ))
+ORDER BY a
+LIMIT 10`
+	assert.Equal(t, expected, PrettySQL(sql))
+}
+
+func TestPrettySQLInsert(t *testing.T) {
+	sql, _, err := Insert("t").Columns("a", "b").Values(1, 2).Suffix("RETURNING id").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO t (a,b)\nVALUES (?,?)\nRETURNING id", PrettySQL(sql))
+
+	sql, _, err = Delete("t").Where("a = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM t\nWHERE a = ?", PrettySQL(sql))
+}
@@ -0,0 +1,194 @@
+package sqrl
+
+import "strings"
+
+// IdentifierQuoter is the interface that wraps the QuoteIdentifier method
+// of Dialect.
+type IdentifierQuoter interface {
+	// QuoteIdentifier quotes name, escaping the quote characters in it.
+	QuoteIdentifier(name string) string
+}
+
+// QuoteIdentifier quotes name as identifier of dialect d, e.g. with
+// backticks for MySQL or brackets for SQL Server. Without dialect name is
+// quoted with double quotes, like standard SQL.
+// Ex:
+//     QuoteIdentifier(MySQL, "order") == "`order`"
+//     QuoteIdentifier(Postgres, `a"b`) == `"a""b"`
+func QuoteIdentifier(d Dialect, name string) string {
+	if d != nil {
+		return d.QuoteIdentifier(name)
+	}
+	return quoteWith(name, `"`)
+}
+
+func (d namedDialect) QuoteIdentifier(name string) string {
+	switch d {
+	case MySQLName:
+		return quoteWith(name, "`")
+	case SQLServerName:
+		return "[" + strings.Replace(name, "]", "]]", -1) + "]"
+	}
+	return quoteWith(name, `"`)
+}
+
+func quoteWith(name, quote string) string {
+	return quote + strings.Replace(name, quote, quote+quote, -1) + quote
+}
+
+// QuoteIdentifiers makes child builders quote the table and column names
+// passed to them as strings, e.g. to Columns, From, OrderBy, Into or Set,
+// with the quote character of the dialect, or double quotes without
+// dialect. Strings which aren't identifiers, like expressions, names which
+// are quoted already and Expr are left as they are.
+//
+// Quoted names are case sensitive with most databases, e.g. "users" does not
+// match the table USERS on Oracle.
+// Ex:
+//     StatementBuilder.Dialect(SQLServer).QuoteIdentifiers().
+//         Select("id", "key").From("users u").Where("u.id = ?", 1)
+//     == "SELECT [id], [key] FROM [users] [u] WHERE u.id = ?"
+func (b StatementBuilderType) QuoteIdentifiers() StatementBuilderType {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// identifierQuoter returns the function quoting the identifiers of child
+// builders, or nil if they are put into the query as they are. Identifiers
+// are quoted with QuoteIdentifiers, and always with the MySQL dialect.
+func (b StatementBuilderType) identifierQuoter() func(string) string {
+	if !b.quoteIdentifiers && dialectName(b.dialect) != MySQLName {
+		return nil
+	}
+	return func(name string) string {
+		return QuoteIdentifier(b.dialect, name)
+	}
+}
+
+// quoteName quotes the names of s, if it is an identifier of kind.
+func (b StatementBuilderType) quoteName(s string, kind int) string {
+	quote := b.identifierQuoter()
+	if quote == nil {
+		return s
+	}
+	return quoteIdentifier(s, kind, quote)
+}
+
+// quoteNames is like quoteName for a list of strings.
+func (b StatementBuilderType) quoteNames(ss []string, kind int) []string {
+	quote := b.identifierQuoter()
+	if quote == nil {
+		return ss
+	}
+	quoted := make([]string, len(ss))
+	for i, s := range ss {
+		quoted[i] = quoteIdentifier(s, kind, quote)
+	}
+	return quoted
+}
+
+// quoteParts is like quoteName for the parts which were passed as strings
+// without args.
+func (b StatementBuilderType) quoteParts(parts []Sqlizer, kind int) []Sqlizer {
+	quote := b.identifierQuoter()
+	if quote == nil {
+		return parts
+	}
+	quoted := make([]Sqlizer, len(parts))
+	for i, p := range parts {
+		quoted[i] = p
+		if p, ok := p.(*part); ok && len(p.args) == 0 {
+			if s, ok := p.pred.(string); ok {
+				quoted[i] = newPart(quoteIdentifier(s, kind, quote))
+			}
+		}
+	}
+	return quoted
+}
+
+// literalKeywords are keywords which parse as identifiers, but must not be
+// quoted.
+var literalKeywords = map[string]bool{
+	"NULL":              true,
+	"TRUE":              true,
+	"FALSE":             true,
+	"DEFAULT":           true,
+	"DISTINCT":          true,
+	"CURRENT_DATE":      true,
+	"CURRENT_TIME":      true,
+	"CURRENT_TIMESTAMP": true,
+	"CURRENT_USER":      true,
+	"LOCALTIME":         true,
+	"LOCALTIMESTAMP":    true,
+}
+
+// quoteIdentifier quotes the names of s with quote, if s is an identifier
+// of kind whose names aren't quoted already.
+func quoteIdentifier(s string, kind int, quote func(string) string) string {
+	if strings.ContainsAny(s, "`\"[") {
+		return s
+	}
+	names, rest, ok := parseIdentifier(s)
+	if !ok || !checkIdentifierRest(rest, kind) {
+		return s
+	}
+	if len(names) == 1 && literalKeywords[strings.ToUpper(names[0])] {
+		return s
+	}
+
+	for i, name := range names {
+		if name != "*" {
+			names[i] = quote(name)
+		}
+	}
+	if kind == identAliased && rest != "" {
+		alias := rest[strings.LastIndexByte(rest, ' ')+1:]
+		rest = rest[:len(rest)-len(alias)] + quote(alias)
+	}
+	return strings.Join(names, ".") + rest
+}
+
+// quotedLen returns the length of the string literal or quoted identifier
+// starting at sql[i], including its quotes, or 0 if none starts there. It is
+// the scanner shared by the functions which look at SQL outside of quotes.
+//
+// Quotes are escaped by doubling them. MySQL strings, in single or double
+// quotes, and PostgreSQL E'...' strings also escape characters with
+// backslashes, and SQL Server quotes identifiers with brackets. Unterminated
+// quotes run to the end of sql.
+func quotedLen(sql string, i int, dialect string) int {
+	open := sql[i]
+	closing := open
+	backslash := false
+	switch open {
+	case '\'':
+		backslash = dialect == MySQLName ||
+			i > 0 && (sql[i-1] == 'E' || sql[i-1] == 'e') && (i == 1 || !isWordChar(sql[i-2]))
+	case '"':
+		backslash = dialect == MySQLName
+	case '`':
+	case '[':
+		if dialect != SQLServerName {
+			return 0
+		}
+		closing = ']'
+	default:
+		return 0
+	}
+
+	for j := i + 1; j < len(sql); j++ {
+		switch sql[j] {
+		case '\\':
+			if backslash {
+				j++
+			}
+		case closing:
+			if j+1 < len(sql) && sql[j+1] == closing {
+				j++
+				continue
+			}
+			return j + 1 - i
+		}
+	}
+	return len(sql) - i
+}
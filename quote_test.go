@@ -0,0 +1,103 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, "`order`", QuoteIdentifier(MySQL, "order"))
+	assert.Equal(t, "`a``b`", QuoteIdentifier(MySQL, "a`b"))
+	assert.Equal(t, `"a""b"`, QuoteIdentifier(Postgres, `a"b`))
+	assert.Equal(t, `"order"`, QuoteIdentifier(nil, "order"))
+	assert.Equal(t, "[a]]b]", QuoteIdentifier(SQLServer, "a]b"))
+}
+
+func TestDialectQuoteSelect(t *testing.T) {
+	sql, args, err := StatementBuilder.Dialect(MySQL).
+		Select("id", "o.order", "o.*", "COUNT(*) AS n", "NULL AS empty", "`key` k").
+		Column("IF(status = ?, 1, 0) AS active", "open").
+		From("orders AS o").
+		Join("users u ON u.id = o.user_id").
+		Where("o.group = ?", 1).
+		GroupBy("o.group").
+		OrderBy("order DESC NULLS LAST", "RAND()").
+		ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT `id`, `o`.`order`, `o`.*, COUNT(*) AS n, NULL AS empty, `key` k, "+
+		"IF(status = ?, 1, 0) AS active FROM `orders` AS `o` JOIN users u ON u.id = o.user_id "+
+		"WHERE o.group = ? GROUP BY `o`.`group` ORDER BY `order` DESC NULLS LAST, RAND()", sql)
+	assert.Equal(t, []interface{}{"open", 1}, args)
+}
+
+func TestDialectQuoteInsertUpdateDelete(t *testing.T) {
+	b := StatementBuilder.Dialect(MySQL)
+
+	sql, _, err := b.Insert("db.order").Columns("key", "value").Values(1, 2).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `db`.`order` (`key`,`value`) VALUES (?,?)", sql)
+
+	sql, _, err = b.Update("order o").Set("key", 1).Set("o.value", Expr("o.value + 1")).OrderBy("id").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `order` `o` SET `key` = ?, `o`.`value` = o.value + 1 ORDER BY `id`", sql)
+
+	sql, _, err = b.Delete("order").Where("id = ?", 1).OrderBy("id DESC").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM `order` WHERE id = ? ORDER BY `id` DESC", sql)
+
+	sql, _, err = Select("order").From("order").Dialect(Postgres).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT order FROM order", sql)
+}
+
+func TestQuoteIdentifiers(t *testing.T) {
+	sql, args, err := StatementBuilder.Dialect(SQLServer).QuoteIdentifiers().
+		Select("id", "key").From("users u").Where("u.id = ?", 1).OrderBy("key DESC").
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT [id], [key] FROM [users] [u] WHERE u.id = ? ORDER BY [key] DESC", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = StatementBuilder.Dialect(Postgres).QuoteIdentifiers().
+		Update("user").Set("order", 1).Set("total", Expr("total + 1")).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "user" SET "order" = $1, "total" = total + 1`, sql)
+
+	sql, _, err = Insert(`we"ird`).Columns("id", `"name"`).Values(1, 2).QuoteIdentifiers().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO we"ird ("id","name") VALUES (?,?)`, sql)
+
+	sql, _, err = StatementBuilder.Dialect(Oracle).QuoteIdentifiers().
+		Merge("users t").Using("staging s").On("t.id = s.id").
+		WhenMatched().Set("name", Expr("s.name")).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `MERGE INTO "users" "t" USING "staging" "s" ON (t.id = s.id) WHEN MATCHED THEN UPDATE SET "name" = s.name`, sql)
+}
+
+func TestQuotedLen(t *testing.T) {
+	cases := []struct {
+		sql     string
+		dialect string
+		want    int
+	}{
+		{"a = 1", "", 0},
+		{"'it''s' x", "", 7},
+		{`"a""b" x`, "", 6},
+		{"`a` x", MySQLName, 3},
+		{`'it\'s' x`, "", 5},
+		{`'it\'s' x`, MySQLName, 7},
+		{`"a\"b" x`, MySQLName, 6},
+		{"[a]]b] x", SQLServerName, 6},
+		{"[1] x", PostgresName, 0},
+		{"'open", "", 5},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, quotedLen(c.sql, 0, c.dialect), c.sql)
+	}
+	assert.Equal(t, 4, quotedLen(`E'\'' x`, 1, PostgresName))
+	assert.Equal(t, 3, quotedLen(`NE'\' x`, 2, PostgresName))
+}
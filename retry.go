@@ -0,0 +1,204 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures a RetryRunner.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a query is run at most, including
+	// the first attempt. Defaults to 3.
+	MaxAttempts int
+
+	// Backoff returns the delay before the given retry, starting at 1.
+	// Defaults to ExponentialBackoff(10ms, 1s).
+	Backoff func(retry int) time.Duration
+
+	// Retryable reports whether a query failing with err can be retried.
+	// Defaults to IsTransientError. Statements failing with a connection
+	// error are only retried if they are safe to run twice, see
+	// WithIdempotent.
+	Retryable func(err error) bool
+}
+
+// ExponentialBackoff returns a Backoff doubling the delay from base with
+// every retry, up to max.
+func ExponentialBackoff(base, max time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		d := base
+		for i := 1; i < retry && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		return d
+	}
+}
+
+// SQL states of transient errors, as reported by drivers like pgx.
+var transientSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"08000": true, // connection_exception
+	"08003": true, // connection_does_not_exist
+	"08006": true, // connection_failure
+}
+
+// IsTransientError reports whether err is likely to go away when the query
+// is retried: a serialization failure or deadlock reported through a
+// SQLState() string method, driver.ErrBadConn or a connection error, see
+// IsConnectionError.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || IsConnectionError(err) {
+		return true
+	}
+
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) {
+		return transientSQLStates[stateErr.SQLState()]
+	}
+	return false
+}
+
+// IsConnectionError reports whether err is a reset connection or a SQL
+// state of class 08, connection exception. The statement may have been run
+// by the database before the connection failed, so it isn't safe to retry
+// statements which aren't idempotent.
+//
+// driver.ErrBadConn is no connection error, drivers only return it if
+// nothing was sent to the database.
+func IsConnectionError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var stateErr interface{ SQLState() string }
+	return errors.As(err, &stateErr) && strings.HasPrefix(stateErr.SQLState(), "08")
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent returns a context marking the statements run with it as
+// idempotent, so a RetryRunner retries them after connection errors. Only
+// SELECT statements are retried after connection errors otherwise.
+// Ex:
+//     Update("users").Set("name", "Joe").Where(Eq{"id": 1}).RunWith(runner).ExecContext(WithIdempotent(ctx))
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// safeToRetry reports whether query can be run again after a connection
+// error: it reads only or was marked with WithIdempotent.
+func safeToRetry(ctx context.Context, query string) bool {
+	if idempotent, _ := ctx.Value(idempotentKey{}).(bool); idempotent {
+		return true
+	}
+	query = strings.TrimLeft(query, " \t\r\n(")
+	return len(query) >= 6 && strings.EqualFold(query[:6], "SELECT")
+}
+
+// RetryRunner wraps a runner and retries queries failing with a transient
+// error.
+//
+// Retrying a single statement inside a transaction is pointless once the
+// transaction is aborted, so RetryRunner should wrap a *sql.DB, not a *sql.Tx.
+//
+// A statement failing with a connection error may have been committed
+// already, so only SELECT statements and those run with a context from
+// WithIdempotent are retried then. Statements like
+// Set("balance", Expr("balance + ?", 10)) must not be marked idempotent.
+// Ex:
+//     runner := NewRetryRunner(db, RetryPolicy{MaxAttempts: 5})
+//     Update("users").Set("name", "Joe").Where(Eq{"id": 1}).RunWith(runner).ExecContext(WithIdempotent(ctx))
+type RetryRunner struct {
+	runner BaseRunnerContext
+	policy RetryPolicy
+}
+
+// NewRetryRunner creates a RetryRunner retrying the queries run with runner
+// according to policy.
+func NewRetryRunner(runner BaseRunnerContext, policy RetryPolicy) *RetryRunner {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 3
+	}
+	if policy.Backoff == nil {
+		policy.Backoff = ExponentialBackoff(10*time.Millisecond, time.Second)
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = IsTransientError
+	}
+	return &RetryRunner{runner: wrapRunner(runner), policy: policy}
+}
+
+// ExecContext executes the query with the wrapped runner.
+func (r *RetryRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
+	err = r.retry(ctx, query, func() error {
+		res, err = r.runner.ExecContext(ctx, query, args...)
+		return err
+	})
+	return
+}
+
+// QueryContext queries the query with the wrapped runner. Errors returned by
+// the rows after the query succeeded are not retried.
+func (r *RetryRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (rows *sql.Rows, err error) {
+	err = r.retry(ctx, query, func() error {
+		rows, err = r.runner.QueryContext(ctx, query, args...)
+		return err
+	})
+	return
+}
+
+// QueryRowContext queries a single row with the wrapped runner. The query is
+// run, and retried, by Scan.
+func (r *RetryRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	queryRower, ok := r.runner.(QueryRowerContext)
+	if !ok {
+		return &Row{err: ErrRunnerNotQueryRunnerContext}
+	}
+	return &retryRow{r: r, ctx: ctx, queryRower: queryRower, query: query, args: args}
+}
+
+func (r *RetryRunner) retry(ctx context.Context, query string, run func() error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = run()
+		if err == nil || attempt >= r.policy.MaxAttempts || !r.policy.Retryable(err) {
+			return err
+		}
+		if IsConnectionError(err) && !safeToRetry(ctx, query) {
+			return err
+		}
+
+		t := time.NewTimer(r.policy.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+	}
+}
+
+type retryRow struct {
+	r          *RetryRunner
+	ctx        context.Context
+	queryRower QueryRowerContext
+	query      string
+	args       []interface{}
+}
+
+func (row *retryRow) Scan(dest ...interface{}) error {
+	return row.r.retry(row.ctx, row.query, func() error {
+		return row.queryRower.QueryRowContext(row.ctx, row.query, row.args...).Scan(dest...)
+	})
+}
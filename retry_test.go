@@ -0,0 +1,139 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sqlStateErr string
+
+func (e sqlStateErr) Error() string    { return "sql state " + string(e) }
+func (e sqlStateErr) SQLState() string { return string(e) }
+
+// flakyRunner fails the first len(errs) calls with errs.
+type flakyRunner struct {
+	errs  []error
+	calls int
+}
+
+func (s *flakyRunner) next() error {
+	s.calls++
+	if s.calls <= len(s.errs) {
+		return s.errs[s.calls-1]
+	}
+	return nil
+}
+
+func (s *flakyRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, s.next()
+}
+
+func (s *flakyRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, s.next()
+}
+
+func (s *flakyRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	return &Row{RowScanner: &RowStub{}, err: s.next()}
+}
+
+var noBackoff = func(int) time.Duration { return 0 }
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, IsTransientError(driver.ErrBadConn))
+	assert.True(t, IsTransientError(sqlStateErr("40001")))
+	assert.True(t, IsTransientError(fmt.Errorf("update: %w", sqlStateErr("40P01"))))
+	assert.False(t, IsTransientError(sqlStateErr("23505")))
+	assert.False(t, IsTransientError(errors.New("syntax error")))
+	assert.False(t, IsTransientError(nil))
+}
+
+func TestRetryRunnerExec(t *testing.T) {
+	db := &flakyRunner{errs: []error{sqlStateErr("40001"), driver.ErrBadConn}}
+	runner := NewRetryRunner(db, RetryPolicy{Backoff: noBackoff})
+
+	_, err := Update("t").Set("a", 1).RunWith(runner).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, db.calls)
+}
+
+func TestRetryRunnerMaxAttempts(t *testing.T) {
+	db := &flakyRunner{errs: []error{driver.ErrBadConn, driver.ErrBadConn, driver.ErrBadConn}}
+	runner := NewRetryRunner(db, RetryPolicy{MaxAttempts: 2, Backoff: noBackoff})
+
+	_, err := Select("a").From("t").RunWith(runner).Query()
+	assert.Equal(t, driver.ErrBadConn, err)
+	assert.Equal(t, 2, db.calls)
+}
+
+func TestRetryRunnerNotRetryable(t *testing.T) {
+	db := &flakyRunner{errs: []error{sqlStateErr("23505")}}
+	runner := NewRetryRunner(db, RetryPolicy{Backoff: noBackoff})
+
+	_, err := Insert("t").Values(1).RunWith(runner).Exec()
+	assert.Error(t, err)
+	assert.Equal(t, 1, db.calls)
+}
+
+func TestRetryRunnerQueryRow(t *testing.T) {
+	db := &flakyRunner{errs: []error{sqlStateErr("40P01")}}
+	runner := NewRetryRunner(db, RetryPolicy{
+		Backoff:   noBackoff,
+		Retryable: func(err error) bool { return err.Error() == "sql state 40P01" },
+	})
+
+	err := Select("a").From("t").RunWith(runner).Scan()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, db.calls)
+}
+
+func TestRetryRunnerConnectionError(t *testing.T) {
+	assert.True(t, IsConnectionError(syscall.ECONNRESET))
+	assert.True(t, IsConnectionError(sqlStateErr("08006")))
+	assert.False(t, IsConnectionError(driver.ErrBadConn))
+	assert.False(t, IsConnectionError(sqlStateErr("40001")))
+
+	db := &flakyRunner{errs: []error{sqlStateErr("08006")}}
+	runner := NewRetryRunner(db, RetryPolicy{Backoff: noBackoff})
+	_, err := Update("accounts").Set("balance", Expr("balance + ?", 10)).RunWith(runner).Exec()
+	assert.Error(t, err)
+	assert.Equal(t, 1, db.calls)
+
+	db = &flakyRunner{errs: []error{sqlStateErr("08006")}}
+	runner = NewRetryRunner(db, RetryPolicy{Backoff: noBackoff})
+	_, err = Update("users").Set("name", "Joe").RunWith(runner).ExecContext(WithIdempotent(context.Background()))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, db.calls)
+
+	db = &flakyRunner{errs: []error{syscall.ECONNRESET}}
+	runner = NewRetryRunner(db, RetryPolicy{Backoff: noBackoff})
+	_, err = Select("a").From("t").RunWith(runner).Query()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, db.calls)
+}
+
+func TestRetryRunnerContextDone(t *testing.T) {
+	db := &flakyRunner{errs: []error{driver.ErrBadConn, driver.ErrBadConn}}
+	runner := NewRetryRunner(db, RetryPolicy{Backoff: func(int) time.Duration { return time.Hour }})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Update("t").Set("a", 1).RunWith(runner).ExecContext(ctx)
+	assert.Equal(t, driver.ErrBadConn, err)
+	assert.Equal(t, 1, db.calls)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	assert.Equal(t, 10*time.Millisecond, backoff(1))
+	assert.Equal(t, 20*time.Millisecond, backoff(2))
+	assert.Equal(t, 40*time.Millisecond, backoff(3))
+	assert.Equal(t, 50*time.Millisecond, backoff(4))
+}
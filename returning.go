@@ -1,7 +1,5 @@
 package sqrl
 
-import "io"
-
 type returning []Sqlizer
 
 func (r *returning) Returning(columns ...string) {
@@ -16,8 +14,8 @@ func (r *returning) ReturningSelect(from *SelectBuilder, alias string) {
 	*r = append(*r, Alias(from, alias))
 }
 
-func (r *returning) AppendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
-	io.WriteString(w, " RETURNING ")
+func (r *returning) AppendToSql(w sqlWriter, args []interface{}) ([]interface{}, error) {
+	w.WriteString(" RETURNING ")
 	return appendToSql(*r, w, ", ", args)
 
 }
@@ -0,0 +1,40 @@
+//go:build go1.21
+
+package sqrl
+
+import "context"
+
+// QueryScalar builds and runs b with the Runner set by RunWith and scans the
+// single column of its single row into a T.
+// Ex:
+//     count, err := QueryScalar[int64](ctx, Select("COUNT(*)").From("users").RunWith(db))
+func QueryScalar[T any](ctx context.Context, b *SelectBuilder) (T, error) {
+	var v T
+	err := b.QueryRowContext(ctx).Scan(&v)
+	return v, err
+}
+
+// QueryScalars builds and runs b with the Runner set by RunWith and scans the
+// single column of all its rows into a slice of T.
+// Ex:
+//     ids, err := QueryScalars[int64](ctx, Select("id").From("users").Where(Eq{"active": true}).RunWith(db))
+func QueryScalars[T any](ctx context.Context, b *SelectBuilder) ([]T, error) {
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var vs []T
+	for rows.Next() {
+		var v T
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		vs = append(vs, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return vs, rows.Close()
+}
@@ -0,0 +1,31 @@
+//go:build go1.21
+
+package sqrl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type int64RowStub int64
+
+func (r int64RowStub) Scan(dest ...interface{}) error {
+	*dest[0].(*int64) = int64(r)
+	return nil
+}
+
+func TestQueryScalar(t *testing.T) {
+	runner := &CaptureRunner{Row: int64RowStub(42)}
+
+	n, err := QueryScalar[int64](context.Background(), Select("COUNT(*)").From("users").RunWith(runner))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), n)
+	assert.Equal(t, "SELECT COUNT(*) FROM users", runner.Last().SQL)
+}
+
+func TestQueryScalarsRunnerNotSet(t *testing.T) {
+	_, err := QueryScalars[int64](context.Background(), Select("id").From("users"))
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
@@ -0,0 +1,345 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ColumnScanner is a RowScanner that knows the columns of its result, like
+// database/sql.Rows.
+type ColumnScanner interface {
+	RowScanner
+	Columns() ([]string, error)
+}
+
+// ScanStruct scans the current row of rows into the struct dest points to.
+//
+// Columns are matched to fields by their db tag, or by their name case
+// insensitively if they have none. Fields tagged db:"-" are skipped, fields
+// of embedded structs are matched as if they were fields of dest. Columns
+// without a field are discarded. Use pointers or sql.Null* types for fields
+// of nullable columns.
+// Ex:
+//     type User struct {
+//         ID    int64          `db:"id"`
+//         Email sql.NullString `db:"email"`
+//     }
+//     for rows.Next() {
+//         var u User
+//         err := ScanStruct(rows, &u)
+//     }
+func ScanStruct(rows ColumnScanner, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("scan destination must be a non-nil pointer to a struct, got %T", dest)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	fields, err := columnFields(v.Elem().Type(), columns, false)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(scanTargets(v.Elem(), fields)...)
+}
+
+// RowsScanner is a ColumnScanner iterating over rows, like database/sql.Rows.
+type RowsScanner interface {
+	ColumnScanner
+	Next() bool
+	Err() error
+}
+
+// ScanStructs scans all remaining rows of rows and appends them to the slice
+// dest points to. The slice elements may be structs or pointers to structs.
+// Columns are matched to fields like by ScanStruct.
+// Ex:
+//     var users []*User
+//     err := ScanStructs(rows, &users)
+func ScanStructs(rows RowsScanner, dest interface{}) error {
+	return scanStructs(rows, dest, false)
+}
+
+// ScanStructsStrict is like ScanStructs, but fails if a column can't be
+// matched to a field.
+func ScanStructsStrict(rows RowsScanner, dest interface{}) error {
+	return scanStructs(rows, dest, true)
+}
+
+func scanStructs(rows RowsScanner, dest interface{}, strict bool) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("scan destination must be a non-nil pointer to a slice, got %T", dest)
+	}
+
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("scan destination must be a slice of structs or struct pointers, got %T", dest)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	fields, err := columnFields(structType, columns, strict)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		elem := reflect.New(structType)
+		if err := rows.Scan(scanTargets(elem.Elem(), fields)...); err != nil {
+			return err
+		}
+		if !isPtr {
+			elem = elem.Elem()
+		}
+		slice = reflect.Append(slice, elem)
+	}
+	v.Elem().Set(slice)
+
+	return rows.Err()
+}
+
+// columnFields returns the index of the field of t each of columns is
+// scanned into, or nil for columns without a field. In strict mode columns
+// without a field are an error.
+func columnFields(t reflect.Type, columns []string, strict bool) ([][]int, error) {
+	fields := structFields(t, false)
+	indexes := make([][]int, len(columns))
+	for i, column := range columns {
+		index, ok := fields[column]
+		if !ok {
+			index, ok = fields[strings.ToLower(column)]
+		}
+		if !ok && strict {
+			return nil, fmt.Errorf("no field of %s for column %q", t, column)
+		}
+		indexes[i] = index
+	}
+	return indexes, nil
+}
+
+// scanTargets returns pointers to the fields of v to scan columns into.
+// Columns without a field are discarded.
+func scanTargets(v reflect.Value, fields [][]int) []interface{} {
+	targets := make([]interface{}, len(fields))
+	for i, index := range fields {
+		if index == nil {
+			targets[i] = new(interface{})
+			continue
+		}
+		targets[i] = fieldByIndex(v, index).Addr().Interface()
+	}
+	return targets
+}
+
+// StructFields maps the column names of the fields of the struct type t to
+// their index, like ScanStruct does, for reading the fields of values of t.
+// Fields without a db tag are mapped by their lower cased name, fields
+// tagged db:"-" are skipped and the fields of embedded structs are included.
+func StructFields(t reflect.Type) map[string][]int {
+	return structFields(t, true)
+}
+
+// structFields maps the column names of the fields of t to their index.
+// Fields without a db tag are mapped by their lower cased name. The fields
+// of unexported embedded struct pointers are only included if read is set,
+// since they can't be allocated when scanning.
+func structFields(t reflect.Type, read bool) map[string][]int {
+	fields := map[string][]int{}
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("db")
+			if tag == "-" {
+				continue
+			}
+
+			fieldIndex := append(append([]int{}, index...), i)
+
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				if f.PkgPath != "" && !read {
+					// unexported embedded pointers can't be allocated
+					continue
+				}
+				ft = ft.Elem()
+			}
+			if f.Anonymous && tag == "" && ft.Kind() == reflect.Struct {
+				walk(ft, fieldIndex)
+				continue
+			}
+
+			if f.PkgPath != "" {
+				// unexported
+				continue
+			}
+
+			name := tag
+			if name == "" {
+				name = strings.ToLower(f.Name)
+			}
+			// Fields closer to the root shadow fields of embedded structs.
+			if _, ok := fields[name]; !ok || len(fields[name]) > len(fieldIndex) {
+				fields[name] = fieldIndex
+			}
+		}
+	}
+	walk(t, nil)
+	return fields
+}
+
+// fieldByIndex returns the field of v at index, allocating nil embedded
+// struct pointers on the way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// ScanStruct builds and runs the query with the Runner set by RunWith and
+// scans the first row into the struct dest points to. It returns
+// sql.ErrNoRows if the query returns no rows.
+//
+// See ScanStruct.
+func (b *SelectBuilder) ScanStruct(dest interface{}) error {
+	return b.ScanStructContext(context.Background(), dest)
+}
+
+// ScanStructContext is like ScanStruct, running the query in given context.
+func (b *SelectBuilder) ScanStructContext(ctx context.Context, dest interface{}) error {
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := ScanStruct(rows, dest); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// QueryStructs builds and runs the query with the Runner set by RunWith
+// and appends all rows to the slice dest points to.
+//
+// See ScanStructs.
+func (b *SelectBuilder) QueryStructs(ctx context.Context, dest interface{}) error {
+	return b.queryStructs(ctx, dest, false)
+}
+
+// QueryStructsStrict is like QueryStructs, but fails if a column can't be
+// matched to a field.
+func (b *SelectBuilder) QueryStructsStrict(ctx context.Context, dest interface{}) error {
+	return b.queryStructs(ctx, dest, true)
+}
+
+func (b *SelectBuilder) queryStructs(ctx context.Context, dest interface{}, strict bool) error {
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if err := scanStructs(rows, dest, strict); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// ScanMap scans the current row of rows into a map of column names to
+// values.
+func ScanMap(rows ColumnScanner) (map[string]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		m[column] = values[i]
+	}
+	return m, nil
+}
+
+// QueryMaps builds and runs the query with the Runner set by RunWith and
+// returns all rows as maps of column names to values.
+func (b *SelectBuilder) QueryMaps(ctx context.Context) ([]map[string]interface{}, error) {
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var maps []map[string]interface{}
+	for rows.Next() {
+		m, err := ScanMap(rows)
+		if err != nil {
+			return nil, err
+		}
+		maps = append(maps, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return maps, rows.Close()
+}
+
+// QueryMap builds and runs the query with the Runner set by RunWith and
+// returns the first row as a map of column names to values. It returns
+// sql.ErrNoRows if the query returns no rows.
+func (b *SelectBuilder) QueryMap(ctx context.Context) (map[string]interface{}, error) {
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+	m, err := ScanMap(rows)
+	if err != nil {
+		return nil, err
+	}
+	return m, rows.Close()
+}
@@ -0,0 +1,208 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type columnScannerStub struct {
+	columns []string
+	values  []interface{}
+}
+
+func (s *columnScannerStub) Columns() ([]string, error) {
+	return s.columns, nil
+}
+
+func (s *columnScannerStub) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		switch d := d.(type) {
+		case *int64:
+			*d = s.values[i].(int64)
+		case *string:
+			*d = s.values[i].(string)
+		case **string:
+			if s.values[i] == nil {
+				*d = nil
+			} else {
+				v := s.values[i].(string)
+				*d = &v
+			}
+		case *sql.NullString:
+			d.Scan(s.values[i])
+		case *interface{}:
+			*d = s.values[i]
+		}
+	}
+	return nil
+}
+
+type timestamps struct {
+	Created string `db:"created_at"`
+}
+
+type Audit struct {
+	By string `db:"created_by"`
+}
+
+type scanUser struct {
+	timestamps
+	*Audit
+	ID       int64 `db:"id"`
+	Name     string
+	Email    sql.NullString `db:"email"`
+	Nickname *string        `db:"nickname"`
+	Ignored  string         `db:"-"`
+	secret   string
+}
+
+func TestScanStruct(t *testing.T) {
+	rows := &columnScannerStub{
+		columns: []string{"id", "NAME", "email", "nickname", "created_at", "created_by", "unknown"},
+		values:  []interface{}{int64(1), "Joe", nil, "joey", "today", "admin", "x"},
+	}
+
+	var u scanUser
+	err := ScanStruct(rows, &u)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(1), u.ID)
+	assert.Equal(t, "Joe", u.Name)
+	assert.False(t, u.Email.Valid)
+	if assert.NotNil(t, u.Nickname) {
+		assert.Equal(t, "joey", *u.Nickname)
+	}
+	assert.Equal(t, "today", u.Created)
+	if assert.NotNil(t, u.Audit) {
+		assert.Equal(t, "admin", u.By)
+	}
+}
+
+func TestScanStructInvalidDest(t *testing.T) {
+	rows := &columnScannerStub{}
+	var u scanUser
+	assert.Error(t, ScanStruct(rows, u))
+	assert.Error(t, ScanStruct(rows, (*scanUser)(nil)))
+	var i int
+	assert.Error(t, ScanStruct(rows, &i))
+}
+
+// rowsStub iterates over rows of values.
+type rowsStub struct {
+	columnScannerStub
+	rows [][]interface{}
+}
+
+func (s *rowsStub) Next() bool {
+	if len(s.rows) == 0 {
+		return false
+	}
+	s.values, s.rows = s.rows[0], s.rows[1:]
+	return true
+}
+
+func (s *rowsStub) Err() error {
+	return nil
+}
+
+func TestScanStructs(t *testing.T) {
+	newRows := func() *rowsStub {
+		return &rowsStub{
+			columnScannerStub: columnScannerStub{columns: []string{"id", "name", "extra"}},
+			rows: [][]interface{}{
+				{int64(1), "Joe", "x"},
+				{int64(2), "Ann", "y"},
+			},
+		}
+	}
+
+	var users []scanUser
+	err := ScanStructs(newRows(), &users)
+	assert.NoError(t, err)
+	if assert.Len(t, users, 2) {
+		assert.Equal(t, int64(1), users[0].ID)
+		assert.Equal(t, "Ann", users[1].Name)
+	}
+
+	ptrs := []*scanUser{{ID: 0}}
+	err = ScanStructs(newRows(), &ptrs)
+	assert.NoError(t, err)
+	if assert.Len(t, ptrs, 3) {
+		assert.Equal(t, int64(2), ptrs[2].ID)
+	}
+
+	err = ScanStructsStrict(newRows(), &users)
+	assert.EqualError(t, err, `no field of sqrl.scanUser for column "extra"`)
+}
+
+func TestScanStructsInvalidDest(t *testing.T) {
+	rows := &rowsStub{}
+	var users []scanUser
+	assert.Error(t, ScanStructs(rows, users))
+	var ints []int
+	assert.Error(t, ScanStructs(rows, &ints))
+}
+
+func TestSelectBuilderScanStructRunnerNotSet(t *testing.T) {
+	var u scanUser
+	assert.Equal(t, ErrRunnerNotSet, Select("id").From("users").ScanStruct(&u))
+}
+
+func TestSelectBuilderScanStructContext(t *testing.T) {
+	d := &stubDriver{
+		cols: []string{"id", "name"},
+		rows: [][]driver.Value{{int64(1), "Joe"}, {int64(2), "Ann"}},
+	}
+	db := openStubDB(d)
+	defer db.Close()
+
+	var u scanUser
+	err := Select("id", "name").From("users").RunWith(db).ScanStructContext(context.Background(), &u)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), u.ID)
+	assert.Equal(t, "Joe", u.Name)
+
+	d.rows = nil
+	err = Select("id", "name").From("users").RunWith(db).ScanStruct(&u)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Select("id", "name").From("users").RunWith(db).ScanStructContext(ctx, &u)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestSelectBuilderQueryStructsRunnerNotSet(t *testing.T) {
+	var users []scanUser
+	assert.Equal(t, ErrRunnerNotSet, Select("id").From("users").QueryStructs(context.Background(), &users))
+}
+
+func TestQueryMaps(t *testing.T) {
+	d := &stubDriver{
+		cols: []string{"id", "name"},
+		rows: [][]driver.Value{{int64(1), "Joe"}, {int64(2), nil}},
+	}
+	db := openStubDB(d)
+	defer db.Close()
+
+	maps, err := Select("id", "name").From("users").RunWith(db).QueryMaps(context.Background())
+	assert.NoError(t, err)
+	expected := []map[string]interface{}{
+		{"id": int64(1), "name": "Joe"},
+		{"id": int64(2), "name": nil},
+	}
+	assert.Equal(t, expected, maps)
+
+	d.rows = [][]driver.Value{{int64(3), "Ann"}, {int64(4), "Bob"}}
+	m, err := Select("id", "name").From("users").RunWith(db).QueryMap(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": int64(3), "name": "Ann"}, m)
+
+	d.rows = nil
+	_, err = Select("id", "name").From("users").RunWith(db).QueryMap(context.Background())
+	assert.Equal(t, sql.ErrNoRows, err)
+}
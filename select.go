@@ -1,12 +1,13 @@
 package sqrl
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // SelectBuilder builds SQL SELECT statements.
@@ -15,6 +16,7 @@ type SelectBuilder struct {
 
 	prefixes    exprs
 	distinct    bool
+	distinctOn  []string
 	options     []string
 	columns     []Sqlizer
 	fromParts   []Sqlizer
@@ -33,15 +35,17 @@ type SelectBuilder struct {
 
 	top      uint64
 	topValid bool
+
+	memo *memo
 }
 
 // NewSelectBuilder creates new instance of SelectBuilder
 func NewSelectBuilder(b StatementBuilderType) *SelectBuilder {
-	return &SelectBuilder{StatementBuilderType: b}
+	return &SelectBuilder{StatementBuilderType: b, memo: newMemo(b.memoize)}
 }
 
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
-func (b *SelectBuilder) RunWith(runner BaseRunner) *SelectBuilder {
+func (b *SelectBuilder) RunWith(runner BaseRunnerContext) *SelectBuilder {
 	b.runWith = wrapRunner(runner)
 	return b
 }
@@ -56,6 +60,8 @@ func (b *SelectBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 	if b.runWith == nil {
 		return nil, ErrRunnerNotSet
 	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
 	return ExecWithContext(ctx, b.runWith, b)
 }
 
@@ -69,7 +75,7 @@ func (b *SelectBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
 	if b.runWith == nil {
 		return nil, ErrRunnerNotSet
 	}
-	return QueryWithContext(ctx, b.runWith, b)
+	return QueryWithContext(b.queryTimeoutContext(ctx), b.runWith, b)
 }
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
@@ -85,7 +91,8 @@ func (b *SelectBuilder) QueryRowContext(ctx context.Context) RowScanner {
 	if !ok {
 		return &Row{err: ErrRunnerNotQueryRunnerContext}
 	}
-	return QueryRowWithContext(ctx, queryRower, b)
+	ctx, cancel := b.timeoutContext(ctx)
+	return &timeoutRow{RowScanner: QueryRowWithContext(ctx, queryRower, b), cancel: cancel}
 }
 
 // Scan is a shortcut for QueryRow().Scan.
@@ -93,6 +100,57 @@ func (b *SelectBuilder) Scan(dest ...interface{}) error {
 	return b.QueryRow().Scan(dest...)
 }
 
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext. For Query the timeout covers reading the rows.
+func (b *SelectBuilder) Timeout(d time.Duration) *SelectBuilder {
+	b.timeout = d
+	return b
+}
+
+// StrictPlaceholders makes ToSql verify that the raw SQL fragments of the
+// query have as many ? placeholders as args.
+func (b *SelectBuilder) StrictPlaceholders() *SelectBuilder {
+	b.strictPlaceholders = true
+	return b
+}
+
+// StrictIdentifiers makes ToSql verify that the table and column names of
+// the query are identifiers, and one of allowed if given.
+//
+// See StatementBuilderType.StrictIdentifiers.
+func (b *SelectBuilder) StrictIdentifiers(allowed ...string) *SelectBuilder {
+	b.strictIdentifiers = true
+	b.allowedIdentifiers = identifierSet(allowed)
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on.
+//
+// See StatementBuilderType.Dialect.
+func (b *SelectBuilder) Dialect(d Dialect) *SelectBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its table and column names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *SelectBuilder) QuoteIdentifiers() *SelectBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// Memoize makes ToSql cache the query until it is changed.
+//
+// See StatementBuilderType.Memoize.
+func (b *SelectBuilder) Memoize() *SelectBuilder {
+	b.memoize = true
+	if b.memo == nil {
+		b.memo = newMemo(true)
+	}
+	return b
+}
+
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b *SelectBuilder) PlaceholderFormat(f PlaceholderFormat) *SelectBuilder {
@@ -100,27 +158,115 @@ func (b *SelectBuilder) PlaceholderFormat(f PlaceholderFormat) *SelectBuilder {
 	return b
 }
 
+// ToSqlNamed builds the query into a SQL string with named placeholders.
+//
+// See ToSqlNamed.
+func (b *SelectBuilder) ToSqlNamed(f NamedFormat) (string, []sql.NamedArg, error) {
+	nb := *b
+	nb.placeholderFormat = Question
+	return ToSqlNamed(&nb, f)
+}
+
 // ToSql builds the query into a SQL string and bound args.
 func (b *SelectBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if b.memoize {
+		return memoized(b.memo, b, func() (string, []interface{}, error) {
+			return b.toSql(true)
+		})
+	}
 	return b.toSql(true)
 }
 
-func (b *SelectBuilder) toSql(replacePlaceholders bool) (sqlStr string, args []interface{}, err error) {
+func (b *SelectBuilder) toSql(replacePlaceholders bool) (string, []interface{}, error) {
+	f := b.placeholderFormat
+	if !replacePlaceholders {
+		f = nil
+	}
+	sql := getQueryBuffer(f)
+	defer putQueryBuffer(sql)
+
+	args := makeArgs(countExprArgs(b.prefixes) + countArgs(b.columns, b.fromParts, b.joins, b.whereParts, b.havingParts) + countExprArgs(b.suffixes))
+	args, err := appendQuery(sql, args, b.dialect, b.appendToSql)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql.finish(args)
+}
+
+// AppendToSql writes the query to w and appends its args to args, to build
+// it into a larger statement without an intermediate string. Placeholders
+// are written as ?, to be replaced by the enclosing statement.
+func (b *SelectBuilder) AppendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
+	return appendQuery(w, args, b.dialect, b.appendToSql)
+}
+
+func (b *SelectBuilder) appendToSql(sql *queryBuffer, in []interface{}) (args []interface{}, err error) {
+	args = in
 	if len(b.columns) == 0 {
 		err = fmt.Errorf("select statements must have at least one result column")
 		return
 	}
+	if b.strictPlaceholders {
+		if err = checkPlaceholders(b.placeholderParts()...); err != nil {
+			return
+		}
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifiers(); err != nil {
+			return
+		}
+	}
+
+	top, topValid := b.top, b.topValid
+	limit, limitValid := b.limit, b.limitValid
+	if b.dialect != nil && topValid {
+		// With a dialect Top and Limit are the same, rendered the way
+		// of the dialect
+		if limitValid {
+			err = fmt.Errorf("select statements can't have both TOP and LIMIT")
+			return
+		}
+		limit, limitValid, topValid = top, true, false
+	}
 
-	sql := &bytes.Buffer{}
+	fetch, limitAsTop := false, false
+	switch limitStyle(b.dialect) {
+	case OffsetFetch:
+		fetch = limitValid || b.offsetValid
+	case TopOffsetFetch:
+		if !limitValid && !b.offsetValid {
+			break
+		}
+		switch {
+		case len(b.orderBys) > 0:
+			fetch = true
+		case b.offsetValid:
+			err = fmt.Errorf("select statements with OFFSET must have an ORDER BY clause on %s", b.dialect.Name())
+			return
+		default:
+			top, topValid, limitAsTop = limit, true, true
+		}
+	}
 
 	if len(b.prefixes) > 0 {
-		args, _ = b.prefixes.AppendToSql(sql, " ", args)
+		args, err = b.prefixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
 	sql.WriteString("SELECT ")
 
-	if b.distinct {
+	if len(b.distinctOn) > 0 {
+		if !supports(b.dialect, FeatureDistinctOn) {
+			err = fmt.Errorf("%s does not support DISTINCT ON", b.dialect.Name())
+			return
+		}
+		sql.WriteString("DISTINCT ON (")
+		sql.WriteString(strings.Join(b.quoteNames(b.distinctOn, identName), ", "))
+		sql.WriteString(") ")
+	} else if b.distinct {
 		sql.WriteString("DISTINCT ")
 	}
 
@@ -129,14 +275,14 @@ func (b *SelectBuilder) toSql(replacePlaceholders bool) (sqlStr string, args []i
 		sql.WriteString(" ")
 	}
 
-	if b.topValid {
+	if topValid {
 		sql.WriteString("TOP ")
-		sql.WriteString(strconv.FormatUint(b.top, 10))
+		sql.WriteString(strconv.FormatUint(top, 10))
 		sql.WriteString(" ")
 	}
 
 	if len(b.columns) > 0 {
-		args, err = appendToSql(b.columns, sql, ", ", args)
+		args, err = appendToSql(b.quoteParts(b.columns, identAliased), sql, ", ", args)
 		if err != nil {
 			return
 		}
@@ -144,10 +290,14 @@ func (b *SelectBuilder) toSql(replacePlaceholders bool) (sqlStr string, args []i
 
 	if len(b.fromParts) > 0 {
 		sql.WriteString(" FROM ")
-		args, err = appendToSql(b.fromParts, sql, ", ", args)
+		args, err = appendToSql(b.quoteParts(b.fromParts, identAliased), sql, ", ", args)
 		if err != nil {
 			return
 		}
+	} else if !supports(b.dialect, FeatureSelectWithoutFrom) {
+		// DUAL is the one row dummy table of Oracle, and others requiring
+		// a FROM clause
+		sql.WriteString(" FROM DUAL")
 	}
 
 	if len(b.joins) > 0 {
@@ -168,7 +318,7 @@ func (b *SelectBuilder) toSql(replacePlaceholders bool) (sqlStr string, args []i
 
 	if len(b.groupBys) > 0 {
 		sql.WriteString(" GROUP BY ")
-		sql.WriteString(strings.Join(b.groupBys, ", "))
+		sql.WriteString(strings.Join(b.quoteNames(b.groupBys, identName), ", "))
 	}
 
 	if len(b.havingParts) > 0 {
@@ -181,33 +331,47 @@ func (b *SelectBuilder) toSql(replacePlaceholders bool) (sqlStr string, args []i
 
 	if len(b.orderBys) > 0 {
 		sql.WriteString(" ORDER BY ")
-		sql.WriteString(strings.Join(b.orderBys, ", "))
+		sql.WriteString(strings.Join(b.quoteNames(b.orderBys, identOrderBy), ", "))
 	}
 
-	// TODO: limit == 0 and offswt == 0 are valid. Need to go dbr way and implement offsetValid and limitValid
-	if b.limitValid {
-		sql.WriteString(" LIMIT ")
-		sql.WriteString(strconv.FormatUint(b.limit, 10))
-	}
-
-	if b.offsetValid {
+	switch {
+	case fetch && !b.offsetValid && limitStyle(b.dialect) == OffsetFetch:
+		sql.WriteString(" FETCH FIRST ")
+		sql.WriteString(strconv.FormatUint(limit, 10))
+		sql.WriteString(" ROWS ONLY")
+	case fetch:
+		// SQL Server and Oracle paginate with OFFSET ... FETCH, which
+		// requires ORDER BY on SQL Server
 		sql.WriteString(" OFFSET ")
 		sql.WriteString(strconv.FormatUint(b.offset, 10))
+		sql.WriteString(" ROWS")
+		if limitValid {
+			sql.WriteString(" FETCH NEXT ")
+			sql.WriteString(strconv.FormatUint(limit, 10))
+			sql.WriteString(" ROWS ONLY")
+		}
+	case !limitAsTop:
+		// TODO: limit == 0 and offswt == 0 are valid. Need to go dbr way and implement offsetValid and limitValid
+		if limitValid {
+			sql.WriteString(" LIMIT ")
+			sql.WriteString(strconv.FormatUint(limit, 10))
+		}
+
+		if b.offsetValid {
+			sql.WriteString(" OFFSET ")
+			sql.WriteString(strconv.FormatUint(b.offset, 10))
+		}
 	}
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = b.suffixes.AppendToSql(sql, " ", args)
-	}
-
-	if replacePlaceholders {
-		sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
-	} else {
-		sqlStr = sql.String()
+		args, err = b.suffixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
 	}
 
 	return
-
 }
 
 // Prefix adds an expression to the beginning of the query
@@ -223,6 +387,17 @@ func (b *SelectBuilder) Distinct() *SelectBuilder {
 	return b
 }
 
+// DistinctOn adds a PostgreSQL DISTINCT ON clause to the query, which keeps
+// the first row of each set of rows with equal columns. It is rejected with
+// dialects not supporting it.
+// Ex:
+//     Select("user_id", "created_at").DistinctOn("user_id").From("orders").OrderBy("user_id", "created_at DESC")
+//     == "SELECT DISTINCT ON (user_id) user_id, created_at FROM orders ORDER BY user_id, created_at DESC"
+func (b *SelectBuilder) DistinctOn(columns ...string) *SelectBuilder {
+	b.distinctOn = append(b.distinctOn, columns...)
+	return b
+}
+
 // Options adds select option to the query
 func (b *SelectBuilder) Options(options ...string) *SelectBuilder {
 	for _, str := range options {
@@ -231,6 +406,12 @@ func (b *SelectBuilder) Options(options ...string) *SelectBuilder {
 	return b
 }
 
+// ForceJoinOrder adds the MySQL STRAIGHT_JOIN select option, which makes
+// the optimizer join the tables in the order they are listed.
+func (b *SelectBuilder) ForceJoinOrder() *SelectBuilder {
+	return b.Options("STRAIGHT_JOIN")
+}
+
 // Columns adds result columns to the query.
 func (b *SelectBuilder) Columns(columns ...string) *SelectBuilder {
 	for _, str := range columns {
@@ -294,6 +475,12 @@ func (b *SelectBuilder) InnerJoin(join string) *SelectBuilder {
 	return b.JoinClause("INNER JOIN " + join)
 }
 
+// StraightJoin adds a MySQL STRAIGHT_JOIN clause to the query, which reads
+// the left table before the right one.
+func (b *SelectBuilder) StraightJoin(join string, rest ...interface{}) *SelectBuilder {
+	return b.JoinClause("STRAIGHT_JOIN "+join, rest...)
+}
+
 // Where adds an expression to the WHERE clause of the query.
 //
 // Expressions are ANDed together in the generated SQL.
@@ -340,6 +527,9 @@ func (b *SelectBuilder) OrderBy(orderBys ...string) *SelectBuilder {
 }
 
 // Limit sets a LIMIT clause on the query.
+//
+// With a dialect, it is rendered the way of the dialect, e.g. as TOP or
+// OFFSET ... FETCH with SQL Server, see LimitStyle.
 func (b *SelectBuilder) Limit(limit uint64) *SelectBuilder {
 	b.limit = limit
 	b.limitValid = true
@@ -360,6 +550,10 @@ func (b *SelectBuilder) Suffix(sql string, args ...interface{}) *SelectBuilder {
 	return b
 }
 
+// Top sets a TOP clause on the query.
+//
+// With a dialect, Top is an alias of Limit rendered the way of the dialect,
+// e.g. as LIMIT with Postgres, and can't be combined with Limit.
 func (b *SelectBuilder) Top(top uint64) *SelectBuilder {
 	b.top = top
 	b.topValid = true
@@ -372,6 +566,7 @@ func (b *SelectBuilder) Copy() *SelectBuilder {
 	vb := *b
 	// ... then make a shallow copy
 	nb := vb
+	nb.memo = newMemo(nb.memoize)
 
 	// Then copy all reference types of the struct to make a deep copy
 	nb.prefixes = make(exprs, len(vb.prefixes))
@@ -379,6 +574,9 @@ func (b *SelectBuilder) Copy() *SelectBuilder {
 	
 	nb.options = make([]string, len(vb.options))
 	copy(nb.options, vb.options)
+
+	nb.columns = make([]Sqlizer, len(vb.columns))
+	copy(nb.columns, vb.columns)
 	
 	nb.fromParts = make([]Sqlizer, len(vb.fromParts))
 	copy(nb.fromParts, vb.fromParts)
@@ -389,6 +587,9 @@ func (b *SelectBuilder) Copy() *SelectBuilder {
 	nb.whereParts = make([]Sqlizer, len(vb.whereParts))
 	copy(nb.whereParts, vb.whereParts)
 	
+	nb.distinctOn = make([]string, len(vb.distinctOn))
+	copy(nb.distinctOn, vb.distinctOn)
+
 	nb.groupBys = make([]string, len(vb.groupBys))
 	copy(nb.groupBys, vb.groupBys)
 	
@@ -1,6 +1,7 @@
 package sqrl
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -85,6 +86,23 @@ func BenchmarkSelectBuilderToSql(b *testing.B) {
 	}
 }
 
+func BenchmarkSelectBuilderToSqlAllocs(b *testing.B) {
+	qb := Select("id", "name").
+		From("users u").
+		Join("emails e ON e.user_id = u.id").
+		Where(Eq{"u.active": true}).
+		Where("u.created_at > ?", 0).
+		OrderBy("u.id").
+		Limit(10).
+		PlaceholderFormat(Dollar)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		qb.ToSql()
+	}
+}
+
 func TestSelectBuilderZeroOffsetLimit(t *testing.T) {
 	qb := Select("a").
 		From("b").
@@ -200,6 +218,16 @@ func TestSelectBuilderParamJoin(t *testing.T) {
 	assert.Equal(t, args, expectedArgs)
 }
 
+func TestSelectBuilderStraightJoin(t *testing.T) {
+	b := Select("*").From("bar").ForceJoinOrder().StraightJoin("baz ON bar.foo = baz.foo AND baz.foo = ?", 42)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SELECT STRAIGHT_JOIN * FROM bar STRAIGHT_JOIN baz ON bar.foo = baz.foo AND baz.foo = ?", sql)
+	assert.Equal(t, []interface{}{42}, args)
+}
+
 func TestSelectBuilderNestedSelectJoin(t *testing.T) {
 	expectedSql := "SELECT * FROM bar JOIN ( SELECT * FROM baz WHERE foo = ? ) r ON bar.foo = r.foo"
 	expectedArgs := []interface{}{42}
@@ -239,4 +267,144 @@ func TestSelectCopy(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Equal(t, "SELECT DISTINCT * FROM foo JOIN baz ON bar.foo = baz.foo AND baz.foo = ?", sql)
-}
\ No newline at end of file
+}
+
+func TestSelectBuilderSQLServerPagination(t *testing.T) {
+	b := StatementBuilder.Dialect(SQLServer)
+
+	sql, _, err := b.Select("id").From("users").OrderBy("id").Limit(10).Offset(20).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users ORDER BY id OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY", sql)
+
+	sql, _, err = b.Select("id").From("users").OrderBy("id").Limit(10).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users ORDER BY id OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY", sql)
+
+	sql, _, err = b.Select("id").From("users").OrderBy("id").Offset(5).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users ORDER BY id OFFSET 5 ROWS", sql)
+
+	sql, _, err = b.Select("id").From("users").Limit(10).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT TOP 10 id FROM users", sql)
+
+	_, _, err = b.Select("id").From("users").Offset(5).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = b.Select("id").From("users").Top(5).Limit(10).ToSql()
+	assert.Error(t, err)
+}
+
+func TestSelectBuilderOraclePagination(t *testing.T) {
+	b := StatementBuilder.Dialect(Oracle)
+
+	sql, args, err := b.Select("id").From("users").Where("active = ?", true).OrderBy("id").Limit(10).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE active = :1 ORDER BY id FETCH FIRST 10 ROWS ONLY", sql)
+	assert.Equal(t, []interface{}{true}, args)
+
+	sql, _, err = b.Select("id").From("users").OrderBy("id").Limit(10).Offset(20).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users ORDER BY id OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY", sql)
+
+	sql, _, err = b.Select("id").From("users").OrderBy("id").Offset(5).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users ORDER BY id OFFSET 5 ROWS", sql)
+}
+
+func TestSelectBuilderDialectPlaceholders(t *testing.T) {
+	sql, _, err := StatementBuilder.Dialect(Postgres).Select("id").From("users").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id = $1", sql)
+
+	sql, _, err = StatementBuilder.PlaceholderFormat(Dollar).Dialect(Oracle).Select("id").From("users").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE id = $1", sql)
+}
+
+func TestSelectBuilderOracleDual(t *testing.T) {
+	sql, _, err := StatementBuilder.Dialect(Oracle).Select("SYSDATE").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT SYSDATE FROM DUAL", sql)
+
+	sql, _, err = StatementBuilder.Dialect(Oracle).Select().Column(NextVal("users_seq")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT users_seq.NEXTVAL FROM DUAL", sql)
+
+	sql, _, err = Select("1").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT 1", sql)
+}
+
+func TestSelectBuilderDialectTop(t *testing.T) {
+	sql, _, err := Select("id").From("users").Top(10).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT TOP 10 id FROM users", sql)
+
+	sql, _, err = Select("id").From("users").Top(10).Offset(5).Dialect(Postgres).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users LIMIT 10 OFFSET 5", sql)
+
+	sql, _, err = Select("id").From("users").Top(10).Dialect(Oracle).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users FETCH FIRST 10 ROWS ONLY", sql)
+
+	sql, _, err = Select("id").From("users").Top(10).Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT TOP 10 id FROM users", sql)
+
+	sql, _, err = Select("id").From("users").OrderBy("id").Top(10).Offset(20).Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users ORDER BY id OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY", sql)
+
+	_, _, err = Select("id").From("users").Top(10).Limit(10).Dialect(Postgres).ToSql()
+	assert.Error(t, err)
+}
+
+func TestSelectBuilderDistinctOn(t *testing.T) {
+	sql, _, err := Select("user_id", "created_at").DistinctOn("user_id").Distinct().
+		From("orders").OrderBy("user_id", "created_at DESC").
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT DISTINCT ON (user_id) user_id, created_at FROM orders ORDER BY user_id, created_at DESC", sql)
+}
+
+func TestSelectBuilderAppendToSql(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("WITH recent AS (")
+	args, err := Select("id").From("orders").Where("created_at > ?", 1).PlaceholderFormat(Dollar).AppendToSql(&buf, []interface{}{0})
+	assert.NoError(t, err)
+	buf.WriteString(")")
+	assert.Equal(t, "WITH recent AS (SELECT id FROM orders WHERE created_at > ?)", buf.String())
+	assert.Equal(t, []interface{}{0, 1}, args)
+
+	_, err = Select().AppendToSql(&buf, nil)
+	assert.Error(t, err)
+
+	sql, args, err := Select("a", "b").
+		Column(Alias(Select("COUNT(*)").From("c").Where("c.a = ?", 1), "n")).
+		From("d").
+		Where("e = ?", 2).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a, b, (SELECT COUNT(*) FROM c WHERE c.a = $1) AS n FROM d WHERE e = $2", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Select("*").From("a").
+		JoinClause(SubQuery(Select("id").From("b").Where("x = ?", 1))).
+		Where("y = ?", 2).PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM a (SELECT id FROM b WHERE x = $1) WHERE y = $2", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+}
+
+func BenchmarkSelectBuilderNestedToSql(b *testing.B) {
+	sub := Select("COUNT(*)").From("orders").Where("orders.user_id = users.id").Where(Eq{"status": []string{"paid", "sent"}})
+	qb := Select("id", "name").Column(Alias(sub, "orders")).From("users").Where(Eq{"active": true}).PlaceholderFormat(Dollar)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		qb.ToSql()
+	}
+}
@@ -0,0 +1,268 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sequenceExpr renders the next or current value of a sequence
+type sequenceExpr struct {
+	name    string
+	next    bool
+	dialect Dialect
+}
+
+// NextVal builds an expression for the next value of the sequence name.
+//
+// It is rendered for the dialect of the builder it is used in, or the one
+// set with For. Used on its own, or in a builder without dialect, the
+// Oracle form is used.
+// Ex:
+//     Insert("users").Columns("id", "name").Values(NextVal("users_seq"), "Joe")
+//     == "INSERT INTO users (id,name) VALUES (users_seq.NEXTVAL,?)"
+//     ForDialect("postgres").Insert("users").Columns("id", "name").Values(NextVal("users_seq"), "Joe")
+//     == "INSERT INTO users (id,name) VALUES (nextval('users_seq'),$1)"
+func NextVal(name string) sequenceExpr {
+	return sequenceExpr{name: name, next: true}
+}
+
+// CurrVal builds an expression for the current value of the sequence name,
+// as last returned by NextVal in the session.
+func CurrVal(name string) sequenceExpr {
+	return sequenceExpr{name: name}
+}
+
+// For sets the dialect the expression is rendered for, overriding the one
+// of the builder it is used in.
+// Ex:
+//     NextVal("users_seq").For(Postgres) == "nextval('users_seq')"
+func (e sequenceExpr) For(dialect Dialect) sequenceExpr {
+	e.dialect = dialect
+	return e
+}
+
+func (e sequenceExpr) defaultDialect(d Dialect) Sqlizer {
+	if e.dialect == nil {
+		e.dialect = d
+	}
+	return e
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (e sequenceExpr) ToSql() (sql string, args []interface{}, err error) {
+	if e.name == "" {
+		err = fmt.Errorf("sequence expressions must have a sequence name")
+		return
+	}
+
+	switch name := dialectName(e.dialect); name {
+	case "", OracleName:
+		if e.next {
+			sql = e.name + ".NEXTVAL"
+		} else {
+			sql = e.name + ".CURRVAL"
+		}
+	case PostgresName:
+		fn := "currval"
+		if e.next {
+			fn = "nextval"
+		}
+		sql = fmt.Sprintf("%s('%s')", fn, strings.Replace(e.name, "'", "''", -1))
+	case SQLServerName:
+		if !e.next {
+			err = fmt.Errorf("sqlserver does not support the current value of sequences")
+			return
+		}
+		sql = "NEXT VALUE FOR " + e.name
+	default:
+		err = fmt.Errorf("%s does not support sequences", name)
+	}
+	return
+}
+
+// CreateSequenceBuilder builds SQL CREATE SEQUENCE statements.
+type CreateSequenceBuilder struct {
+	StatementBuilderType
+
+	name        string
+	ifNotExists bool
+	typ         string
+	start       *int64
+	increment   *int64
+	minValue    *int64
+	maxValue    *int64
+	cache       *int64
+	cycle       bool
+}
+
+// NewCreateSequenceBuilder creates new instance of CreateSequenceBuilder
+func NewCreateSequenceBuilder(b StatementBuilderType) *CreateSequenceBuilder {
+	return &CreateSequenceBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+func (b *CreateSequenceBuilder) RunWith(runner BaseRunnerContext) *CreateSequenceBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *CreateSequenceBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *CreateSequenceBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext.
+func (b *CreateSequenceBuilder) Timeout(d time.Duration) *CreateSequenceBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on.
+//
+// See StatementBuilderType.Dialect.
+func (b *CreateSequenceBuilder) Dialect(d Dialect) *CreateSequenceBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its sequence name.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *CreateSequenceBuilder) QuoteIdentifiers() *CreateSequenceBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b *CreateSequenceBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if len(b.name) == 0 {
+		err = fmt.Errorf("create sequence statements must specify a sequence")
+		return
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifier(b.name, identName); err != nil {
+			return
+		}
+	}
+	switch d := dialectName(b.dialect); d {
+	case MySQLName, SQLiteName:
+		err = fmt.Errorf("%s does not support sequences", d)
+		return
+	case OracleName:
+		if len(b.typ) > 0 {
+			err = fmt.Errorf("%s does not support sequence types", d)
+			return
+		}
+	}
+
+	sql := getBuffer()
+	defer putBuffer(sql)
+
+	sql.WriteString("CREATE SEQUENCE ")
+	if b.ifNotExists {
+		if !supports(b.dialect, FeatureIfNotExists) {
+			err = fmt.Errorf("%s does not support CREATE SEQUENCE IF NOT EXISTS", b.dialect.Name())
+			return
+		}
+		sql.WriteString("IF NOT EXISTS ")
+	}
+	sql.WriteString(b.quoteName(b.name, identName))
+
+	if len(b.typ) > 0 {
+		sql.WriteString(" AS ")
+		sql.WriteString(b.typ)
+	}
+	options := []struct {
+		keyword string
+		value   *int64
+	}{
+		{" START WITH ", b.start},
+		{" INCREMENT BY ", b.increment},
+		{" MINVALUE ", b.minValue},
+		{" MAXVALUE ", b.maxValue},
+		{" CACHE ", b.cache},
+	}
+	for _, o := range options {
+		if o.value != nil {
+			sql.WriteString(o.keyword)
+			sql.WriteString(strconv.FormatInt(*o.value, 10))
+		}
+	}
+	if b.cycle {
+		sql.WriteString(" CYCLE")
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+// Name sets the name of the sequence to create.
+func (b *CreateSequenceBuilder) Name(name string) *CreateSequenceBuilder {
+	b.name = name
+	return b
+}
+
+// IfNotExists adds IF NOT EXISTS, so the statement does nothing if the
+// sequence already exists.
+func (b *CreateSequenceBuilder) IfNotExists() *CreateSequenceBuilder {
+	b.ifNotExists = true
+	return b
+}
+
+// As sets the data type of the sequence, like bigint, on PostgreSQL and
+// SQL Server.
+func (b *CreateSequenceBuilder) As(typ string) *CreateSequenceBuilder {
+	b.typ = typ
+	return b
+}
+
+// StartWith sets the first value of the sequence.
+func (b *CreateSequenceBuilder) StartWith(start int64) *CreateSequenceBuilder {
+	b.start = &start
+	return b
+}
+
+// IncrementBy sets the value added to the sequence for every new value.
+func (b *CreateSequenceBuilder) IncrementBy(increment int64) *CreateSequenceBuilder {
+	b.increment = &increment
+	return b
+}
+
+// MinValue sets the minimum value of the sequence.
+func (b *CreateSequenceBuilder) MinValue(min int64) *CreateSequenceBuilder {
+	b.minValue = &min
+	return b
+}
+
+// MaxValue sets the maximum value of the sequence.
+func (b *CreateSequenceBuilder) MaxValue(max int64) *CreateSequenceBuilder {
+	b.maxValue = &max
+	return b
+}
+
+// Cache sets how many values of the sequence are allocated in advance.
+func (b *CreateSequenceBuilder) Cache(cache int64) *CreateSequenceBuilder {
+	b.cache = &cache
+	return b
+}
+
+// Cycle makes the sequence wrap around when it reaches its maximum value.
+func (b *CreateSequenceBuilder) Cycle() *CreateSequenceBuilder {
+	b.cycle = true
+	return b
+}
@@ -0,0 +1,157 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequenceToSql(t *testing.T) {
+	tests := []struct {
+		expr Sqlizer
+		sql  string
+	}{
+		{NextVal("users_seq"), "users_seq.NEXTVAL"},
+		{CurrVal("users_seq").For(Oracle), "users_seq.CURRVAL"},
+		{NextVal("users_seq").For(Postgres), "nextval('users_seq')"},
+		{CurrVal("users_seq").For(Postgres), "currval('users_seq')"},
+		{NextVal("users_seq").For(SQLServer), "NEXT VALUE FOR users_seq"},
+	}
+
+	for _, test := range tests {
+		sql, args, err := test.expr.ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Empty(t, args)
+	}
+}
+
+func TestSequenceErr(t *testing.T) {
+	invalid := []Sqlizer{
+		NextVal(""),
+		CurrVal("users_seq").For(SQLServer),
+		NextVal("users_seq").For(MySQL),
+		NextVal("users_seq").For(SQLite),
+	}
+
+	for _, expr := range invalid {
+		_, _, err := expr.ToSql()
+		assert.Error(t, err)
+	}
+}
+
+func TestSequenceInsert(t *testing.T) {
+	sql, args, err := StatementBuilder.Dialect(Oracle).Insert("users").
+		Columns("id", "name").
+		Values(NextVal("users_seq"), "Joe").
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name) VALUES (users_seq.NEXTVAL,:1)", sql)
+	assert.Equal(t, []interface{}{"Joe"}, args)
+}
+
+func TestCreateSequenceBuilderToSql(t *testing.T) {
+	sql, args, err := CreateSequence("users_seq").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE SEQUENCE users_seq", sql)
+	assert.Nil(t, args)
+
+	sql, _, err = CreateSequence("users_seq").IfNotExists().As("bigint").
+		StartWith(1000).IncrementBy(10).MinValue(1).MaxValue(1000000).Cache(20).Cycle().
+		Dialect(Postgres).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE SEQUENCE IF NOT EXISTS users_seq AS bigint START WITH 1000 INCREMENT BY 10 "+
+		"MINVALUE 1 MAXVALUE 1000000 CACHE 20 CYCLE", sql)
+
+	sql, _, err = CreateSequence("users_seq").StartWith(0).IncrementBy(-1).Dialect(Oracle).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE SEQUENCE users_seq START WITH 0 INCREMENT BY -1", sql)
+
+	_, _, err = CreateSequence("").ToSql()
+	assert.Error(t, err)
+	_, _, err = CreateSequence("users_seq").Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+	_, _, err = CreateSequence("users_seq").IfNotExists().Dialect(SQLServer).ToSql()
+	assert.Error(t, err)
+	_, _, err = CreateSequence("users_seq").As("bigint").Dialect(Oracle).ToSql()
+	assert.Error(t, err)
+}
+
+func TestSequenceInBuilders(t *testing.T) {
+	seq := NextVal("users_seq").For(Postgres)
+	sql, args, err := Insert("users").Columns("id", "name").Values(seq, "Joe").PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name) VALUES (nextval('users_seq'),$1)", sql)
+	assert.Equal(t, []interface{}{"Joe"}, args)
+
+	sql, _, err = Select().Column(CurrVal("users_seq").For(Postgres)).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT currval('users_seq')", sql)
+}
+
+func TestSequenceBuilderDialect(t *testing.T) {
+	psql := ForDialect("postgres")
+	sql, args, err := psql.Insert("users").Columns("id", "name").Values(NextVal("users_seq"), "Joe").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name) VALUES (nextval('users_seq'),$1)", sql)
+	assert.Equal(t, []interface{}{"Joe"}, args)
+
+	sql, _, err = psql.Select().Column(CurrVal("users_seq")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT currval('users_seq')", sql)
+
+	sql, _, err = psql.Update("users").Set("id", NextVal("users_seq")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET id = nextval('users_seq')", sql)
+
+	sql, _, err = psql.Select().Column(NextVal("users_seq").For(Oracle)).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT users_seq.NEXTVAL", sql, "For overrides the builder dialect")
+
+	_, _, err = StatementBuilder.Dialect(SQLServer).Select().Column(CurrVal("users_seq")).ToSql()
+	assert.Error(t, err)
+}
+
+func TestSequenceMergeDialect(t *testing.T) {
+	sql, args, err := Merge("users t").Dialect(SQLServer).
+		UsingValues("s", []string{"id", "name"}, []interface{}{NextVal("users_seq"), "Joe"}).
+		On("t.name = s.name").
+		WhenMatched().Set("id", NextVal("users_seq")).
+		WhenNotMatched().Insert("id", "name").Values(NextVal("users_seq"), Expr("s.name")).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO users t USING (VALUES (NEXT VALUE FOR users_seq,?)) AS s (id,name) ON t.name = s.name "+
+		"WHEN MATCHED THEN UPDATE SET id = NEXT VALUE FOR users_seq "+
+		"WHEN NOT MATCHED THEN INSERT (id,name) VALUES (NEXT VALUE FOR users_seq,s.name);", sql)
+	assert.Equal(t, []interface{}{"Joe"}, args)
+
+	sql, _, err = Merge("users t").Dialect(Postgres).
+		Using("staging s").
+		On(Eq{"t.id": CurrVal("users_seq")}).
+		WhenNotMatched().Values(NextVal("users_seq")).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO users t USING staging s ON t.id = currval('users_seq') "+
+		"WHEN NOT MATCHED THEN INSERT VALUES (nextval('users_seq'))", sql)
+}
+
+func TestSequencePredicateDialect(t *testing.T) {
+	psql := StatementBuilder.Dialect(Postgres)
+	sql, args, err := psql.Select("*").From("users").Where(Eq{"id": CurrVal("users_seq")}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = currval('users_seq')", sql)
+	assert.Empty(t, args)
+
+	sql, _, err = psql.Select("*").From("users").
+		Where(Or{Gt{"id": CurrVal("a_seq")}, Not(NotEq{"id": CurrVal("b_seq")})}).
+		Having(And{LikeOr{"name": CurrVal("c_seq")}}).
+		Where(map[string]interface{}{"id": CurrVal("d_seq")}).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE (id > (currval('a_seq')) OR NOT (id <> currval('b_seq'))) "+
+		"AND id = currval('d_seq') HAVING (name LIKE currval('c_seq'))", sql)
+
+	sql, _, err = Select("*").From("users").Where(Eq{"id": CurrVal("users_seq")}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = users_seq.CURRVAL", sql)
+}
@@ -0,0 +1,300 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetBuilder builds SQL SET statements, which change a setting of the
+// session, like statement_timeout or search_path.
+type SetBuilder struct {
+	StatementBuilderType
+
+	name   string
+	values []interface{}
+	local  bool
+}
+
+// NewSetBuilder creates new instance of SetBuilder
+func NewSetBuilder(b StatementBuilderType) *SetBuilder {
+	return &SetBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+//
+// Settings only apply to the connection the statement is run on, so it
+// should be a *sql.Conn or *sql.Tx rather than a *sql.DB.
+func (b *SetBuilder) RunWith(runner BaseRunnerContext) *SetBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b *SetBuilder) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext builds and Execs the query with the Runner set by RunWith using given context.
+func (b *SetBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecWithContext(ctx, b.runWith, b)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext.
+func (b *SetBuilder) Timeout(d time.Duration) *SetBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on. MySQL
+// renders SET SESSION, SQLite PRAGMA, SQL Server SET without = and Oracle
+// ALTER SESSION SET.
+//
+// See StatementBuilderType.Dialect.
+func (b *SetBuilder) Dialect(d Dialect) *SetBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args.
+//
+// On PostgreSQL the setting is changed with set_config, which binds the
+// name and the values, joined with ", ". Other databases don't bind args in
+// SET statements, so the values are escaped into the statement as literals
+// and no args are returned. Only NULL, booleans, numbers and strings can be
+// escaped, and Sqlizer values are rendered as SET statements on PostgreSQL
+// too.
+func (b *SetBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if err = b.checkSettingName(b.name); err != nil {
+		return
+	}
+	if len(b.values) == 0 {
+		err = fmt.Errorf("set statements must specify a value")
+		return
+	}
+	d := dialectName(b.dialect)
+	if b.local && d != "" && d != PostgresName {
+		err = fmt.Errorf("%s does not support SET LOCAL", d)
+		return
+	}
+	if len(b.values) > 1 && d != "" && d != PostgresName {
+		err = fmt.Errorf("%s does not support settings with several values", d)
+		return
+	}
+
+	if d == PostgresName && !hasSqlizer(b.values) {
+		return b.setConfigSql()
+	}
+
+	sql := getBuffer()
+	defer putBuffer(sql)
+
+	switch d {
+	case MySQLName:
+		sql.WriteString("SET SESSION ")
+	case SQLiteName:
+		sql.WriteString("PRAGMA ")
+	case OracleName:
+		sql.WriteString("ALTER SESSION SET ")
+	default:
+		sql.WriteString("SET ")
+		if b.local {
+			sql.WriteString("LOCAL ")
+		}
+	}
+	sql.WriteString(b.name)
+	if d == SQLServerName {
+		sql.WriteString(" ")
+	} else {
+		sql.WriteString(" = ")
+	}
+
+	for i, v := range b.values {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		var literal string
+		if s, ok := v.(Sqlizer); ok {
+			literal, err = escapeSql(s, b.dialect)
+		} else {
+			literal, err = escapeLiteral(v, b.dialect)
+		}
+		if err != nil {
+			return
+		}
+		sql.WriteString(literal)
+	}
+
+	sqlStr = sql.String()
+	return
+}
+
+// setConfigSql renders the PostgreSQL set_config call changing the setting.
+func (b *SetBuilder) setConfigSql() (sqlStr string, args []interface{}, err error) {
+	values := make([]string, len(b.values))
+	for i, v := range b.values {
+		if values[i], err = settingValue(v); err != nil {
+			return
+		}
+	}
+	sqlStr = fmt.Sprintf("SELECT set_config(?, ?, %t)", b.local)
+	return replaceFormat(b.placeholderFormat, sqlStr, []interface{}{b.name, strings.Join(values, ", ")})
+}
+
+// settingValue converts v into the text of a setting value.
+func settingValue(v interface{}) (string, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		var err error
+		if v, err = valuer.Value(); err != nil {
+			return "", err
+		}
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("cannot set a setting to arg of type %T", v)
+}
+
+// Name sets the name of the setting.
+func (b *SetBuilder) Name(name string) *SetBuilder {
+	b.name = name
+	return b
+}
+
+// To sets the value of the setting. Several values set a list, like the
+// schemas of search_path on PostgreSQL. Values may be Sqlizers, e.g.
+// Expr("DEFAULT").
+// Ex:
+//     Set("search_path").To("tenant_1", "public")
+//     == "SET search_path = 'tenant_1', 'public'"
+//     Set("search_path").To("tenant_1", "public").Dialect(Postgres)
+//     == "SELECT set_config($1, $2, false)", "search_path", "tenant_1, public"
+func (b *SetBuilder) To(values ...interface{}) *SetBuilder {
+	b.values = values
+	return b
+}
+
+// Local makes the setting last until the end of the transaction, with the
+// PostgreSQL SET LOCAL.
+func (b *SetBuilder) Local() *SetBuilder {
+	b.local = true
+	return b
+}
+
+// ShowBuilder builds SQL SHOW statements, which read a setting of the
+// session.
+type ShowBuilder struct {
+	StatementBuilderType
+
+	name string
+}
+
+// NewShowBuilder creates new instance of ShowBuilder
+func NewShowBuilder(b StatementBuilderType) *ShowBuilder {
+	return &ShowBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Query.
+func (b *ShowBuilder) RunWith(runner BaseRunnerContext) *ShowBuilder {
+	b.runWith = wrapRunner(runner)
+	return b
+}
+
+// QueryRow builds and QueryRows the query with the Runner set by RunWith.
+func (b *ShowBuilder) QueryRow() RowScanner {
+	return b.QueryRowContext(context.Background())
+}
+
+// QueryRowContext builds and QueryRows the query with the Runner set by RunWith in given context.
+func (b *ShowBuilder) QueryRowContext(ctx context.Context) RowScanner {
+	if b.runWith == nil {
+		return &Row{err: ErrRunnerNotSet}
+	}
+	queryRower, ok := b.runWith.(QueryRowerContext)
+	if !ok {
+		return &Row{err: ErrRunnerNotQueryRunnerContext}
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	return &timeoutRow{RowScanner: QueryRowWithContext(ctx, queryRower, b), cancel: cancel}
+}
+
+// Scan is a shortcut for QueryRow().Scan.
+func (b *ShowBuilder) Scan(dest ...interface{}) error {
+	return b.QueryRow().Scan(dest...)
+}
+
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. QueryRowContext.
+func (b *ShowBuilder) Timeout(d time.Duration) *ShowBuilder {
+	b.timeout = d
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on. MySQL
+// selects the session variable, and SQLite renders PRAGMA.
+//
+// See StatementBuilderType.Dialect.
+func (b *ShowBuilder) Dialect(d Dialect) *ShowBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// ToSql builds the query into a SQL string and bound args. The query
+// returns a single row with the value of the setting.
+func (b *ShowBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+	if err = b.checkSettingName(b.name); err != nil {
+		return
+	}
+	switch d := dialectName(b.dialect); d {
+	case MySQLName:
+		sqlStr = "SELECT @@SESSION." + b.name
+	case SQLiteName:
+		sqlStr = "PRAGMA " + b.name
+	case SQLServerName, OracleName:
+		err = fmt.Errorf("%s does not support SHOW", d)
+	default:
+		sqlStr = "SHOW " + b.name
+	}
+	return
+}
+
+// Name sets the name of the setting.
+func (b *ShowBuilder) Name(name string) *ShowBuilder {
+	b.name = name
+	return b
+}
+
+// checkSettingName returns an error if name isn't the name of a setting,
+// a dot separated list of letters, digits and underscores like
+// statement_timeout or myapp.tenant_id, since it can't be quoted.
+func (b StatementBuilderType) checkSettingName(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("session statements must specify a setting")
+	}
+	for _, part := range strings.Split(name, ".") {
+		if part == "" || (part[0] >= '0' && part[0] <= '9') {
+			return fmt.Errorf("invalid setting name %q", name)
+		}
+		for _, r := range part {
+			if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+				return fmt.Errorf("invalid setting name %q", name)
+			}
+		}
+	}
+	if b.allowedIdentifiers != nil && !b.allowedIdentifiers[name] {
+		return fmt.Errorf("identifier %q is not allowed", name)
+	}
+	return nil
+}
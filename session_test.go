@@ -0,0 +1,116 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetBuilderToSql(t *testing.T) {
+	sql, args, err := Set("statement_timeout", "5s").Local().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SET LOCAL statement_timeout = '5s'", sql)
+	assert.Nil(t, args)
+
+	sql, args, err = Set("search_path").To("tenant's", "public").Dialect(Postgres).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT set_config($1, $2, false)", sql)
+	assert.Equal(t, []interface{}{"search_path", "tenant's, public"}, args)
+
+	sql, args, err = Set("statement_timeout", 5000).Local().Dialect(Postgres).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT set_config($1, $2, true)", sql)
+	assert.Equal(t, []interface{}{"statement_timeout", "5000"}, args)
+
+	sql, _, err = Set("search_path").To("a", "b").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SET search_path = 'a', 'b'", sql)
+
+	sql, _, err = Set("application_name", `x\'; DROP TABLE users; --`).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `SET application_name = E'x\\''; DROP TABLE users; --'`, sql)
+
+	sql, _, err = Set("sql_mode", `\'; DROP TABLE users; --`).Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `SET SESSION sql_mode = '\\''; DROP TABLE users; --'`, sql)
+
+	sql, _, err = Set("myapp.tenant_id", Expr("current_setting(?)", "x'y")).Dialect(Postgres).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SET myapp.tenant_id = current_setting('x''y')", sql)
+
+	sql, _, err = Set("myapp.tenant_id", Expr("DEFAULT")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SET myapp.tenant_id = DEFAULT", sql)
+
+	sql, _, err = Set("sql_safe_updates", true).Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SET SESSION sql_safe_updates = 1", sql)
+
+	sql, _, err = Set("foreign_keys", Expr("ON")).Dialect(SQLite).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "PRAGMA foreign_keys = ON", sql)
+
+	sql, _, err = Set("LOCK_TIMEOUT", 1000).Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SET LOCK_TIMEOUT 1000", sql)
+
+	sql, _, err = Set("NLS_DATE_FORMAT", "YYYY-MM-DD").Dialect(Oracle).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "ALTER SESSION SET NLS_DATE_FORMAT = 'YYYY-MM-DD'", sql)
+}
+
+func TestSetBuilderToSqlErr(t *testing.T) {
+	invalid := []*SetBuilder{
+		Set("", 1),
+		Set("statement_timeout"),
+		Set("statement_timeout = 0; DROP TABLE users; --", 1),
+		Set("1abc", 1),
+		Set("myapp.", 1),
+		Set("time_zone", "UTC").Local().Dialect(MySQL),
+		Set("search_path", "a", "b").Dialect(Oracle),
+		Set("statement_timeout", struct{}{}),
+		Set("statement_timeout", struct{}{}).Dialect(Postgres),
+		Set("statement_timeout", []byte("x")),
+		Set("myapp.tenant_id", Expr("?", []int{1})),
+		StatementBuilder.StrictIdentifiers("search_path").Set("statement_timeout", 0),
+	}
+	for _, b := range invalid {
+		_, _, err := b.ToSql()
+		assert.Error(t, err)
+	}
+}
+
+func TestShowBuilderToSql(t *testing.T) {
+	sql, args, err := Show("statement_timeout").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SHOW statement_timeout", sql)
+	assert.Nil(t, args)
+
+	sql, _, err = Show("time_zone").Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT @@SESSION.time_zone", sql)
+
+	sql, _, err = Show("journal_mode").Dialect(SQLite).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "PRAGMA journal_mode", sql)
+
+	_, _, err = Show("x; SELECT 1").ToSql()
+	assert.Error(t, err)
+	_, _, err = Show("LOCK_TIMEOUT").Dialect(SQLServer).ToSql()
+	assert.Error(t, err)
+}
+
+func TestSessionBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	_, err := Set("statement_timeout", 0).RunWith(db).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, "SET statement_timeout = 0", db.LastExecSql)
+
+	Show("statement_timeout").RunWith(db).QueryRow()
+	assert.Equal(t, "SHOW statement_timeout", db.LastQueryRowSql)
+
+	_, err = Set("statement_timeout", 0).Exec()
+	assert.Equal(t, ErrRunnerNotSet, err)
+	err = Show("statement_timeout").Scan()
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
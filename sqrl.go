@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 )
 
 // Sqlizer is the interface that wraps the ToSql method.
@@ -17,6 +18,15 @@ type Sqlizer interface {
 	ToSql() (string, []interface{}, error)
 }
 
+// SqlAppender is the interface that wraps the AppendToSql method.
+//
+// AppendToSql writes the SQL of a Sqlizer to w and appends its args to args,
+// so it can be built into a larger statement without an intermediate string.
+// It must write the same, non-empty, SQL as ToSql, with ? placeholders.
+type SqlAppender interface {
+	AppendToSql(w io.Writer, args []interface{}) ([]interface{}, error)
+}
+
 // Execer is the interface that wraps the Exec method.
 //
 // Exec executes the given query as implemented by database/sql.Exec.
@@ -67,6 +77,15 @@ type BaseRunner interface {
 	QueryerContext
 }
 
+// BaseRunnerContext groups the ExecerContext and QueryerContext interfaces.
+//
+// It is implemented by *sql.DB, *sql.Tx and *sql.Conn, the latter being
+// useful to run queries on a pinned connection.
+type BaseRunnerContext interface {
+	ExecerContext
+	QueryerContext
+}
+
 // Runner groups the Execer, Queryer, and QueryRower interfaces.
 type Runner interface {
 	Execer
@@ -104,6 +123,36 @@ func ExecWithContext(ctx context.Context, db ExecerContext, s Sqlizer) (res sql.
 	return db.ExecContext(ctx, query, args...)
 }
 
+// RowsAffectedError is returned by ExecAffected if the statement ran, but
+// the driver couldn't report the number of rows it affected.
+type RowsAffectedError struct {
+	Err error
+}
+
+func (e *RowsAffectedError) Error() string {
+	return "cannot get rows affected: " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by RowsAffected.
+func (e *RowsAffectedError) Unwrap() error {
+	return e.Err
+}
+
+// ExecAffectedWithContext Execs the SQL returned by s with db and returns the
+// number of rows affected. If the driver doesn't support it, a
+// *RowsAffectedError is returned.
+func ExecAffectedWithContext(ctx context.Context, db ExecerContext, s Sqlizer) (int64, error) {
+	res, err := ExecWithContext(ctx, db, s)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, &RowsAffectedError{Err: err}
+	}
+	return n, nil
+}
+
 // QueryWith Querys the SQL returned by s with db.
 func QueryWith(db Queryer, s Sqlizer) (rows *sql.Rows, err error) {
 	query, args, err := s.ToSql()
@@ -160,15 +209,35 @@ func (r *txRunner) QueryRowContext(ctx context.Context, query string, args ...in
 	return r.Tx.QueryRowContext(ctx, query, args...)
 }
 
-// WrapRunner returns Runner for sql.DB and sql.Tx, or BaseRunner otherwise.
-func wrapRunner(baseRunner BaseRunner) (runner BaseRunner) {
+// connRunner wraps runners returning *sql.Row from QueryRowContext, like
+// sql.Conn, to implement QueryRowerContext.
+type connRunner struct {
+	BaseRunnerContext
+	queryRower sqlQueryRowerContext
+}
+
+type sqlQueryRowerContext interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (r *connRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	return r.queryRower.QueryRowContext(ctx, query, args...)
+}
+
+// WrapRunner returns Runner for sql.DB and sql.Tx, a runner implementing
+// QueryRowerContext for sql.Conn and alike, or baseRunner otherwise.
+func wrapRunner(baseRunner BaseRunnerContext) (runner BaseRunnerContext) {
 	switch r := baseRunner.(type) {
 	case *sql.DB:
 		runner = &dbRunner{r}
 	case *sql.Tx:
 		runner = &txRunner{r}
+	case QueryRowerContext:
+		runner = baseRunner
+	case sqlQueryRowerContext:
+		runner = &connRunner{BaseRunnerContext: baseRunner, queryRower: r}
 	default:
-		runner = r
+		runner = baseRunner
 	}
 	return
 }
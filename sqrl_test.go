@@ -3,6 +3,8 @@ package sqrl
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -106,3 +108,24 @@ func TestWithToSqlErr(t *testing.T) {
 	err = QueryRowWith(db, sqlizer).Scan()
 	assert.Error(t, err)
 }
+
+func TestExecAffected(t *testing.T) {
+	ctx := context.Background()
+	runner := &CaptureRunner{Result: driver.RowsAffected(3)}
+
+	n, err := Update("t").Set("a", 1).RunWith(runner).ExecAffected(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	n, err = Delete("t").RunWith(runner).ExecAffected(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	runner.Result = driver.ResultNoRows
+	_, err = Insert("t").Values(1).RunWith(runner).ExecAffected(ctx)
+	var affectedErr *RowsAffectedError
+	assert.True(t, errors.As(err, &affectedErr))
+
+	_, err = Insert("t").Values(1).ExecAffected(ctx)
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
@@ -0,0 +1,272 @@
+// Package sqrlgen generates Go constants for the tables and columns of a
+// database schema, along with builders for its tables, so the names used
+// with sqrl are checked by the compiler.
+//
+// It is run with go generate from a small program registering the
+// database/sql driver:
+//
+//     //go:build ignore
+//
+//     package main
+//
+//     import (
+//         _ "github.com/lib/pq"
+//         "github.com/rubenhazelaar/sqrl/sqrlgen"
+//     )
+//
+//     func main() {
+//         sqrlgen.Main()
+//     }
+//
+// and
+//
+//     //go:generate go run gen.go -driver postgres -dsn $DATABASE_URL -pkg models -o schema_gen.go
+package sqrlgen
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rubenhazelaar/sqrl"
+)
+
+// Table is a table of a schema.
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+// Column is a column of a table.
+type Column struct {
+	Name     string
+	Type     string
+	Nullable bool
+}
+
+// LoadSchema reads the tables of schema from information_schema, e.g.
+// "public" on PostgreSQL or the database name on MySQL. The query is built
+// for the dialect d.
+func LoadSchema(ctx context.Context, db sqrl.QueryerContext, d sqrl.Dialect, schema string) ([]Table, error) {
+	if d != nil && (d.Name() == sqrl.SQLiteName || d.Name() == sqrl.OracleName) {
+		return nil, fmt.Errorf("%s has no information_schema", d.Name())
+	}
+
+	q := sqrl.Select("table_name", "column_name", "data_type", "is_nullable").
+		From("information_schema.columns").
+		Where(sqrl.Eq{"table_schema": schema}).
+		OrderBy("table_name", "ordinal_position")
+	if d != nil {
+		q = q.Dialect(d)
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []Table
+	for rows.Next() {
+		var table, nullable string
+		var c Column
+		if err := rows.Scan(&table, &c.Name, &c.Type, &nullable); err != nil {
+			return nil, err
+		}
+		c.Nullable = nullable == "YES"
+
+		if len(tables) == 0 || tables[len(tables)-1].Name != table {
+			tables = append(tables, Table{Name: table})
+		}
+		t := &tables[len(tables)-1]
+		t.Columns = append(t.Columns, c)
+	}
+	return tables, rows.Err()
+}
+
+// Generate writes the Go source of package pkg declaring the tables to w.
+//
+// For a table users with the columns id and email it declares
+//
+//     const Users = "users"
+//
+//     const (
+//         UsersID    = "id"
+//         UsersEmail = "email"
+//     )
+//
+//     var UsersColumns = []string{UsersID, UsersEmail}
+//
+// and the functions SelectUsers, InsertUsers, UpdateUsers and DeleteUsers
+// returning builders for the table.
+func Generate(w io.Writer, pkg string, tables []Table) error {
+	tables = append([]Table(nil), tables...)
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by sqrlgen. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	fmt.Fprintf(buf, "import \"github.com/rubenhazelaar/sqrl\"\n")
+
+	used := map[string]bool{}
+	for _, t := range tables {
+		if len(t.Columns) == 0 {
+			continue
+		}
+		name := uniqueName(goName(t.Name), used,
+			"Columns", "Select", "Insert", "Update", "Delete")
+
+		fmt.Fprintf(buf, "\n// %s is the table %s.\nconst %s = %q\n", name, t.Name, name, t.Name)
+
+		fmt.Fprintf(buf, "\n// Columns of the table %s.\nconst (\n", t.Name)
+		columns := make([]string, len(t.Columns))
+		for i, c := range t.Columns {
+			columns[i] = uniqueName(name+goName(c.Name), used)
+			nullable := ""
+			if c.Nullable {
+				nullable = ", nullable"
+			}
+			fmt.Fprintf(buf, "\t%s = %q // %s%s\n", columns[i], c.Name, c.Type, nullable)
+		}
+		fmt.Fprintf(buf, ")\n")
+
+		fmt.Fprintf(buf, "\n// %sColumns lists the columns of the table %s.\n", name, t.Name)
+		fmt.Fprintf(buf, "var %sColumns = []string{%s}\n", name, strings.Join(columns, ", "))
+
+		fmt.Fprintf(buf, "\n// Select%s returns a SelectBuilder selecting all columns of %s.\n", name, t.Name)
+		fmt.Fprintf(buf, "func Select%s() *sqrl.SelectBuilder {\n\treturn sqrl.Select(%sColumns...).From(%s)\n}\n", name, name, name)
+		fmt.Fprintf(buf, "\n// Insert%s returns an InsertBuilder inserting into %s.\n", name, t.Name)
+		fmt.Fprintf(buf, "func Insert%s() *sqrl.InsertBuilder {\n\treturn sqrl.Insert(%s)\n}\n", name, name)
+		fmt.Fprintf(buf, "\n// Update%s returns an UpdateBuilder updating %s.\n", name, t.Name)
+		fmt.Fprintf(buf, "func Update%s() *sqrl.UpdateBuilder {\n\treturn sqrl.Update(%s)\n}\n", name, name)
+		fmt.Fprintf(buf, "\n// Delete%s returns a DeleteBuilder deleting from %s.\n", name, t.Name)
+		fmt.Fprintf(buf, "func Delete%s() *sqrl.DeleteBuilder {\n\treturn sqrl.Delete(%s)\n}\n", name, name)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// Main runs the generator configured by command line flags, see the package
+// documentation. The database/sql driver must have been registered.
+func Main() {
+	driver := flag.String("driver", "postgres", "database/sql driver name")
+	dsn := flag.String("dsn", "", "data source name of the database")
+	schema := flag.String("schema", "public", "schema to generate, the database name on MySQL")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file")
+	out := flag.String("o", "schema_gen.go", "generated file")
+	flag.Parse()
+
+	if err := run(*driver, *dsn, *schema, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "sqrlgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(driver, dsn, schema, pkg, out string) error {
+	if pkg == "" {
+		return fmt.Errorf("no package given, use -pkg")
+	}
+	d, err := sqrl.LookupDialect(driver)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tables, err := LoadSchema(context.Background(), db, d, schema)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := Generate(buf, pkg, tables); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(out, buf.Bytes(), 0666)
+}
+
+// commonInitialisms are written in upper case in Go names.
+var commonInitialisms = map[string]bool{
+	"API": true, "CPU": true, "CSS": true, "DNS": true, "HTML": true, "HTTP": true,
+	"ID": true, "IP": true, "JSON": true, "SQL": true, "TCP": true, "TTL": true,
+	"UID": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// goName returns the exported Go name of the SQL name s, e.g. UserID for
+// user_id.
+func goName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var name strings.Builder
+	for _, w := range words {
+		upper := strings.ToUpper(w)
+		if commonInitialisms[upper] {
+			name.WriteString(upper)
+			continue
+		}
+		if w == upper {
+			w = strings.ToLower(w)
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		name.WriteString(string(r))
+	}
+
+	if name.Len() == 0 {
+		return "X"
+	}
+	if first := []rune(name.String())[0]; !unicode.IsLetter(first) {
+		return "X" + name.String()
+	}
+	return name.String()
+}
+
+// uniqueName returns name, with a number appended if it or name followed by
+// one of the suffixes was already used, and marks them as used.
+func uniqueName(name string, used map[string]bool, suffixes ...string) string {
+	taken := func(n string) bool {
+		if used[n] {
+			return true
+		}
+		for _, s := range suffixes {
+			if used[n+s] || used[s+n] {
+				return true
+			}
+		}
+		return false
+	}
+
+	unique := name
+	for i := 2; taken(unique); i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	used[unique] = true
+	for _, s := range suffixes {
+		used[unique+s] = true
+		used[s+unique] = true
+	}
+	return unique
+}
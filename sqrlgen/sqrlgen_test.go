@@ -0,0 +1,50 @@
+package sqrlgen_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rubenhazelaar/sqrl"
+	"github.com/rubenhazelaar/sqrl/sqrlgen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	tables := []sqrlgen.Table{
+		{Name: "users", Columns: []sqrlgen.Column{
+			{Name: "id", Type: "bigint"},
+			{Name: "email", Type: "text", Nullable: true},
+			{Name: "columns", Type: "integer"},
+		}},
+		{Name: "order_items", Columns: []sqrlgen.Column{
+			{Name: "order_id", Type: "bigint"},
+			{Name: "2fa", Type: "boolean"},
+		}},
+		{Name: "empty"},
+	}
+
+	var buf bytes.Buffer
+	err := sqrlgen.Generate(&buf, "models", tables)
+	assert.NoError(t, err)
+
+	src := buf.String()
+	assert.Contains(t, src, "// Code generated by sqrlgen. DO NOT EDIT.\n\npackage models\n")
+	assert.Contains(t, src, `const Users = "users"`)
+	assert.Contains(t, src, `UsersID       = "id"      // bigint`)
+	assert.Contains(t, src, `UsersEmail    = "email"   // text, nullable`)
+	assert.Contains(t, src, `UsersColumns2 = "columns" // integer`)
+	assert.Contains(t, src, "var UsersColumns = []string{UsersID, UsersEmail, UsersColumns2}")
+	assert.Contains(t, src, "func SelectUsers() *sqrl.SelectBuilder {\n\treturn sqrl.Select(UsersColumns...).From(Users)\n}")
+	assert.Contains(t, src, `const OrderItems = "order_items"`)
+	assert.Contains(t, src, `OrderItemsOrderID = "order_id"`)
+	assert.Contains(t, src, `OrderItemsX2fa`)
+	assert.Contains(t, src, "func DeleteOrderItems() *sqrl.DeleteBuilder {")
+	assert.NotContains(t, src, "Empty")
+	assert.True(t, bytes.Index(buf.Bytes(), []byte("OrderItems")) < bytes.Index(buf.Bytes(), []byte("Users")))
+}
+
+func TestLoadSchemaUnsupported(t *testing.T) {
+	_, err := sqrlgen.LoadSchema(context.Background(), nil, sqrl.SQLite, "main")
+	assert.Error(t, err)
+}
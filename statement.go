@@ -1,9 +1,23 @@
 package sqrl
 
+import (
+	"context"
+	"time"
+)
+
 // StatementBuilderType is the type of StatementBuilder.
 type StatementBuilderType struct {
 	placeholderFormat PlaceholderFormat
-	runWith           BaseRunner
+	runWith           BaseRunnerContext
+	timeout           time.Duration
+
+	strictPlaceholders bool
+	strictIdentifiers  bool
+	allowedIdentifiers map[string]bool
+
+	dialect          Dialect
+	quoteIdentifiers bool
+	memoize          bool
 }
 
 // Select returns a SelectBuilder for this StatementBuilder.
@@ -26,6 +40,62 @@ func (b StatementBuilderType) Delete(what ...string) *DeleteBuilder {
 	return NewDeleteBuilder(b).What(what...)
 }
 
+// Merge returns a MergeBuilder for this StatementBuilder.
+func (b StatementBuilderType) Merge(into string) *MergeBuilder {
+	return NewMergeBuilder(b).Into(into)
+}
+
+// CreateTable returns a CreateTableBuilder for this StatementBuilder.
+func (b StatementBuilderType) CreateTable(table string) *CreateTableBuilder {
+	return NewCreateTableBuilder(b).Table(table)
+}
+
+// CreateIndex returns a CreateIndexBuilder for this StatementBuilder.
+func (b StatementBuilderType) CreateIndex(name string) *CreateIndexBuilder {
+	return NewCreateIndexBuilder(b).Name(name)
+}
+
+// DropTable returns a DropTableBuilder for this StatementBuilder.
+func (b StatementBuilderType) DropTable(tables ...string) *DropTableBuilder {
+	return NewDropTableBuilder(b).Tables(tables...)
+}
+
+// DropIndex returns a DropIndexBuilder for this StatementBuilder.
+func (b StatementBuilderType) DropIndex(name string) *DropIndexBuilder {
+	return NewDropIndexBuilder(b).Name(name)
+}
+
+// CreateSequence returns a CreateSequenceBuilder for this StatementBuilder.
+func (b StatementBuilderType) CreateSequence(name string) *CreateSequenceBuilder {
+	return NewCreateSequenceBuilder(b).Name(name)
+}
+
+// CommentOn returns a CommentBuilder for this StatementBuilder.
+func (b StatementBuilderType) CommentOn(object, name, text string) *CommentBuilder {
+	return NewCommentBuilder(b).On(object, name).Is(text)
+}
+
+// CommentOnColumn returns a CommentBuilder commenting on a column for this
+// StatementBuilder.
+func (b StatementBuilderType) CommentOnColumn(table, column, text string) *CommentBuilder {
+	return b.CommentOn("COLUMN", table+"."+column, text)
+}
+
+// LockTable returns a LockTableBuilder for this StatementBuilder.
+func (b StatementBuilderType) LockTable(tables ...string) *LockTableBuilder {
+	return NewLockTableBuilder(b).Tables(tables...)
+}
+
+// Set returns a SetBuilder for this StatementBuilder.
+func (b StatementBuilderType) Set(name string, values ...interface{}) *SetBuilder {
+	return NewSetBuilder(b).Name(name).To(values...)
+}
+
+// Show returns a ShowBuilder for this StatementBuilder.
+func (b StatementBuilderType) Show(name string) *ShowBuilder {
+	return NewShowBuilder(b).Name(name)
+}
+
 // PlaceholderFormat sets the PlaceholderFormat field for any child builders.
 func (b StatementBuilderType) PlaceholderFormat(f PlaceholderFormat) StatementBuilderType {
 	b.placeholderFormat = f
@@ -33,11 +103,48 @@ func (b StatementBuilderType) PlaceholderFormat(f PlaceholderFormat) StatementBu
 }
 
 // RunWith sets the RunWith field for any child builders.
-func (b StatementBuilderType) RunWith(runner BaseRunner) StatementBuilderType {
+func (b StatementBuilderType) RunWith(runner BaseRunnerContext) StatementBuilderType {
 	b.runWith = wrapRunner(runner)
 	return b
 }
 
+// Timeout sets the Timeout for any child builders.
+func (b StatementBuilderType) Timeout(d time.Duration) StatementBuilderType {
+	b.timeout = d
+	return b
+}
+
+// timeoutContext returns ctx with the timeout set by Timeout applied.
+func (b StatementBuilderType) timeoutContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if b.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, b.timeout)
+}
+
+// queryTimeoutContext is like timeoutContext for queries returning rows.
+// Cancelling the context closes the rows, so it isn't cancelled: its timer
+// releases it once the timeout expires.
+func (b StatementBuilderType) queryTimeoutContext(ctx context.Context) context.Context {
+	if b.timeout <= 0 {
+		return ctx
+	}
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	_ = cancel
+	return ctx
+}
+
+// timeoutRow releases the context of QueryRow once the row is scanned.
+type timeoutRow struct {
+	RowScanner
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRow) Scan(dest ...interface{}) error {
+	defer r.cancel()
+	return r.RowScanner.Scan(dest...)
+}
+
 // StatementBuilder is a basic statement builder, holds global configuration options
 // like placeholder format or SQL runner
 var StatementBuilder = StatementBuilderType{placeholderFormat: Question}
@@ -70,6 +177,84 @@ func Delete(what ...string) *DeleteBuilder {
 	return StatementBuilder.Delete(what...)
 }
 
+// Merge returns a new MergeBuilder with the given target table name.
+//
+// See MergeBuilder.Into.
+func Merge(into string) *MergeBuilder {
+	return StatementBuilder.Merge(into)
+}
+
+// CreateTable returns a new CreateTableBuilder with the given table name.
+//
+// See CreateTableBuilder.Table.
+func CreateTable(table string) *CreateTableBuilder {
+	return StatementBuilder.CreateTable(table)
+}
+
+// CreateIndex returns a new CreateIndexBuilder with the given index name.
+//
+// See CreateIndexBuilder.On.
+func CreateIndex(name string) *CreateIndexBuilder {
+	return StatementBuilder.CreateIndex(name)
+}
+
+// DropTable returns a new DropTableBuilder for the given table names.
+//
+// See DropTableBuilder.Tables.
+func DropTable(tables ...string) *DropTableBuilder {
+	return StatementBuilder.DropTable(tables...)
+}
+
+// DropIndex returns a new DropIndexBuilder with the given index name.
+//
+// See DropIndexBuilder.On.
+func DropIndex(name string) *DropIndexBuilder {
+	return StatementBuilder.DropIndex(name)
+}
+
+// CreateSequence returns a new CreateSequenceBuilder with the given sequence
+// name.
+//
+// See CreateSequenceBuilder.Name.
+func CreateSequence(name string) *CreateSequenceBuilder {
+	return StatementBuilder.CreateSequence(name)
+}
+
+// CommentOn returns a new CommentBuilder setting the comment of the object
+// of type object, like TABLE or VIEW, named name.
+// Ex:
+//     CommentOn("TABLE", "users", "Registered users, see the user's guide")
+//     == "COMMENT ON TABLE users IS 'Registered users, see the user''s guide'"
+func CommentOn(object, name, text string) *CommentBuilder {
+	return StatementBuilder.CommentOn(object, name, text)
+}
+
+// CommentOnColumn returns a new CommentBuilder setting the comment of the
+// column of table.
+func CommentOnColumn(table, column, text string) *CommentBuilder {
+	return StatementBuilder.CommentOnColumn(table, column, text)
+}
+
+// LockTable returns a new LockTableBuilder locking the given tables.
+//
+// See LockTableBuilder.In.
+func LockTable(tables ...string) *LockTableBuilder {
+	return StatementBuilder.LockTable(tables...)
+}
+
+// Set returns a new SetBuilder setting the session setting name to values.
+// Ex:
+//     Set("statement_timeout", "5s").Local()
+//     == "SET LOCAL statement_timeout = '5s'"
+func Set(name string, values ...interface{}) *SetBuilder {
+	return StatementBuilder.Set(name, values...)
+}
+
+// Show returns a new ShowBuilder reading the session setting name.
+func Show(name string) *ShowBuilder {
+	return StatementBuilder.Show(name)
+}
+
 // Case returns a new CaseBuilder
 // "what" represents case value
 func Case(what ...interface{}) *CaseBuilder {
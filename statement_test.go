@@ -1,8 +1,10 @@
 package sqrl
 
 import (
+	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -43,3 +45,85 @@ func TestRunWithTx(t *testing.T) {
 		Delete("t").RunWith(tx)
 	}, "RunWith(*sql.Tx) should not panic")
 }
+
+func TestRunWithConn(t *testing.T) {
+	conn := &sql.Conn{}
+	assert.NotPanics(t, func() {
+		Select().RunWith(conn)
+		Insert("t").RunWith(conn)
+		Update("t").RunWith(conn)
+		Delete("t").RunWith(conn)
+	}, "RunWith(*sql.Conn) should not panic")
+
+	b := Select("test").RunWith(conn)
+	_, ok := b.runWith.(QueryRowerContext)
+	assert.True(t, ok, "RunWith(*sql.Conn) should support QueryRow")
+}
+
+// contextRunnerStub only implements BaseRunnerContext.
+type contextRunnerStub struct {
+	db *DBStub
+}
+
+func (s contextRunnerStub) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return s.db.ExecContext(ctx, query, args...)
+}
+
+func (s contextRunnerStub) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, query, args...)
+}
+
+func TestRunWithContextRunner(t *testing.T) {
+	db := &DBStub{}
+	b := Select("test").RunWith(contextRunnerStub{db})
+
+	b.ExecContext(context.Background())
+	assert.Equal(t, "SELECT test", db.LastExecSql)
+
+	err := b.QueryRowContext(context.Background()).Scan()
+	assert.Equal(t, ErrRunnerNotQueryRunnerContext, err)
+}
+
+// deadlineRunner records whether the context of the last query had a deadline.
+type deadlineRunner struct {
+	CaptureRunner
+	hasDeadline bool
+}
+
+func (r *deadlineRunner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	_, r.hasDeadline = ctx.Deadline()
+	return r.CaptureRunner.ExecContext(ctx, query, args...)
+}
+
+func (r *deadlineRunner) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	_, r.hasDeadline = ctx.Deadline()
+	return r.CaptureRunner.QueryContext(ctx, query, args...)
+}
+
+func (r *deadlineRunner) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	_, r.hasDeadline = ctx.Deadline()
+	return &RowStub{}
+}
+
+func TestTimeout(t *testing.T) {
+	runner := &deadlineRunner{}
+
+	Update("t").Set("a", 1).RunWith(runner).Exec()
+	assert.False(t, runner.hasDeadline)
+
+	Update("t").Set("a", 1).RunWith(runner).Timeout(time.Second).Exec()
+	assert.True(t, runner.hasDeadline)
+
+	Delete("t").RunWith(runner).Timeout(time.Second).ExecAffected(context.Background())
+	assert.True(t, runner.hasDeadline)
+
+	Insert("t").Values(1).RunWith(runner).Timeout(time.Second).Query()
+	assert.True(t, runner.hasDeadline)
+
+	err := Select("a").From("t").RunWith(runner).Timeout(time.Second).Scan()
+	assert.NoError(t, err)
+	assert.True(t, runner.hasDeadline)
+
+	StatementBuilder.Timeout(time.Second).Select("a").RunWith(runner).Query()
+	assert.True(t, runner.hasDeadline)
+}
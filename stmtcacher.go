@@ -1,6 +1,7 @@
 package sqrl
 
 import (
+	"container/list"
 	"context"
 	"database/sql"
 	"sync"
@@ -12,6 +13,14 @@ import (
 // Prepare executes the given query as implemented by database/sql.PrepareContext.
 type Preparer interface {
 	Prepare(query string) (*sql.Stmt, error)
+	PreparerContext
+}
+
+// PreparerContext is the interface that wraps the PrepareContext method.
+//
+// PrepareContext executes the given query as implemented by database/sql.PrepareContext.
+// It is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type PreparerContext interface {
 	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
 }
 
@@ -26,70 +35,184 @@ type DBProxy interface {
 	QueryRowerContext
 }
 
-type stmtCacher struct {
-	prep  Preparer
-	cache map[string]*sql.Stmt
+// StmtCacher is a DBProxy which caches prepared statements.
+type StmtCacher struct {
+	prep  PreparerContext
+	cache map[string]*list.Element
+	lru   *list.List
+	limit int
 	mu    sync.Mutex
 }
 
+// cachedStmt is a statement of a StmtCacher. refs counts the queries being
+// run with it, an evicted statement is closed once they finished.
+type cachedStmt struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
 // NewStmtCacher returns a DBProxy wrapping prep that caches Prepared Stmts.
 //
 // Stmts are cached based on the string value of their queries.
-func NewStmtCacher(prep Preparer) DBProxy {
-	return &stmtCacher{prep: prep, cache: make(map[string]*sql.Stmt)}
+func NewStmtCacher(prep PreparerContext) DBProxy {
+	return NewLimitedStmtCacher(prep, 0)
+}
+
+// NewLimitedStmtCacher returns a StmtCacher which keeps at most limit
+// statements. The least recently used statement is closed when the limit is
+// exceeded, or once the queries being run with it finished. A limit of 0 or
+// less keeps all statements.
+//
+// Statements returned by Prepare may be closed when they are evicted, so
+// run queries with the Exec and Query methods of the StmtCacher when it is
+// shared by goroutines.
+func NewLimitedStmtCacher(prep PreparerContext, limit int) *StmtCacher {
+	return &StmtCacher{
+		prep:  prep,
+		cache: make(map[string]*list.Element),
+		lru:   list.New(),
+		limit: limit,
+	}
+}
+
+func (sc *StmtCacher) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	cached, err := sc.prepare(ctx, query, false)
+	if err != nil {
+		return nil, err
+	}
+	return cached.stmt, nil
+}
+
+// acquire returns the statement of query, which isn't closed before it is
+// passed to release.
+func (sc *StmtCacher) acquire(ctx context.Context, query string) (*cachedStmt, error) {
+	return sc.prepare(ctx, query, true)
+}
+
+// release closes cached if it was evicted and no more queries are run with
+// it.
+func (sc *StmtCacher) release(cached *cachedStmt) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	cached.refs--
+	if cached.evicted && cached.refs == 0 {
+		closeStmt(cached.stmt)
+	}
 }
 
-func (sc *stmtCacher) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+func (sc *StmtCacher) prepare(ctx context.Context, query string, ref bool) (*cachedStmt, error) {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
-	stmt, ok := sc.cache[query]
-	if ok {
-		return stmt, nil
+	if elem, ok := sc.cache[query]; ok {
+		sc.lru.MoveToFront(elem)
+		cached := elem.Value.(*cachedStmt)
+		if ref {
+			cached.refs++
+		}
+		return cached, nil
 	}
 	stmt, err := sc.prep.PrepareContext(ctx, query)
-	if err == nil {
-		sc.cache[query] = stmt
+	if err != nil {
+		return nil, err
+	}
+
+	cached := &cachedStmt{query: query, stmt: stmt}
+	if ref {
+		cached.refs++
+	}
+	sc.cache[query] = sc.lru.PushFront(cached)
+	for sc.limit > 0 && sc.lru.Len() > sc.limit {
+		sc.evict(sc.lru.Back())
+	}
+	return cached, nil
+}
+
+// evict removes elem from the cache and closes its statement, unless
+// queries are still run with it.
+func (sc *StmtCacher) evict(elem *list.Element) error {
+	cached := sc.lru.Remove(elem).(*cachedStmt)
+	delete(sc.cache, cached.query)
+	cached.evicted = true
+	if cached.refs > 0 {
+		return nil
+	}
+	return closeStmt(cached.stmt)
+}
+
+// Len returns the number of cached statements.
+func (sc *StmtCacher) Len() int {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.lru.Len()
+}
+
+// Close closes all cached statements and empties the cache. Statements
+// queries are being run with are closed once they finished. The StmtCacher
+// can still be used afterwards.
+func (sc *StmtCacher) Close() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	var firstErr error
+	for sc.lru.Len() > 0 {
+		if err := sc.evict(sc.lru.Front()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func closeStmt(stmt *sql.Stmt) error {
+	if stmt == nil {
+		return nil
 	}
-	return stmt, err
+	return stmt.Close()
 }
 
-func (sc *stmtCacher) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
-	stmt, err := sc.PrepareContext(ctx, query)
+func (sc *StmtCacher) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
+	cached, err := sc.acquire(ctx, query)
 	if err != nil {
 		return
 	}
-	return stmt.ExecContext(ctx, args...)
+	defer sc.release(cached)
+	return cached.stmt.ExecContext(ctx, args...)
 }
 
-func (sc *stmtCacher) QueryContext(ctx context.Context, query string, args ...interface{}) (rows *sql.Rows, err error) {
-	stmt, err := sc.PrepareContext(ctx, query)
+// QueryContext runs query with its cached statement. Rows keep the
+// statement open until they are closed, so it is released on return.
+func (sc *StmtCacher) QueryContext(ctx context.Context, query string, args ...interface{}) (rows *sql.Rows, err error) {
+	cached, err := sc.acquire(ctx, query)
 	if err != nil {
 		return
 	}
-	return stmt.QueryContext(ctx, args...)
+	defer sc.release(cached)
+	return cached.stmt.QueryContext(ctx, args...)
 }
 
-func (sc *stmtCacher) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
-	stmt, err := sc.PrepareContext(ctx, query)
+func (sc *StmtCacher) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	cached, err := sc.acquire(ctx, query)
 	if err != nil {
 		return &Row{err: err}
 	}
-	return stmt.QueryRowContext(ctx, args...)
+	defer sc.release(cached)
+	return cached.stmt.QueryRowContext(ctx, args...)
 }
 
-func (sc *stmtCacher) Prepare(query string) (*sql.Stmt, error) {
+func (sc *StmtCacher) Prepare(query string) (*sql.Stmt, error) {
 	return sc.PrepareContext(context.Background(), query)
 }
 
-func (sc *stmtCacher) Exec(query string, args ...interface{}) (res sql.Result, err error) {
+func (sc *StmtCacher) Exec(query string, args ...interface{}) (res sql.Result, err error) {
 	return sc.ExecContext(context.Background(), query, args...)
 }
 
-func (sc *stmtCacher) Query(query string, args ...interface{}) (rows *sql.Rows, err error) {
+func (sc *StmtCacher) Query(query string, args ...interface{}) (rows *sql.Rows, err error) {
 	return sc.QueryContext(context.Background(), query, args...)
 }
 
-func (sc *stmtCacher) QueryRow(query string, args ...interface{}) RowScanner {
+func (sc *StmtCacher) QueryRow(query string, args ...interface{}) RowScanner {
 	return sc.QueryRowContext(context.Background(), query, args...)
 }
 
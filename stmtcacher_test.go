@@ -1,6 +1,7 @@
 package sqrl
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,3 +18,59 @@ func TestStmtCacherPrepare(t *testing.T) {
 	sc.Prepare(query)
 	assert.Equal(t, 1, db.PrepareCount, "expected 1 Prepare, got %d", db.PrepareCount)
 }
+
+func TestLimitedStmtCacher(t *testing.T) {
+	db := &DBStub{}
+	sc := NewLimitedStmtCacher(db, 2)
+
+	sc.Prepare("SELECT 1")
+	sc.Prepare("SELECT 2")
+	sc.Prepare("SELECT 1")
+	sc.Prepare("SELECT 3")
+	assert.Equal(t, 3, db.PrepareCount)
+	assert.Equal(t, 2, sc.Len())
+
+	// SELECT 2 was least recently used and has been evicted
+	sc.Prepare("SELECT 1")
+	assert.Equal(t, 3, db.PrepareCount)
+	sc.Prepare("SELECT 2")
+	assert.Equal(t, 4, db.PrepareCount)
+
+	assert.NoError(t, sc.Close())
+	assert.Equal(t, 0, sc.Len())
+
+	sc.Prepare("SELECT 1")
+	assert.Equal(t, 5, db.PrepareCount)
+}
+
+func TestStmtCacherRunWith(t *testing.T) {
+	sc := NewLimitedStmtCacher(&DBStub{}, 10)
+	b := Select("test").RunWith(sc)
+
+	_, ok := b.runWith.(QueryRowerContext)
+	assert.True(t, ok, "StmtCacher should be usable with QueryRow")
+}
+
+func TestLimitedStmtCacherEvictInUse(t *testing.T) {
+	d := &stubDriver{}
+	db := openStubDB(d)
+	defer db.Close()
+	sc := NewLimitedStmtCacher(db, 1)
+	ctx := context.Background()
+
+	cached, err := sc.acquire(ctx, "DELETE FROM a")
+	assert.NoError(t, err)
+
+	// evicts DELETE FROM a while it is in use
+	_, err = sc.ExecContext(ctx, "DELETE FROM b")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sc.Len())
+
+	_, err = cached.stmt.ExecContext(ctx)
+	assert.NoError(t, err)
+	sc.release(cached)
+
+	_, err = cached.stmt.ExecContext(ctx)
+	assert.Error(t, err, "evicted statement should be closed once released")
+	assert.Equal(t, []string{"DELETE FROM b", "DELETE FROM a"}, d.Log())
+}
@@ -0,0 +1,137 @@
+package sqrl
+
+import "fmt"
+
+// StrictPlaceholders makes child builders verify on ToSql that the raw SQL
+// fragments passed to them, e.g. to Where, Expr, Prefix or Suffix, have as
+// many ? placeholders as args.
+func (b StatementBuilderType) StrictPlaceholders() StatementBuilderType {
+	b.strictPlaceholders = true
+	return b
+}
+
+// countPlaceholders returns the number of ? placeholders in sql, not
+// counting escaped ?? placeholders and those in quotes.
+func countPlaceholders(sql string) int {
+	count := 0
+	for i := 0; i < len(sql); i++ {
+		if n := quotedLen(sql, i, ""); n > 0 {
+			i += n - 1
+			continue
+		}
+		if sql[i] != '?' {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == '?' {
+			i++
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// checkFragment returns an error if sql doesn't have a placeholder for every
+// arg.
+func checkFragment(sql string, args []interface{}) error {
+	if n := countPlaceholders(sql); n != len(args) {
+		return fmt.Errorf("%q has %d placeholders but %d args", sql, n, len(args))
+	}
+	for _, arg := range args {
+		if s, ok := arg.(Sqlizer); ok {
+			if err := checkSqlizer(s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkPlaceholders checks the raw SQL fragments of parts, which can be
+// Sqlizers, slices of them or values of an INSERT or SET clause.
+func checkPlaceholders(parts ...interface{}) error {
+	for _, p := range parts {
+		switch p := p.(type) {
+		case exprs:
+			for _, e := range p {
+				if err := checkSqlizer(e); err != nil {
+					return err
+				}
+			}
+		case []Sqlizer:
+			for _, s := range p {
+				if err := checkSqlizer(s); err != nil {
+					return err
+				}
+			}
+		case Sqlizer:
+			if err := checkSqlizer(p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkSqlizer checks the raw SQL fragments of s. Sqlizers generating their
+// own placeholders, like Eq, are correct by construction.
+func checkSqlizer(s Sqlizer) error {
+	switch s := s.(type) {
+	case expr:
+		return checkFragment(s.sql, s.args)
+	case *wherePart:
+		if sql, ok := s.pred.(string); ok {
+			return checkFragment(sql, s.args)
+		}
+		if pred, ok := s.pred.(Sqlizer); ok {
+			return checkSqlizer(pred)
+		}
+	case *part:
+		if sql, ok := s.pred.(string); ok {
+			return checkFragment(sql, s.args)
+		}
+		if pred, ok := s.pred.(Sqlizer); ok {
+			return checkSqlizer(pred)
+		}
+	case And:
+		return checkPlaceholders([]Sqlizer(s))
+	case Or:
+		return checkPlaceholders([]Sqlizer(s))
+	case not:
+		return checkSqlizer(s.pred)
+	case aliasExpr:
+		return checkSqlizer(s.expr)
+	case subQuery:
+		return checkPlaceholders(s.sb.placeholderParts()...)
+	case *SelectBuilder:
+		return checkPlaceholders(s.placeholderParts()...)
+	}
+	return nil
+}
+
+func (b *SelectBuilder) placeholderParts() []interface{} {
+	return []interface{}{b.prefixes, b.columns, b.fromParts, b.joins, b.whereParts, b.havingParts, b.suffixes}
+}
+
+func (b *InsertBuilder) placeholderParts() []interface{} {
+	parts := []interface{}{b.prefixes, b.suffixes}
+	if b.iselect != nil {
+		parts = append(parts, b.iselect)
+	}
+	for _, row := range b.values {
+		parts = append(parts, row...)
+	}
+	return parts
+}
+
+func (b *UpdateBuilder) placeholderParts() []interface{} {
+	parts := []interface{}{b.prefixes, b.fromParts, b.joins, b.whereParts, b.suffixes}
+	for _, c := range b.setClauses {
+		parts = append(parts, c.value)
+	}
+	return parts
+}
+
+func (b *DeleteBuilder) placeholderParts() []interface{} {
+	return []interface{}{b.prefixes, b.joins, b.usingParts, b.whereParts, b.suffixes}
+}
@@ -0,0 +1,50 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictPlaceholders(t *testing.T) {
+	sb := StatementBuilder.StrictPlaceholders()
+
+	_, _, err := sb.Select("*").From("t").Where("a = ? AND b = ?", 1).ToSql()
+	assert.EqualError(t, err, `"a = ? AND b = ?" has 2 placeholders but 1 args`)
+
+	_, _, err = sb.Select("*").From("t").Where("a = ?", 1, 2).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Select("*").From("t").Where(Or{Eq{"a": 1}, Expr("b > ?")}).ToSql()
+	assert.EqualError(t, err, `"b > ?" has 1 placeholders but 0 args`)
+
+	_, _, err = sb.Update("t").Set("a", Expr("a + ?")).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Insert("t").Values(1).Suffix("RETURNING ?").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Delete("t").Prefix("WITH x AS (SELECT ?)", 1, 2).ToSql()
+	assert.Error(t, err)
+
+	sub := Select("id").From("u").Where("c = ?")
+	_, _, err = Select("*").From("t").Where(Expr("id IN ?", sub)).StrictPlaceholders().ToSql()
+	assert.EqualError(t, err, `"c = ?" has 1 placeholders but 0 args`)
+}
+
+func TestStrictPlaceholdersValid(t *testing.T) {
+	sql, args, err := Select("*").From("t").
+		Where("a = ? AND data ?? 'k'", 1).
+		Where(Not(Expr("b IN ?", Select("b").From("u").Where("c = ?", 2)))).
+		StrictPlaceholders().
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE a = ? AND data ?? 'k' AND NOT (b IN SELECT b FROM u WHERE c = ?)", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	_, _, err = Select("*").From("t").Where("a = ? AND b <> '?' AND \"c?\" = 1", 1).StrictPlaceholders().ToSql()
+	assert.NoError(t, err, "quoted ? are no placeholders")
+
+	_, _, err = Select("*").From("t").Where("a = ?").ToSql()
+	assert.NoError(t, err, "placeholders are only checked in strict mode")
+}
@@ -0,0 +1,44 @@
+package sqrl
+
+import "strings"
+
+// TableHint is a SQL Server table hint, see Hinted.
+type TableHint string
+
+// SQL Server table hints.
+const (
+	NoLock         TableHint = "NOLOCK"
+	ReadPast       TableHint = "READPAST"
+	ReadCommitted  TableHint = "READCOMMITTED"
+	RepeatableRead TableHint = "REPEATABLEREAD"
+	Serializable   TableHint = "SERIALIZABLE"
+	HoldLock       TableHint = "HOLDLOCK"
+	UpdLock        TableHint = "UPDLOCK"
+	XLock          TableHint = "XLOCK"
+	RowLock        TableHint = "ROWLOCK"
+	PagLock        TableHint = "PAGLOCK"
+	TabLock        TableHint = "TABLOCK"
+	NoWait         TableHint = "NOWAIT"
+)
+
+// IndexHint returns a TableHint forcing the use of indexes.
+func IndexHint(indexes ...string) TableHint {
+	return TableHint("INDEX(" + strings.Join(indexes, ",") + ")")
+}
+
+// Hinted adds a SQL Server WITH table hint to table, which may have an
+// alias, to be passed to From, Update or the join methods of SelectBuilder.
+// Ex:
+//     Select("*").From(Hinted("users u", NoLock)).
+//         Join(Hinted("orders o", UpdLock, RowLock) + " ON o.user_id = u.id")
+//     == "SELECT * FROM users u WITH (NOLOCK) JOIN orders o WITH (UPDLOCK, ROWLOCK) ON o.user_id = u.id"
+func Hinted(table string, hints ...TableHint) string {
+	if len(hints) == 0 {
+		return table
+	}
+	s := make([]string, len(hints))
+	for i, hint := range hints {
+		s[i] = string(hint)
+	}
+	return table + " WITH (" + strings.Join(s, ", ") + ")"
+}
@@ -0,0 +1,28 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHinted(t *testing.T) {
+	assert.Equal(t, "orders", Hinted("orders"))
+	assert.Equal(t, "orders o WITH (NOLOCK)", Hinted("orders o", NoLock))
+	assert.Equal(t, "orders WITH (INDEX(idx_user,idx_date), HOLDLOCK)", Hinted("orders", IndexHint("idx_user", "idx_date"), HoldLock))
+}
+
+func TestHintedSelect(t *testing.T) {
+	sql, args, err := StatementBuilder.Dialect(SQLServer).Select("*").
+		From(Hinted("users u", NoLock)).
+		Join(Hinted("orders o", UpdLock, RowLock)+" ON o.user_id = u.id").
+		Where("u.id = ?", 1).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users u WITH (NOLOCK) JOIN orders o WITH (UPDLOCK, ROWLOCK) ON o.user_id = u.id WHERE u.id = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = StatementBuilder.Dialect(SQLServer).Update(Hinted("orders", RowLock)).Set("status", "paid").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders WITH (ROWLOCK) SET status = ?", sql)
+}
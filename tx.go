@@ -0,0 +1,83 @@
+package sqrl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// TxBeginner is the interface that wraps the BeginTx method.
+//
+// BeginTx starts a transaction as implemented by database/sql.DB.BeginTx.
+// It is implemented by *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// WithTx begins a transaction with db and returns a StatementBuilderType
+// running all child builders with it, along with a function ending the
+// transaction: it commits if passed a nil error and rolls back otherwise,
+// returning the error passed or the error of the commit.
+// Ex:
+//     sb, done, err := StatementBuilder.WithTx(ctx, db, nil)
+//     if err != nil {
+//         return err
+//     }
+//     defer func() { err = done(err) }()
+//     _, err = sb.Update("accounts").Set("balance", Expr("balance - ?", 10)).Where(Eq{"id": 1}).Exec()
+func (b StatementBuilderType) WithTx(ctx context.Context, db TxBeginner, opts *sql.TxOptions) (StatementBuilderType, func(error) error, error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return b, nil, err
+	}
+
+	done := func(err error) error {
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}
+	return b.RunWith(tx), done, nil
+}
+
+// RunInTx runs fn in a transaction begun with db, passing it a
+// StatementBuilderType running all child builders with the transaction.
+// The transaction is committed if fn returns nil and rolled back if it
+// returns an error or panics.
+// Ex:
+//     err := StatementBuilder.RunInTx(ctx, db, nil, func(sb StatementBuilderType) error {
+//         _, err := sb.Insert("audit").Values("transfer").Exec()
+//         return err
+//     })
+func (b StatementBuilderType) RunInTx(ctx context.Context, db TxBeginner, opts *sql.TxOptions, fn func(StatementBuilderType) error) (err error) {
+	sb, done, err := b.WithTx(ctx, db, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			done(errTxPanic)
+			panic(p)
+		}
+	}()
+
+	return done(fn(sb))
+}
+
+var errTxPanic = errors.New("panic in transaction")
+
+// WithTx begins a transaction with db using StatementBuilder.
+//
+// See StatementBuilderType.WithTx.
+func WithTx(ctx context.Context, db TxBeginner, opts *sql.TxOptions) (StatementBuilderType, func(error) error, error) {
+	return StatementBuilder.WithTx(ctx, db, opts)
+}
+
+// RunInTx runs fn in a transaction begun with db using StatementBuilder.
+//
+// See StatementBuilderType.RunInTx.
+func RunInTx(ctx context.Context, db TxBeginner, opts *sql.TxOptions, fn func(StatementBuilderType) error) error {
+	return StatementBuilder.RunInTx(ctx, db, opts, fn)
+}
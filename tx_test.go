@@ -0,0 +1,64 @@
+package sqrl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTx(t *testing.T) {
+	d := &stubDriver{}
+	db := openStubDB(d)
+	defer db.Close()
+
+	sb, done, err := StatementBuilder.PlaceholderFormat(Dollar).WithTx(context.Background(), db, nil)
+	assert.NoError(t, err)
+
+	_, err = sb.Update("t").Set("a", 1).Exec()
+	assert.NoError(t, err)
+	assert.NoError(t, done(nil))
+
+	assert.Equal(t, []string{"BEGIN", "UPDATE t SET a = $1", "COMMIT"}, d.Log())
+}
+
+func TestRunInTx(t *testing.T) {
+	d := &stubDriver{}
+	db := openStubDB(d)
+	defer db.Close()
+
+	err := RunInTx(context.Background(), db, nil, func(sb StatementBuilderType) error {
+		_, err := sb.Delete("t").Exec()
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BEGIN", "DELETE FROM t", "COMMIT"}, d.Log())
+}
+
+func TestRunInTxRollback(t *testing.T) {
+	d := &stubDriver{}
+	db := openStubDB(d)
+	defer db.Close()
+
+	fnErr := errors.New("fail")
+	err := RunInTx(context.Background(), db, nil, func(sb StatementBuilderType) error {
+		sb.Insert("t").Values(1).Exec()
+		return fnErr
+	})
+	assert.Equal(t, fnErr, err)
+	assert.Equal(t, []string{"BEGIN", "INSERT INTO t VALUES (?)", "ROLLBACK"}, d.Log())
+}
+
+func TestRunInTxPanic(t *testing.T) {
+	d := &stubDriver{}
+	db := openStubDB(d)
+	defer db.Close()
+
+	assert.Panics(t, func() {
+		RunInTx(context.Background(), db, nil, func(sb StatementBuilderType) error {
+			panic("boom")
+		})
+	})
+	assert.Equal(t, []string{"BEGIN", "ROLLBACK"}, d.Log())
+}
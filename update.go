@@ -1,13 +1,14 @@
 package sqrl
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type setClause struct {
@@ -37,15 +38,17 @@ type UpdateBuilder struct {
 	offsetValid bool
 
 	suffixes exprs
+
+	memo *memo
 }
 
 // NewUpdateBuilder creates new instance of UpdateBuilder
 func NewUpdateBuilder(b StatementBuilderType) *UpdateBuilder {
-	return &UpdateBuilder{StatementBuilderType: b}
+	return &UpdateBuilder{StatementBuilderType: b, memo: newMemo(b.memoize)}
 }
 
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
-func (b *UpdateBuilder) RunWith(runner BaseRunner) *UpdateBuilder {
+func (b *UpdateBuilder) RunWith(runner BaseRunnerContext) *UpdateBuilder {
 	b.runWith = wrapRunner(runner)
 	return b
 }
@@ -60,9 +63,24 @@ func (b *UpdateBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 	if b.runWith == nil {
 		return nil, ErrRunnerNotSet
 	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
 	return ExecWithContext(ctx, b.runWith, b)
 }
 
+// ExecAffected builds and Execs the query with the Runner set by RunWith
+// using given context and returns the number of rows affected.
+//
+// See ExecAffectedWithContext.
+func (b *UpdateBuilder) ExecAffected(ctx context.Context) (int64, error) {
+	if b.runWith == nil {
+		return 0, ErrRunnerNotSet
+	}
+	ctx, cancel := b.timeoutContext(ctx)
+	defer cancel()
+	return ExecAffectedWithContext(ctx, b.runWith, b)
+}
+
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b *UpdateBuilder) Query() (*sql.Rows, error) {
 	return b.QueryContext(context.Background())
@@ -73,7 +91,7 @@ func (b *UpdateBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
 	if b.runWith == nil {
 		return nil, ErrRunnerNotSet
 	}
-	return QueryWithContext(ctx, b.runWith, b)
+	return QueryWithContext(b.queryTimeoutContext(ctx), b.runWith, b)
 }
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
@@ -90,7 +108,8 @@ func (b *UpdateBuilder) QueryRowContext(ctx context.Context) RowScanner {
 	if !ok {
 		return &Row{err: ErrRunnerNotQueryRunnerContext}
 	}
-	return QueryRowWithContext(ctx, queryRower, b)
+	ctx, cancel := b.timeoutContext(ctx)
+	return &timeoutRow{RowScanner: QueryRowWithContext(ctx, queryRower, b), cancel: cancel}
 }
 
 // Scan is a shortcut for QueryRow().Scan.
@@ -98,6 +117,57 @@ func (b *UpdateBuilder) Scan(dest ...interface{}) error {
 	return b.QueryRow().Scan(dest...)
 }
 
+// Timeout sets a timeout for running the query, applied to the context
+// passed to e.g. ExecContext. For Query the timeout covers reading the rows.
+func (b *UpdateBuilder) Timeout(d time.Duration) *UpdateBuilder {
+	b.timeout = d
+	return b
+}
+
+// StrictPlaceholders makes ToSql verify that the raw SQL fragments of the
+// query have as many ? placeholders as args.
+func (b *UpdateBuilder) StrictPlaceholders() *UpdateBuilder {
+	b.strictPlaceholders = true
+	return b
+}
+
+// StrictIdentifiers makes ToSql verify that the table and column names of
+// the query are identifiers, and one of allowed if given.
+//
+// See StatementBuilderType.StrictIdentifiers.
+func (b *UpdateBuilder) StrictIdentifiers(allowed ...string) *UpdateBuilder {
+	b.strictIdentifiers = true
+	b.allowedIdentifiers = identifierSet(allowed)
+	return b
+}
+
+// Dialect sets the Dialect of the database the query is run on.
+//
+// See StatementBuilderType.Dialect.
+func (b *UpdateBuilder) Dialect(d Dialect) *UpdateBuilder {
+	b.setDialect(d)
+	return b
+}
+
+// QuoteIdentifiers makes the query quote its table and column names.
+//
+// See StatementBuilderType.QuoteIdentifiers.
+func (b *UpdateBuilder) QuoteIdentifiers() *UpdateBuilder {
+	b.quoteIdentifiers = true
+	return b
+}
+
+// Memoize makes ToSql cache the query until it is changed.
+//
+// See StatementBuilderType.Memoize.
+func (b *UpdateBuilder) Memoize() *UpdateBuilder {
+	b.memoize = true
+	if b.memo == nil {
+		b.memo = newMemo(true)
+	}
+	return b
+}
+
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b *UpdateBuilder) PlaceholderFormat(f PlaceholderFormat) *UpdateBuilder {
@@ -105,8 +175,44 @@ func (b *UpdateBuilder) PlaceholderFormat(f PlaceholderFormat) *UpdateBuilder {
 	return b
 }
 
+// ToSqlNamed builds the query into a SQL string with named placeholders.
+//
+// See ToSqlNamed.
+func (b *UpdateBuilder) ToSqlNamed(f NamedFormat) (string, []sql.NamedArg, error) {
+	nb := *b
+	nb.placeholderFormat = Question
+	return ToSqlNamed(&nb, f)
+}
+
 // ToSql builds the query into a SQL string and bound args.
-func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
+func (b *UpdateBuilder) ToSql() (string, []interface{}, error) {
+	if b.memoize {
+		return memoized(b.memo, b, b.toSql)
+	}
+	return b.toSql()
+}
+
+func (b *UpdateBuilder) toSql() (string, []interface{}, error) {
+	sql := getQueryBuffer(b.placeholderFormat)
+	defer putQueryBuffer(sql)
+
+	args := makeArgs(countExprArgs(b.prefixes) + countArgs(b.fromParts, b.joins, b.whereParts) + len(b.setClauses) + countExprArgs(b.suffixes))
+	args, err := appendQuery(sql, args, b.dialect, b.appendToSql)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql.finish(args)
+}
+
+// AppendToSql writes the query to w and appends its args to args, to build
+// it into a larger statement without an intermediate string. Placeholders
+// are written as ?, to be replaced by the enclosing statement.
+func (b *UpdateBuilder) AppendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
+	return appendQuery(w, args, b.dialect, b.appendToSql)
+}
+
+func (b *UpdateBuilder) appendToSql(sql *queryBuffer, in []interface{}) (args []interface{}, err error) {
+	args = in
 	if len(b.table) == 0 {
 		err = fmt.Errorf("update statements must specify a table")
 		return
@@ -115,16 +221,27 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 		err = fmt.Errorf("update statements must have at least one Set clause")
 		return
 	}
-
-	sql := &bytes.Buffer{}
+	if b.strictPlaceholders {
+		if err = checkPlaceholders(b.placeholderParts()...); err != nil {
+			return
+		}
+	}
+	if b.strictIdentifiers {
+		if err = b.checkIdentifiers(); err != nil {
+			return
+		}
+	}
 
 	if len(b.prefixes) > 0 {
-		args, _ = b.prefixes.AppendToSql(sql, " ", args)
+		args, err = b.prefixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
 	sql.WriteString("UPDATE ")
-	sql.WriteString(b.table)
+	sql.WriteString(b.quoteName(b.table, identAliased))
 
 	sql.WriteString(" SET ")
 	setSqls := make([]string, len(b.setClauses))
@@ -133,7 +250,7 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 		switch typedVal := setClause.value.(type) {
 		case Sqlizer:
 			var valArgs []interface{}
-			valSql, valArgs, err = typedVal.ToSql()
+			valSql, valArgs, err = withDialect(typedVal, b.dialect).ToSql()
 			if err != nil {
 				return
 			}
@@ -142,13 +259,13 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 			valSql = "?"
 			args = append(args, typedVal)
 		}
-		setSqls[i] = fmt.Sprintf("%s = %s", setClause.column, valSql)
+		setSqls[i] = b.quoteName(setClause.column, identName) + " = " + valSql
 	}
 	sql.WriteString(strings.Join(setSqls, ", "))
 
 	if len(b.fromParts) > 0 {
 		sql.WriteString(" FROM ")
-		args, err = appendToSql(b.fromParts, sql, ", ", args)
+		args, err = appendToSql(b.quoteParts(b.fromParts, identAliased), sql, ", ", args)
 		if err != nil {
 			return
 		}
@@ -173,7 +290,7 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if len(b.orderBys) > 0 {
 		sql.WriteString(" ORDER BY ")
-		sql.WriteString(strings.Join(b.orderBys, ", "))
+		sql.WriteString(strings.Join(b.quoteNames(b.orderBys, identOrderBy), ", "))
 	}
 
 	// TODO: limit == 0 and offswt == 0 are valid. Need to go dbr way and implement offsetValid and limitValid
@@ -188,6 +305,10 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 	}
 
 	if len(b.returning) > 0 {
+		if !supports(b.dialect, FeatureReturning) {
+			err = fmt.Errorf("%s does not support RETURNING", b.dialect.Name())
+			return
+		}
 		args, err = b.returning.AppendToSql(sql, args)
 		if err != nil {
 			return
@@ -196,10 +317,12 @@ func (b *UpdateBuilder) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = b.suffixes.AppendToSql(sql, " ", args)
+		args, err = b.suffixes.AppendToSql(sql, " ", args)
+		if err != nil {
+			return
+		}
 	}
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
 	return
 }
 
@@ -344,6 +467,7 @@ func (b *UpdateBuilder) Copy() *UpdateBuilder {
 	vb := *b
 	// ... then make a shallow copy
 	nb := vb
+	nb.memo = newMemo(nb.memoize)
 
 	// Then copy all reference types of the struct to make a deep copy
 	nb.returning = make(returning, len(vb.returning))
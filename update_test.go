@@ -173,3 +173,17 @@ func TestUpdateCopy(t *testing.T) {
 	expectedArgs = []interface{}{1, 3}
 	assert.Equal(t, expectedArgs, args)
 }
+
+func BenchmarkUpdateBuilderToSqlAllocs(b *testing.B) {
+	qb := Update("users").
+		Set("name", "Joe").
+		Set("updated_at", Expr("NOW()")).
+		Where(Eq{"id": 1}).
+		PlaceholderFormat(Dollar)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		qb.ToSql()
+	}
+}
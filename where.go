@@ -24,3 +24,13 @@ func (p wherePart) ToSql() (sql string, args []interface{}, err error) {
 	}
 	return
 }
+
+func (p wherePart) appendPart(w sqlWriter, sep string, args []interface{}) ([]interface{}, bool, error) {
+	switch pred := p.pred.(type) {
+	case map[string]interface{}:
+		return writePart(w, sep, Eq(pred), args)
+	case nil, Sqlizer, string:
+		return part(p).appendPart(w, sep, args)
+	}
+	return nil, false, fmt.Errorf("expected string-keyed map or string, not %T", p.pred)
+}